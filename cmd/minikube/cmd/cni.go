@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/cni"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+// cniCmd represents the set of cni subcommands
+var cniCmd = &cobra.Command{
+	Use:   "cni",
+	Short: "Manage the CNI of a running cluster",
+	Long:  "Operations on the Container Networking Interface (CNI) of a running cluster",
+	Run: func(_ *cobra.Command, _ []string) {
+		exit.Message(reason.Usage, "Usage: minikube cni [set]")
+	},
+}
+
+var cniSetCmd = &cobra.Command{
+	Use:   "set <name|manifest>",
+	Short: "Switches a running cluster to another CNI",
+	Long: `Switches a running cluster to another CNI, without deleting it.
+The current CNI is removed from every node, the new one is applied and pods that are not on the host network are restarted.
+Valid options are: auto, bridge, calico, cilium, flannel, kindnet, or path to a CNI manifest.`,
+	Example: "minikube cni set calico",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "Usage: minikube cni set <name|manifest>")
+		}
+
+		co := mustload.Healthy(ClusterFlagValue())
+		cc := co.Config
+
+		if cc.KubernetesConfig.NetworkPlugin != "" && cc.KubernetesConfig.NetworkPlugin != "cni" {
+			exit.Message(reason.Usage, "The {{.plugin}} network plugin does not support CNIs, recreate the cluster with --cni instead", out.V{"plugin": cc.KubernetesConfig.NetworkPlugin})
+		}
+
+		current, err := cni.New(cc)
+		if err != nil {
+			exit.Error(reason.GuestCNI, "Unable to load the current CNI", err)
+		}
+
+		next := *cc
+		next.KubernetesConfig.CNI = args[0]
+		cnm, err := cni.New(&next)
+		if err != nil {
+			exit.Error(reason.Usage, "Invalid CNI", err)
+		}
+		if _, ok := cnm.(cni.Disabled); ok {
+			exit.Message(reason.Usage, "Disabling the CNI of a running cluster is not supported")
+		}
+		if cnm.String() == current.String() {
+			out.Styled(style.Check, "The cluster already uses {{.name}}", out.V{"name": cnm.String()})
+			next.KubernetesConfig.NetworkPlugin = "cni"
+			if err := config.SaveProfile(cc.Name, &next); err != nil {
+				exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+			}
+			return
+		}
+
+		if err := switchCNI(co, current, cnm); err != nil {
+			exit.Error(reason.GuestCNI, "Failed to switch CNI", err)
+		}
+
+		next.KubernetesConfig.NetworkPlugin = "cni"
+		if err := config.SaveProfile(cc.Name, &next); err != nil {
+			exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+		}
+
+		client, err := kapi.Client(cc.Name)
+		if err != nil {
+			exit.Error(reason.InternalKubernetesClient, "kubernetes client", err)
+		}
+		out.Step(style.Restarting, "Restarting pods to attach them to {{.name}} ...", out.V{"name": cnm.String()})
+		if _, err := kapi.RestartPodsWithPodNetwork(client); err != nil {
+			exit.Error(reason.GuestCNI, "Failed to restart pods", err)
+		}
+
+		out.Step(style.Ready, "Cluster {{.cluster}} now uses {{.name}}", out.V{"cluster": cc.Name, "name": cnm.String()})
+	},
+}
+
+// switchCNI removes the current CNI from the cluster and every node, then applies the new one
+func switchCNI(co mustload.ClusterController, current, cnm cni.Manager) error {
+	cc := co.Config
+	if _, ok := current.(cni.Disabled); !ok {
+		out.Step(style.Resetting, "Removing {{.name}} ...", out.V{"name": current.String()})
+		if err := cni.Remove(*cc, current, co.CP.Runner); err != nil {
+			return errors.Wrapf(err, "removing %s", current)
+		}
+	}
+
+	// the configs, interfaces and binaries of the CNI are left on every node, workers included
+	var failed []string
+	for _, n := range co.Config.Nodes {
+		r, err := nodeRunner(co, n)
+		if err == nil {
+			klog.Infof("cleaning up %s on node %q", current, n.Name)
+			err = cni.CleanupNode(r, current)
+		}
+		if err != nil {
+			klog.Warningf("unable to clean up %s on node %q: %v", current, n.Name, err)
+			failed = append(failed, config.MachineName(*cc, n))
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("cleaning up %s on nodes %s", current, strings.Join(failed, ", "))
+	}
+
+	out.Step(style.CNI, "Configuring {{.name}} (Container Networking Interface) ...", out.V{"name": cnm.String()})
+	return cnm.Apply(co.CP.Runner)
+}
+
+// nodeRunner returns a command runner for a node of a running cluster
+func nodeRunner(co mustload.ClusterController, n config.Node) (command.Runner, error) {
+	h, err := machine.GetHost(co.API, *co.Config, n)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting host for node %q", n.Name)
+	}
+	return machine.CommandRunner(h)
+}
+
+func init() {
+	cniCmd.AddCommand(cniSetCmd)
+}
@@ -27,7 +27,10 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
 )
@@ -75,6 +78,30 @@ func printAddonImagesTable(addon string) {
 				tData = append(tData, []string{imageName, defaultImage, conf.Registries[imageName]})
 			}
 
+			if err := table.Bulk(tData); err != nil {
+				klog.Error("Error rendering table (bulk)", err)
+			}
+			if err := table.Render(); err != nil {
+				klog.Error("Error rendering table", err)
+			}
+		} else if conf.Chart != nil {
+			images := chartImages(conf)
+			if len(images) == 0 {
+				out.Infof("{{.name}} doesn't have images.", out.V{"name": addon})
+				return
+			}
+			out.Infof("{{.name}} has the following images:", out.V{"name": addon})
+
+			var tData [][]string
+			table := tablewriter.NewWriter(os.Stdout)
+			table.Header("Image")
+			table.Options(
+				tablewriter.WithHeaderAutoFormat(tw.On),
+			)
+			for _, image := range images {
+				tData = append(tData, []string{image})
+			}
+
 			if err := table.Bulk(tData); err != nil {
 				klog.Error("Error rendering table (bulk)", err)
 			}
@@ -89,6 +116,24 @@ func printAddonImagesTable(addon string) {
 	}
 }
 
+// chartImages returns the images of a chart-backed addon, rendered with the custom images and registries of the profile if it exists
+func chartImages(conf *assets.Addon) []string {
+	cc := &config.ClusterConfig{KubernetesConfig: config.KubernetesConfig{KubernetesVersion: constants.DefaultKubernetesVersion}}
+	if config.ProfileExists(ClusterFlagValue()) {
+		_, cc = mustload.Partial(ClusterFlagValue())
+	}
+	images, registries, err := assets.SelectAndPersistImages(conf, cc)
+	if err != nil {
+		exit.Error(reason.InternalAddonImages, "Failed to select addon images", err)
+	}
+	data := assets.GenerateTemplateData(conf, cc, assets.NetworkInfo{}, images, registries, true)
+	chartImages, err := conf.Chart.Images(cc.KubernetesConfig.KubernetesVersion, data)
+	if err != nil {
+		exit.Error(reason.InternalAddonImages, "Failed to render addon chart", err)
+	}
+	return chartImages
+}
+
 func printAddonImagesJSON(addon string) {
 	if conf, ok := assets.Addons[addon]; ok {
 		if conf.Images != nil {
@@ -98,6 +143,13 @@ func printAddonImagesJSON(addon string) {
 				data = append(data, conf.Registries[imageName]+"/"+defaultImage)
 			}
 
+			jsonString, _ := json.Marshal(data)
+			out.String(string(jsonString))
+		} else if conf.Chart != nil {
+			data := chartImages(conf)
+			if data == nil {
+				data = []string{}
+			}
 			jsonString, _ := json.Marshal(data)
 			out.String(string(jsonString))
 		} else {
@@ -17,24 +17,23 @@ limitations under the License.
 package cmd
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
-	"fmt"
-	"os"
-	pt "path"
-	"strings"
-
-	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/node"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
 )
 
 type remotePath struct {
@@ -42,94 +41,135 @@ type remotePath struct {
 	path string
 }
 
+var (
+	cpAllNodes bool
+	cpArchive  bool
+)
+
 // cpCmd represents the cp command, similar to docker cp
 var cpCmd = &cobra.Command{
-	Use:   "cp <source node name>:<source file path> <target node name>:<target file absolute path>",
-	Short: "Copy the specified file into minikube",
-	Long: `Copy the specified file into minikube, it will be saved at path <target file absolute path> in your minikube.
+	Use:   "cp <source node name>:<source path>... <target node name>:<target absolute path>",
+	Short: "Copy the specified files and directories into, out of, or between minikube nodes",
+	Long: `Copy the specified files and directories into, out of, or between minikube nodes. Directories are copied recursively, keeping the permissions of the files.
 Default target node controlplane and If <source node name> is omitted, It will trying to copy from host.
+A source can be a glob, such as "minikube:/var/log/pods/*". A single source is copied as the target, unless the target is a directory or ends with a '/'. Several sources are copied into the target directory.
 
 Example Command : "minikube cp a.txt /home/docker/b.txt" +
                   "minikube cp a.txt minikube-m02:/home/docker/b.txt"
-                  "minikube cp minikube-m01:a.txt minikube-m02:/home/docker/b.txt"`,
+                  "minikube cp minikube-m01:a.txt minikube-m02:/home/docker/b.txt"
+                  "minikube cp ./fixtures /data/ --all-nodes"
+                  "minikube cp 'minikube:/var/log/*.log' ./logs/"`,
 	Run: func(_ *cobra.Command, args []string) {
-		if len(args) != 2 {
+		if len(args) < 2 {
 			exit.Message(reason.Usage, `Please specify the path to copy: 
 	minikube cp <source file path> <target file absolute path> (example: "minikube cp a/b.txt /copied.txt")`)
 		}
 
-		srcPath := args[0]
-		dstPath := setDstFileNameFromSrc(args[1], srcPath)
-		src := newRemotePath(srcPath)
-		dst := newRemotePath(dstPath)
-		validateArgs(src, dst)
+		dst := newRemotePath(args[len(args)-1])
+		srcs := []*remotePath{}
+		for _, arg := range args[:len(args)-1] {
+			src := newRemotePath(arg)
+			validateArgs(src, dst)
+			srcs = append(srcs, src)
+		}
+		if cpAllNodes && dst.node != "" {
+			exit.Message(reason.Usage, "The target {{.path}} can not name a node with --all-nodes, the files are copied to every node", out.V{"path": args[len(args)-1]})
+		}
 
 		co := mustload.Running(ClusterFlagValue())
-		var runner command.Runner
-
-		if dst.node != "" {
-			runner = remoteCommandRunner(&co, dst.node)
-		} else if src.node == "" {
-			// if node name not explicitly specified in both of source and target,
-			// consider target is control-plane node for backward compatibility.
-			runner = co.CP.Runner
-		} else {
-			runner = command.NewExecRunner(false)
+		sources := expandCopySources(&co, srcs)
+		count := 0
+		for _, s := range sources {
+			count += len(s.paths)
 		}
-
-		fa := copyableFile(&co, src, dst)
-		if err := runner.Copy(fa); err != nil {
-			exit.Error(reason.InternalCommandRunner, fmt.Sprintf("Fail to copy file %s", fa.GetSourcePath()), err)
+		target := copyTargetPath(dst.path, count)
+		opts := machine.CopyOptions{Archive: cpArchive}
+
+		for _, t := range copyTargets(&co, srcs, dst) {
+			for _, s := range sources {
+				if err := machine.CopyPaths(s.runner, s.paths, t.runner, target, opts); err != nil {
+					exit.Error(reason.InternalCommandRunner, fmt.Sprintf("Fail to copy %s", strings.Join(s.paths, ", ")), err)
+				}
+			}
+			if cpAllNodes {
+				out.Step(style.Copying, "Copied to {{.node}}:{{.path}}", out.V{"node": t.name, "path": dst.path})
+			}
 		}
 	},
 }
 
-// setDstFileNameFromSrc sets the src filename as dst filename
-// when the dst file name is not provided and ends with a `/`.
-// Otherwise this function is a no-op and returns the passed dst.
-func setDstFileNameFromSrc(dst, src string) string {
-	srcPath := newRemotePath(src)
-	dstPath := newRemotePath(dst)
-	guestToHost := srcPath.node != "" && dstPath.node == ""
-	guestToGuest := srcPath.node != "" && dstPath.node != ""
-
-	// Since Host can be any OS and Guest can only be linux, use
-	// filepath and path respectively
-	var dd, df, sf string
-	switch {
-	case guestToHost:
-		_, sf = pt.Split(src)
-		dd, df = filepath.Split(dst)
-	case guestToGuest:
-		_, sf = pt.Split(src)
-		dd, df = pt.Split(dst)
-	default:
-		_, sf = filepath.Split(src)
-		dd, df = pt.Split(dst)
-	}
+// copySource is a group of files copied from the host, or from a node
+type copySource struct {
+	runner command.Runner // nil for the host
+	paths  []string
+}
 
-	// if dst is empty, dd and df will be empty, so return dst
-	// validation will be happening in `validateArgs`
-	if dd == "" && df == "" {
-		return ""
+// copyTarget is the host, or a node the files are copied to
+type copyTarget struct {
+	name   string
+	runner command.Runner // nil for the host
+}
+
+// expandCopySources returns the files matching the globs of srcs, grouped by node
+func expandCopySources(co *mustload.ClusterController, srcs []*remotePath) []*copySource {
+	sources := []*copySource{}
+	byNode := map[string]*copySource{}
+	for _, src := range srcs {
+		s, ok := byNode[src.node]
+		if !ok {
+			s = &copySource{}
+			if src.node != "" {
+				s.runner = remoteCommandRunner(co, src.node)
+			}
+			byNode[src.node] = s
+			sources = append(sources, s)
+		}
+
+		paths, err := machine.ExpandCopySource(s.runner, src.path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				exit.Message(reason.HostPathMissing, "Cannot find {{.path}} for copy", out.V{"path": src.path})
+			}
+			exit.Error(reason.HostPathStat, "stat failed", err)
+		}
+		s.paths = append(s.paths, paths...)
 	}
+	return sources
+}
 
-	// if filename is already provided, return dst
-	if df != "" {
-		return dst
+// copyTargets returns where the files are copied to
+func copyTargets(co *mustload.ClusterController, srcs []*remotePath, dst *remotePath) []copyTarget {
+	if cpAllNodes {
+		targets := []copyTarget{}
+		for _, n := range co.Config.Nodes {
+			name := config.MachineName(*co.Config, n)
+			targets = append(targets, copyTarget{name: name, runner: remoteCommandRunner(co, name)})
+		}
+		return targets
 	}
 
-	// if src filename is empty, return dst
-	if sf == "" {
-		return dst
+	if dst.node != "" {
+		return []copyTarget{{name: dst.node, runner: remoteCommandRunner(co, dst.node)}}
 	}
 
-	// https://github.com/kubernetes/minikube/pull/15519/files#r1261750910
-	if guestToHost {
-		return filepath.Join(dd, sf)
+	for _, src := range srcs {
+		if src.node != "" {
+			return []copyTarget{{name: "host"}}
+		}
 	}
 
-	return pt.Join(dd, sf)
+	// if node name not explicitly specified in both of source and target,
+	// consider target is control-plane node for backward compatibility.
+	return []copyTarget{{name: config.MachineName(*co.Config, *co.CP.Node), runner: co.CP.Runner}}
+}
+
+// copyTargetPath returns the path the sources are copied to, several sources are
+// copied into the target directory
+func copyTargetPath(dst string, sources int) string {
+	if sources > 1 && !strings.HasSuffix(dst, "/") && !strings.HasSuffix(dst, string(filepath.Separator)) {
+		return dst + "/"
+	}
+	return dst
 }
 
 // split path to node name and file path
@@ -164,38 +204,9 @@ func remoteCommandRunner(co *mustload.ClusterController, nodeName string) comman
 	return runner
 }
 
-func copyableFile(co *mustload.ClusterController, src, dst *remotePath) assets.CopyableFile {
-	// get assets.CopyableFile from minikube node
-	if src.node != "" {
-		runner := remoteCommandRunner(co, src.node)
-		f, err := runner.ReadableFile(src.path)
-		if err != nil {
-			out.ErrLn("%v", errors.Wrapf(err, "getting file from %s node", src.node))
-			os.Exit(1)
-		}
-
-		fakeWriter := func(_ []byte) (n int, err error) {
-			return 0, nil
-		}
-
-		return assets.NewBaseCopyableFile(f, fakeWriter, pt.Dir(dst.path), pt.Base(dst.path))
-	}
-
-	if _, err := os.Stat(src.path); err != nil {
-		if os.IsNotExist(err) {
-			exit.Message(reason.HostPathMissing, "Cannot find directory {{.path}} for copy", out.V{"path": src})
-		} else {
-			exit.Error(reason.HostPathStat, "stat failed", err)
-		}
-	}
-
-	fa, err := assets.NewFileAsset(src.path, pt.Dir(dst.path), pt.Base(dst.path), "0644")
-	if err != nil {
-		out.ErrLn("%v", errors.Wrap(err, "getting file asset"))
-		os.Exit(1)
-	}
-
-	return fa
+func init() {
+	cpCmd.Flags().BoolVar(&cpAllNodes, "all-nodes", false, "Copy the files to the target path of every node of the cluster")
+	cpCmd.Flags().BoolVarP(&cpArchive, "archive", "a", false, "Preserve the owners of the files, instead of giving the copies to root in the nodes or to the current user on the host")
 }
 
 func validateArgs(src, dst *remotePath) {
@@ -209,7 +220,8 @@ func validateArgs(src, dst *remotePath) {
 
 	// if node name not explicitly specified in both of source and target,
 	// consider target node is control-plane for backward compatibility.
-	if src.node == "" && dst.node == "" && !strings.HasPrefix(dst.path, "/") {
+	// with --all-nodes the target is in every node, whatever the source.
+	if (src.node == "" && dst.node == "" || cpAllNodes) && !strings.HasPrefix(dst.path, "/") {
 		exit.Message(reason.Usage, `Target <remote file path> must be an absolute Path. Relative Path is not allowed (example: "minikube:/home/docker/copied.txt")`)
 	}
 }
@@ -61,25 +61,23 @@ func TestParsePath(t *testing.T) {
 	}
 }
 
-func TestSetDstFileNameFromSrc(t *testing.T) {
+func TestCopyTargetPath(t *testing.T) {
 	cases := []struct {
-		src  string
-		dst  string
-		want string
+		dst     string
+		sources int
+		want    string
 	}{
-		{"./a/b", "/c/", "/c/b"},
-		{"./a/b", "node:/c/", "node:/c/b"},
-		{"./a", "/c/", "/c/a"},
-		{"", "/c/", "/c/"},
-		{"./a/b", "", ""},
-		{"./a/b", "/c", "/c"},
-		{"./a/", "/c/", "/c/"},
+		{"/c/", 1, "/c/"},
+		{"/c", 1, "/c"},
+		{"/c/b", 1, "/c/b"},
+		{"/c", 2, "/c/"},
+		{"/c/", 3, "/c/"},
 	}
 
 	for _, c := range cases {
-		got := setDstFileNameFromSrc(c.dst, c.src)
+		got := copyTargetPath(c.dst, c.sources)
 		if c.want != got {
-			t.Fatalf("wrong dst path for src=%s & dst=%s. want: %q, got: %q", c.src, c.dst, c.want, got)
+			t.Fatalf("wrong target path for dst=%s & %d sources. want: %q, got: %q", c.dst, c.sources, c.want, got)
 		}
 	}
 }
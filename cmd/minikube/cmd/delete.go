@@ -48,6 +48,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/kubeconfig"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/node"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/minikube/reason"
@@ -97,6 +98,12 @@ var hostAndDirsDeleter = func(api libmachine.API, cc *config.ClusterConfig, prof
 	if err := sshagent.Stop(profileName); err != nil && !config.IsNotExist(err) {
 		out.FailureT("Failed to stop ssh-agent process: {{.error}}", out.V{"error": err})
 	}
+	if err := cluster.StopSocketForwardDaemon(profileName, nerdctlForward); err != nil {
+		out.FailureT("Failed to stop the socket forwarding process: {{.error}}", out.V{"error": err})
+	}
+	if cc != nil {
+		stopManagedMounts(cc)
+	}
 
 	deleteHosts(api, cc)
 
@@ -108,7 +115,7 @@ var hostAndDirsDeleter = func(api libmachine.API, cc *config.ClusterConfig, prof
 		return err
 	}
 
-	return deleteContext(profileName)
+	return deleteContext(profileName, config.KubeconfigPath(cc))
 }
 
 func init() {
@@ -339,6 +346,7 @@ func deleteProfile(ctx context.Context, profile *config.Profile) error {
 				klog.Warningf("failed to unpause %s : %v", profile.Name, err)
 			}
 			out.Styled(style.DeletingHost, `Deleting "{{.profile_name}}" in {{.driver_name}} ...`, out.V{"profile_name": profile.Name, "driver_name": profile.Config.Driver})
+			node.DisconnectAll(*profile.Config)
 			for _, n := range profile.Config.Nodes {
 				machineName := config.MachineName(*profile.Config, n)
 				delete.PossibleLeftOvers(ctx, machineName, profile.Config.Driver)
@@ -455,8 +463,8 @@ func deleteConfig(cname string) error {
 	return nil
 }
 
-func deleteContext(machineName string) error {
-	if err := kubeconfig.DeleteContext(machineName); err != nil {
+func deleteContext(machineName string, kubeconfigPath string) error {
+	if err := kubeconfig.DeleteContext(machineName, kubeconfigPath); err != nil {
 		return DeletionError{Err: fmt.Errorf("update config: %v", err), Errtype: Fatal}
 	}
 
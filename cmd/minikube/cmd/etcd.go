@@ -0,0 +1,205 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	apiWait "k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/kubeadm"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/etcd"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/minikube/sysinit"
+)
+
+// etcdRestoreTimeout is how long the restore waits for the API server to be running again
+const etcdRestoreTimeout = 4 * time.Minute
+
+// etcdCmd represents the set of etcd subcommands
+var etcdCmd = &cobra.Command{
+	Use:   "etcd",
+	Short: "Manage the etcd of the control plane",
+	Long:  "Operations on the etcd of the control plane, which stores the state of the cluster",
+	Run: func(_ *cobra.Command, _ []string) {
+		exit.Message(reason.Usage, "Usage: minikube etcd [snapshot]")
+	},
+}
+
+var etcdSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save and restore snapshots of etcd",
+	Long:  "Save and restore snapshots of etcd, to checkpoint the state of the cluster at the API level",
+	Run: func(_ *cobra.Command, _ []string) {
+		exit.Message(reason.Usage, "Usage: minikube etcd snapshot [save|restore] FILE")
+	},
+}
+
+var etcdSnapshotSaveCmd = &cobra.Command{
+	Use:     "save FILE",
+	Short:   "Saves a snapshot of etcd to a file",
+	Long:    "Saves a snapshot of etcd to a file on the host, taken by etcdctl in the etcd of the primary control-plane node. The snapshot holds the secrets of the cluster.",
+	Example: "minikube etcd snapshot save before-upgrade.db",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "Usage: minikube etcd snapshot save FILE")
+		}
+
+		co := mustload.Running(ClusterFlagValue())
+		r, cr, m, err := etcdMember(co, *co.CP.Node)
+		if err != nil {
+			exit.Error(reason.GuestEtcdSnapshot, "Unable to read the etcd member", err)
+		}
+		if err := etcd.SaveSnapshot(r, cr, m, args[0]); err != nil {
+			exit.Error(reason.GuestEtcdSnapshot, "Failed to save the snapshot of etcd", err)
+		}
+		out.Step(style.Check, "Saved the snapshot of etcd to {{.file}}", out.V{"file": args[0]})
+	},
+}
+
+var etcdSnapshotRestoreCmd = &cobra.Command{
+	Use:   "restore FILE",
+	Short: "Restores a snapshot of etcd from a file",
+	Long: `Restores a snapshot of etcd from a file on the host, replacing the state of the cluster.
+Every control-plane node restores the snapshot as a member of a new etcd cluster, then the control plane is restarted.`,
+	Example: "minikube etcd snapshot restore before-upgrade.db",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "Usage: minikube etcd snapshot restore FILE")
+		}
+		if _, err := os.Stat(args[0]); err != nil {
+			exit.Message(reason.Usage, "Unable to read the snapshot {{.file}}: {{.err}}", out.V{"file": args[0], "err": err})
+		}
+
+		co := mustload.Running(ClusterFlagValue())
+		out.Step(style.Resetting, "Restoring the snapshot of etcd {{.file}} ...", out.V{"file": args[0]})
+		if err := restoreEtcdSnapshot(co, args[0]); err != nil {
+			exit.Error(reason.GuestEtcdRestore, "Failed to restore the snapshot of etcd", err)
+		}
+		out.Step(style.Ready, "Restored the snapshot of etcd {{.file}}", out.V{"file": args[0]})
+	},
+}
+
+// etcdNode is a control-plane node, and its etcd member
+type etcdNode struct {
+	name   string
+	runner command.Runner
+	cr     cruntime.Manager
+	member etcd.Member
+}
+
+// restoreEtcdSnapshot restores the snapshot on every control-plane node, which all stop their control plane before they replace the data of etcd,
+// so that the members of the restored cluster start together
+func restoreEtcdSnapshot(co mustload.ClusterController, snapshot string) error {
+	nodes := []etcdNode{}
+	members := []etcd.Member{}
+	for _, n := range config.ControlPlanes(*co.Config) {
+		name := config.MachineName(*co.Config, n)
+		r, cr, m, err := etcdMember(co, n)
+		if err != nil {
+			return errors.Wrapf(err, "node %q", name)
+		}
+		nodes = append(nodes, etcdNode{name: name, runner: r, cr: cr, member: m})
+		members = append(members, m)
+	}
+
+	initialCluster := etcd.InitialCluster(members)
+	for _, n := range nodes {
+		klog.Infof("restoring the snapshot on node %q as %s", n.name, initialCluster)
+		if err := etcd.PrepareRestore(n.runner, n.cr, n.member, snapshot, initialCluster); err != nil {
+			return errors.Wrapf(err, "restoring on node %q", n.name)
+		}
+	}
+	for _, n := range nodes {
+		kubeadm.StopKubernetes(n.runner, n.cr)
+	}
+	// the kubelets are started again whatever happens, the nodes on which the data was not replaced keep their data
+	restored := []string{}
+	failed := []string{}
+	problems := []string{}
+	for _, n := range nodes {
+		if err := etcd.ReplaceData(n.runner, n.member); err != nil {
+			failed = append(failed, n.name)
+			problems = append(problems, fmt.Sprintf("node %q: %v", n.name, err))
+			continue
+		}
+		restored = append(restored, n.name)
+	}
+	for _, n := range nodes {
+		if err := sysinit.New(n.runner).Start("kubelet"); err != nil {
+			problems = append(problems, fmt.Sprintf("starting kubelet on node %q: %v", n.name, err))
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("the snapshot was restored on the nodes [%s] but not on [%s]: %s", strings.Join(restored, ", "), strings.Join(failed, ", "), strings.Join(problems, "; "))
+	}
+	if len(problems) != 0 {
+		return errors.Errorf("the snapshot was restored on every node: %s", strings.Join(problems, "; "))
+	}
+
+	out.Step(style.Waiting, "Waiting for the API server ...")
+	return apiWait.PollUntilContextTimeout(context.Background(), time.Second, etcdRestoreTimeout, true, func(_ context.Context) (bool, error) {
+		st, err := kverify.APIServerStatus(co.CP.Runner, co.CP.Hostname, co.CP.Port)
+		if err != nil {
+			klog.Infof("API server status: %v", err)
+		}
+		return st == state.Running, nil
+	})
+}
+
+// etcdMember returns the runner and the container runtime of a control-plane node, and its etcd member
+func etcdMember(co mustload.ClusterController, n config.Node) (command.Runner, cruntime.Manager, etcd.Member, error) {
+	if co.Config.KubernetesConfig.KubernetesVersion == constants.NoKubernetesVersion {
+		return nil, nil, etcd.Member{}, errors.New("the cluster runs without Kubernetes")
+	}
+	r, err := nodeRunner(co, n)
+	if err != nil {
+		return nil, nil, etcd.Member{}, err
+	}
+	cr, err := cruntime.New(cruntime.Config{Type: co.Config.KubernetesConfig.ContainerRuntime, Runner: r, Socket: co.Config.KubernetesConfig.CRISocket})
+	if err != nil {
+		return nil, nil, etcd.Member{}, errors.Wrap(err, "runtime")
+	}
+	m, err := etcd.ReadMember(r)
+	if err != nil {
+		return nil, nil, etcd.Member{}, err
+	}
+	return r, cr, m, nil
+}
+
+func init() {
+	etcdSnapshotCmd.AddCommand(etcdSnapshotSaveCmd)
+	etcdSnapshotCmd.AddCommand(etcdSnapshotRestoreCmd)
+	etcdCmd.AddCommand(etcdSnapshotCmd)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/kubeconfig"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+var (
+	exportEmbedCerts    bool
+	exportServerAddress string
+)
+
+var kubeconfigCmd = &cobra.Command{
+	Use:   "kubeconfig COMMAND",
+	Short: "Manage the kubeconfig of the cluster",
+}
+
+var kubeconfigExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Print a kubeconfig holding only the context of the cluster",
+	Long: `Prints a kubeconfig holding only the context of the cluster, with the minikube metadata used by 'minikube update-context'.
+
+With --embed-certs the certificates are embedded, so the kubeconfig can be used by scripts, in containers or on other machines.
+The address given with --server-address must be one of the names of the certificate of the API server, see the --apiserver-names and --apiserver-ips flags of 'minikube start'.`,
+	Example: `minikube kubeconfig export --embed-certs > cluster.kubeconfig
+minikube kubeconfig export --embed-certs --server-address host.docker.internal:8443`,
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) > 0 {
+			exit.Message(reason.Usage, "Usage: minikube kubeconfig export [--embed-certs] [--server-address host:port]")
+		}
+
+		cname := ClusterFlagValue()
+		co := mustload.Running(cname)
+
+		address := exportServerAddress
+		if address == "" {
+			address = kubeconfigServerAddress(co)
+		} else if _, _, err := net.SplitHostPort(address); err != nil {
+			exit.Message(reason.Usage, "Invalid server address {{.address}}, it must be host:port: {{.error}}", out.V{"address": address, "error": err})
+		}
+
+		data, err := kubeconfig.Export(&kubeconfig.Settings{
+			ClusterName:          cname,
+			Namespace:            co.Config.KubernetesConfig.Namespace,
+			ClusterServerAddress: "https://" + address,
+			ClientCertificate:    localpath.ClientCert(cname),
+			ClientKey:            localpath.ClientKey(cname),
+			CertificateAuthority: localpath.CACert(),
+			EmbedCerts:           exportEmbedCerts,
+		})
+		if err != nil {
+			exit.Error(reason.HostKubeconfigExport, "Failed to export kubeconfig", err)
+		}
+		// not through out, which logs what it prints, as the kubeconfig may hold the client key
+		if _, err := os.Stdout.Write(data); err != nil {
+			exit.Error(reason.HostKubeconfigExport, "Failed to export kubeconfig", err)
+		}
+	},
+}
+
+// kubeconfigServerAddress returns the address of the API server written to the kubeconfig of the cluster by 'minikube start'
+func kubeconfigServerAddress(co mustload.ClusterController) string {
+	host, port, err := kubeconfig.Endpoint(co.Config.Name, config.KubeconfigPath(co.Config))
+	if err != nil {
+		klog.Warningf("unable to read the server address from kubeconfig: %v", err)
+		host, port = co.CP.Hostname, co.CP.Port
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+func init() {
+	kubeconfigExportCmd.Flags().BoolVar(&exportEmbedCerts, "embed-certs", false, "Embed the certificates in the kubeconfig instead of referencing their files")
+	kubeconfigExportCmd.Flags().StringVar(&exportServerAddress, "server-address", "", "The host:port of the API server in the kubeconfig, instead of the address used by the host")
+	kubeconfigCmd.AddCommand(kubeconfigExportCmd)
+}
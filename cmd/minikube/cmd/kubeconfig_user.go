@@ -0,0 +1,376 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	rbac "k8s.io/api/rbac/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/kubeconfig"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/util"
+)
+
+// userLabel labels the bindings created for a user by 'minikube kubeconfig user add'
+const userLabel = "minikube.sigs.k8s.io/user"
+
+// userPresets are the cluster roles which can be bound to a user
+var userPresets = []string{"view", "edit", "admin", "cluster-admin"}
+
+var (
+	userGroups      []string
+	userExpiry      time.Duration
+	userPreset      string
+	userNamespaces  []string
+	userClusterWide bool
+	userListOutput  string
+)
+
+// kubeconfigUser is a user with a client certificate, as listed by 'minikube kubeconfig user list'
+type kubeconfigUser struct {
+	Name    string    `json:"name"`
+	Groups  []string  `json:"groups"`
+	Expiry  time.Time `json:"expiry"`
+	Expired bool      `json:"expired"`
+	Context string    `json:"context"`
+}
+
+var kubeconfigUserCmd = &cobra.Command{
+	Use:   "user COMMAND",
+	Short: "Manage the users of the cluster, authenticated by client certificates",
+}
+
+var kubeconfigUserAddCmd = &cobra.Command{
+	Use:   "add NAME",
+	Short: "Adds a user with a client certificate, and a kubeconfig context for it",
+	Long: `Signs a client certificate for the user NAME, member of the --groups, with the CA of the cluster, and writes a kubeconfig context NAME@PROFILE for it.
+
+The user has no permission, unless one is given to its groups, or with --preset which binds the cluster role of the same name to the user, in the --namespaces or in the whole cluster.
+
+Adding a user again issues a new certificate, with the new groups and preset.`,
+	Example: `minikube kubeconfig user add alice --groups dev,viewers --preset edit --namespaces dev
+kubectl --context alice@minikube auth can-i create deployments -n dev`,
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "Usage: minikube kubeconfig user add NAME [--groups GROUP,...] [--expiry DURATION] [--preset ROLE]")
+		}
+		name := args[0]
+		if err := validateKubeconfigUser(name, userGroups, userPreset); err != nil {
+			exit.Message(reason.Usage, "{{.err}}", out.V{"err": err})
+		}
+		if userExpiry <= 0 {
+			exit.Message(reason.Usage, "The expiry of the certificate must be positive, but it is {{.expiry}}", out.V{"expiry": userExpiry})
+		}
+
+		cname := ClusterFlagValue()
+		co := mustload.Running(cname)
+		certPath := localpath.UserCert(cname, name)
+		keyPath := localpath.UserKey(cname, name)
+
+		if err := util.GenerateClientCert(certPath, keyPath, name, userGroups, localpath.CACert(), localpath.CAKey(), userExpiry); err != nil {
+			exit.Error(reason.HostUserCert, "Failed to sign the certificate of the user", err)
+		}
+
+		client, err := kapi.Client(cname)
+		if err != nil {
+			exit.Error(reason.InternalKubernetesClient, "Failed to get a Kubernetes client", err)
+		}
+		// the bindings of a previous certificate of the user are replaced
+		if err := unbindUser(client, name); err != nil {
+			exit.Error(reason.HostUserBind, "Failed to delete the bindings of the user", err)
+		}
+		if userPreset != "" {
+			if err := bindUser(client, name, userPreset, userNamespaces, userClusterWide); err != nil {
+				exit.Error(reason.HostUserBind, "Failed to bind the role to the user", err)
+			}
+		}
+
+		kubeconfigPath := config.KubeconfigPath(co.Config)
+		contextName, err := kubeconfig.SetUserContext(cname, name, co.Config.KubernetesConfig.Namespace, certPath, keyPath, co.Config.EmbedCerts, kubeconfigPath)
+		if err != nil {
+			exit.Error(reason.HostKubeconfigUpdate, "Failed to write the context of the user", err)
+		}
+
+		out.Step(style.Celebrate, `User "{{.name}}" added, its certificate expires on {{.expiry}}`, out.V{"name": name, "expiry": time.Now().Add(userExpiry).Format(time.RFC1123)})
+		if kubeconfigPath != kubeconfig.PathFromEnv() {
+			out.Styled(style.Kubectl, "To connect to this cluster as {{.name}}, use:  --kubeconfig={{.path}} --context={{.context}}", out.V{"name": name, "path": kubeconfigPath, "context": contextName})
+		} else {
+			out.Styled(style.Kubectl, "To connect to this cluster as {{.name}}, use:  --context={{.context}}", out.V{"name": name, "context": contextName})
+		}
+	},
+}
+
+var kubeconfigUserListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the users added with 'minikube kubeconfig user add'",
+	Run: func(_ *cobra.Command, _ []string) {
+		cname := ClusterFlagValue()
+		users, err := listUsers(cname, time.Now())
+		if err != nil {
+			exit.Error(reason.HostUserCert, "Failed to list the users", err)
+		}
+
+		switch strings.ToLower(userListOutput) {
+		case "table":
+			printUsersTable(users)
+		case "json":
+			b, err := json.Marshal(users)
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "Failed to marshal users", err)
+			}
+			out.String(string(b))
+		default:
+			exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'table', 'json'", userListOutput))
+		}
+	},
+}
+
+var kubeconfigUserRevokeCmd = &cobra.Command{
+	Use:   "revoke NAME",
+	Short: "Revokes a user added with 'minikube kubeconfig user add'",
+	Long: `Deletes the bindings created for the user, its certificate, key and kubeconfig context.
+
+Kubernetes does not support revoking certificates: copies of the certificate of the user are still authenticated until they expire, with the permissions given to its groups.`,
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "Usage: minikube kubeconfig user revoke NAME")
+		}
+		name := args[0]
+		cname := ClusterFlagValue()
+		co := mustload.Running(cname)
+
+		users, err := listUsers(cname, time.Now())
+		if err != nil {
+			exit.Error(reason.HostUserCert, "Failed to list the users", err)
+		}
+		var user *kubeconfigUser
+		for i := range users {
+			if users[i].Name == name {
+				user = &users[i]
+			}
+		}
+		if user == nil {
+			exit.Message(reason.Usage, `No user "{{.name}}" in the cluster, see 'minikube kubeconfig user list'`, out.V{"name": name})
+		}
+
+		client, err := kapi.Client(cname)
+		if err != nil {
+			exit.Error(reason.InternalKubernetesClient, "Failed to get a Kubernetes client", err)
+		}
+		if err := unbindUser(client, name); err != nil {
+			exit.Error(reason.HostUserBind, "Failed to delete the bindings of the user", err)
+		}
+		if err := kubeconfig.DeleteContext(user.Context, config.KubeconfigPath(co.Config)); err != nil {
+			exit.Error(reason.HostKubeconfigDeleteCtx, "Failed to delete the context of the user", err)
+		}
+		for _, p := range []string{localpath.UserCert(cname, name), localpath.UserKey(cname, name)} {
+			if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+				exit.Error(reason.HostUserCert, "Failed to delete the certificate of the user", err)
+			}
+		}
+
+		out.Step(style.Deleted, `User "{{.name}}" revoked`, out.V{"name": name})
+		if len(user.Groups) > 0 && !user.Expired {
+			out.WarningT("Copies of the certificate of {{.name}} are still authenticated until {{.expiry}}, with the permissions given to the groups {{.groups}}", out.V{"name": name, "expiry": user.Expiry.Format(time.RFC1123), "groups": strings.Join(user.Groups, ", ")})
+		}
+	},
+}
+
+// validateKubeconfigUser validates the name, groups and preset of a user
+func validateKubeconfigUser(name string, groups []string, preset string) error {
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return errors.Errorf("Invalid user name %q: %s", name, strings.Join(errs, ", "))
+	}
+	for _, g := range groups {
+		// the system groups, like system:masters, give permissions which can't be taken back before the certificate expires
+		if strings.HasPrefix(g, "system:") {
+			return errors.Errorf("Invalid group %q: the system groups are reserved", g)
+		}
+	}
+	if preset == "" {
+		return nil
+	}
+	for _, p := range userPresets {
+		if preset == p {
+			return nil
+		}
+	}
+	return errors.Errorf("Invalid preset %q, must be one of: %s", preset, strings.Join(userPresets, ", "))
+}
+
+// userBindingName returns the name of the bindings of the cluster role preset to the user name
+func userBindingName(name, preset string) string {
+	return fmt.Sprintf("minikube-user-%s-%s", name, preset)
+}
+
+// bindUser binds the cluster role preset to the user name, in the namespaces or in the whole cluster
+func bindUser(client kubernetes.Interface, name, preset string, namespaces []string, clusterWide bool) error {
+	ctx := context.Background()
+	objectMeta := meta.ObjectMeta{
+		Name:   userBindingName(name, preset),
+		Labels: map[string]string{userLabel: name},
+	}
+	roleRef := rbac.RoleRef{APIGroup: rbac.GroupName, Kind: "ClusterRole", Name: preset}
+	subjects := []rbac.Subject{{APIGroup: rbac.GroupName, Kind: rbac.UserKind, Name: name}}
+
+	if clusterWide {
+		crb := &rbac.ClusterRoleBinding{ObjectMeta: objectMeta, RoleRef: roleRef, Subjects: subjects}
+		if _, err := client.RbacV1().ClusterRoleBindings().Create(ctx, crb, meta.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "create cluster role binding %s", crb.Name)
+		}
+		return nil
+	}
+
+	for _, ns := range namespaces {
+		rb := &rbac.RoleBinding{ObjectMeta: *objectMeta.DeepCopy(), RoleRef: roleRef, Subjects: subjects}
+		rb.Namespace = ns
+		if _, err := client.RbacV1().RoleBindings(ns).Create(ctx, rb, meta.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "create role binding %s in %s", rb.Name, ns)
+		}
+	}
+	return nil
+}
+
+// unbindUser deletes the bindings created for the user name
+func unbindUser(client kubernetes.Interface, name string) error {
+	ctx := context.Background()
+	selector := meta.ListOptions{LabelSelector: userLabel + "=" + name}
+
+	crbs, err := client.RbacV1().ClusterRoleBindings().List(ctx, selector)
+	if err != nil {
+		return errors.Wrap(err, "list cluster role bindings")
+	}
+	for _, crb := range crbs.Items {
+		klog.Infof("deleting cluster role binding %s", crb.Name)
+		if err := client.RbacV1().ClusterRoleBindings().Delete(ctx, crb.Name, meta.DeleteOptions{}); err != nil && !apierr.IsNotFound(err) {
+			return errors.Wrapf(err, "delete cluster role binding %s", crb.Name)
+		}
+	}
+
+	rbs, err := client.RbacV1().RoleBindings(meta.NamespaceAll).List(ctx, selector)
+	if err != nil {
+		return errors.Wrap(err, "list role bindings")
+	}
+	for _, rb := range rbs.Items {
+		klog.Infof("deleting role binding %s/%s", rb.Namespace, rb.Name)
+		if err := client.RbacV1().RoleBindings(rb.Namespace).Delete(ctx, rb.Name, meta.DeleteOptions{}); err != nil && !apierr.IsNotFound(err) {
+			return errors.Wrapf(err, "delete role binding %s/%s", rb.Name, rb.Namespace)
+		}
+	}
+	return nil
+}
+
+// listUsers returns the users of the profile, read from their certificates
+func listUsers(profile string, now time.Time) ([]kubeconfigUser, error) {
+	certs, err := filepath.Glob(filepath.Join(filepath.Dir(localpath.UserCert(profile, "user")), "*.crt"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(certs)
+
+	users := []kubeconfigUser{}
+	for _, p := range certs {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, errors.Errorf("unable to decode certificate %s", p)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse certificate %s", p)
+		}
+
+		name := cert.Subject.CommonName
+		users = append(users, kubeconfigUser{
+			Name:    name,
+			Groups:  cert.Subject.Organization,
+			Expiry:  cert.NotAfter,
+			Expired: now.After(cert.NotAfter),
+			Context: kubeconfig.UserContext(profile, name),
+		})
+	}
+	return users, nil
+}
+
+func printUsersTable(users []kubeconfigUser) {
+	if len(users) == 0 {
+		out.Styled(style.Empty, "No users, add one with 'minikube kubeconfig user add'")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Name", "Groups", "Expiry", "Context")
+	table.Options(
+		tablewriter.WithHeaderAutoFormat(tw.On),
+	)
+	var data [][]string
+	for _, u := range users {
+		expiry := u.Expiry.Format(time.RFC1123)
+		if u.Expired {
+			expiry += " (expired)"
+		}
+		data = append(data, []string{u.Name, strings.Join(u.Groups, ","), expiry, u.Context})
+	}
+	if err := table.Bulk(data); err != nil {
+		klog.Error("Error rendering table (bulk)", err)
+	}
+	if err := table.Render(); err != nil {
+		klog.Error("Error rendering table", err)
+	}
+}
+
+func init() {
+	kubeconfigUserAddCmd.Flags().StringSliceVar(&userGroups, "groups", []string{}, "The groups of the user, as the organizations of its certificate")
+	kubeconfigUserAddCmd.Flags().DurationVar(&userExpiry, "expiry", 24*time.Hour, "The duration of the certificate of the user, which can't be revoked before it expires")
+	kubeconfigUserAddCmd.Flags().StringVar(&userPreset, "preset", "", fmt.Sprintf("The cluster role bound to the user, one of: %s", strings.Join(userPresets, ", ")))
+	kubeconfigUserAddCmd.Flags().StringSliceVar(&userNamespaces, "namespaces", []string{meta.NamespaceDefault}, "The namespaces of the role bindings of the --preset")
+	kubeconfigUserAddCmd.Flags().BoolVar(&userClusterWide, "cluster-wide", false, "Bind the --preset in the whole cluster, instead of the --namespaces")
+	kubeconfigUserListCmd.Flags().StringVarP(&userListOutput, "output", "o", "table", "Output format. Accepted values: [table, json]")
+
+	kubeconfigUserCmd.AddCommand(kubeconfigUserAddCmd)
+	kubeconfigUserCmd.AddCommand(kubeconfigUserListCmd)
+	kubeconfigUserCmd.AddCommand(kubeconfigUserRevokeCmd)
+	kubeconfigCmd.AddCommand(kubeconfigUserCmd)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	rbac "k8s.io/api/rbac/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/util"
+)
+
+func TestValidateKubeconfigUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		groups  []string
+		preset  string
+		wantErr bool
+	}{
+		{"alice", []string{"dev", "viewers"}, "edit", false},
+		{"bob", nil, "", false},
+		{"Alice", nil, "", true},
+		{"alice@minikube", nil, "", true},
+		{"alice", []string{"system:masters"}, "", true},
+		{"alice", nil, "root", true},
+	}
+	for _, tc := range tests {
+		err := validateKubeconfigUser(tc.name, tc.groups, tc.preset)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("validateKubeconfigUser(%q, %v, %q) = %v, want error: %v", tc.name, tc.groups, tc.preset, err, tc.wantErr)
+		}
+	}
+}
+
+func TestBindUser(t *testing.T) {
+	ctx := context.Background()
+	other := &rbac.RoleBinding{ObjectMeta: meta.ObjectMeta{Name: "other", Namespace: "dev", Labels: map[string]string{userLabel: "bob"}}}
+	client := fake.NewSimpleClientset(other)
+
+	if err := bindUser(client, "alice", "edit", []string{"default", "dev"}, false); err != nil {
+		t.Fatalf("bindUser() = %v", err)
+	}
+	if err := bindUser(client, "alice", "view", nil, true); err != nil {
+		t.Fatalf("bindUser(cluster wide) = %v", err)
+	}
+
+	rb, err := client.RbacV1().RoleBindings("dev").Get(ctx, "minikube-user-alice-edit", meta.GetOptions{})
+	if err != nil {
+		t.Fatalf("get role binding: %v", err)
+	}
+	if rb.RoleRef.Kind != "ClusterRole" || rb.RoleRef.Name != "edit" {
+		t.Errorf("role ref = %+v, want ClusterRole edit", rb.RoleRef)
+	}
+	if len(rb.Subjects) != 1 || rb.Subjects[0].Kind != rbac.UserKind || rb.Subjects[0].Name != "alice" {
+		t.Errorf("subjects = %+v, want the user alice", rb.Subjects)
+	}
+	if _, err := client.RbacV1().ClusterRoleBindings().Get(ctx, "minikube-user-alice-view", meta.GetOptions{}); err != nil {
+		t.Errorf("get cluster role binding: %v", err)
+	}
+
+	if err := unbindUser(client, "alice"); err != nil {
+		t.Fatalf("unbindUser() = %v", err)
+	}
+	rbs, err := client.RbacV1().RoleBindings(meta.NamespaceAll).List(ctx, meta.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rbs.Items) != 1 || rbs.Items[0].Name != "other" {
+		t.Errorf("role bindings after unbindUser() = %+v, want only the binding of bob", rbs.Items)
+	}
+	crbs, err := client.RbacV1().ClusterRoleBindings().List(ctx, meta.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(crbs.Items) != 0 {
+		t.Errorf("cluster role bindings after unbindUser() = %+v, want none", crbs.Items)
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(localpath.MinikubeHome, dir)
+
+	caCert := filepath.Join(dir, "ca.crt")
+	caKey := filepath.Join(dir, "ca.key")
+	if err := util.GenerateCACert(caCert, caKey, "minikubeCA"); err != nil {
+		t.Fatal(err)
+	}
+	if users, err := listUsers("p1", time.Now()); err != nil || len(users) != 0 {
+		t.Fatalf("listUsers() without users = %v, %v, want none", users, err)
+	}
+
+	for name, expiry := range map[string]time.Duration{"alice": time.Hour, "bob": 2 * time.Hour} {
+		if err := util.GenerateClientCert(localpath.UserCert("p1", name), localpath.UserKey("p1", name), name, []string{"dev"}, caCert, caKey, expiry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(filepath.Dir(localpath.UserKey("p1", "alice")), "notes.txt"), []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := listUsers("p1", time.Now().Add(90*time.Minute))
+	if err != nil {
+		t.Fatalf("listUsers() = %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("listUsers() = %+v, want alice and bob", users)
+	}
+	if users[0].Name != "alice" || !users[0].Expired || users[0].Context != "alice@p1" {
+		t.Errorf("users[0] = %+v, want the expired alice@p1", users[0])
+	}
+	if users[1].Name != "bob" || users[1].Expired || len(users[1].Groups) != 1 || users[1].Groups[0] != "dev" {
+		t.Errorf("users[1] = %+v, want bob of the group dev", users[1])
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/detect"
+	"k8s.io/minikube/pkg/minikube/kubeconfig"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/node"
@@ -113,8 +114,11 @@ host. Please be aware that when using --ssh all paths will apply to the remote m
 					}
 				}
 			}
-			clusterArg := "--cluster=" + cname
-			args = append(append(append([]string{}, args[:insertIndex]...), clusterArg), args[insertIndex:]...)
+			clusterArgs := []string{"--cluster=" + cname}
+			if cc != nil && cc.KubeconfigMode == kubeconfig.SeparateMode {
+				clusterArgs = append(clusterArgs, "--kubeconfig="+config.KubeconfigPath(cc))
+			}
+			args = append(append(append([]string{}, args[:insertIndex]...), clusterArgs...), args[insertIndex:]...)
 		}
 
 		c, err := KubectlCommand(version, binaryMirror, args...)
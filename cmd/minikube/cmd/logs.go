@@ -31,6 +31,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/logs"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/node"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
 	"k8s.io/minikube/pkg/minikube/style"
@@ -53,6 +54,8 @@ var (
 	fileOutput string
 	// auditLogs only shows the audit logs
 	auditLogs bool
+	// apiServerAuditLogs only shows the audit log of the apiserver
+	apiServerAuditLogs bool
 	// lastStartOnly shows logs from last start
 	lastStartOnly bool
 )
@@ -92,6 +95,10 @@ var logsCmd = &cobra.Command{
 			}
 			return
 		}
+		if apiServerAuditLogs {
+			outputAPIServerAudit(logOutput)
+			return
+		}
 		logs.OutputOffline(numberOfLines, logOutput)
 
 		if shouldSilentFail() {
@@ -128,6 +135,30 @@ var logsCmd = &cobra.Command{
 	},
 }
 
+// outputAPIServerAudit writes the audit log of the apiserver of the --node, or of the primary control plane
+func outputAPIServerAudit(logOutput *os.File) {
+	co := mustload.Running(ClusterFlagValue())
+	if co.Config.KubernetesConfig.APIServerAuditPolicy == "" {
+		exit.Message(reason.Usage, "The audit log of the apiserver is not enabled, start the cluster with --apiserver-audit-policy")
+	}
+
+	runner := co.CP.Runner
+	if nodeName != "" {
+		n, _, err := node.Retrieve(*co.Config, nodeName)
+		if err != nil {
+			exit.Message(reason.GuestNodeRetrieve, "Node {{.nodeName}} does not exist.", out.V{"nodeName": nodeName})
+		}
+		if !n.ControlPlane {
+			exit.Message(reason.Usage, "Node {{.nodeName}} is not a control-plane node, only control-plane nodes run an apiserver", out.V{"nodeName": nodeName})
+		}
+		runner = remoteCommandRunner(&co, nodeName)
+	}
+
+	if err := logs.APIServerAudit(runner, numberOfLines, followLogs, logOutput); err != nil {
+		exit.Error(reason.GuestAPIServerAuditLog, "Failed to read the audit log of the apiserver", err)
+	}
+}
+
 // shouldSilentFail returns true if the user specifies the --file flag and the host isn't running
 // This is to prevent outputting the message 'The control plane node must be running for this command' which confuses
 // many users while gathering logs to report their issue as the message makes them think the log file wasn't generated
@@ -159,5 +190,6 @@ func init() {
 	logsCmd.Flags().StringVar(&nodeName, "node", "", "The node to get logs from. Defaults to the primary control plane.")
 	logsCmd.Flags().StringVar(&fileOutput, "file", "", "If present, writes to the provided file instead of stdout.")
 	logsCmd.Flags().BoolVar(&auditLogs, "audit", false, "Show only the audit logs")
+	logsCmd.Flags().BoolVar(&apiServerAuditLogs, "apiserver-audit", false, "Show only the audit log of the apiserver, enabled by 'minikube start --apiserver-audit-policy'. Use --follow to stream it, and --node to read it from another control-plane node.")
 	logsCmd.Flags().BoolVar(&lastStartOnly, "last-start-only", false, "Show only the last start logs.")
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
@@ -27,11 +28,13 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/detect"
 	"k8s.io/minikube/pkg/minikube/driver"
@@ -43,6 +46,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/style"
 	pkgnetwork "k8s.io/minikube/pkg/network"
 	"k8s.io/minikube/pkg/util/lock"
+	"k8s.io/minikube/third_party/go9p"
 	"k8s.io/minikube/third_party/go9p/ufs"
 )
 
@@ -81,6 +85,10 @@ var (
 	gid          string
 	mSize        int
 	options      []string
+
+	mountNotify         bool
+	mountNotifyIgnore   []string
+	mountNotifyDebounce time.Duration
 )
 
 // supportedFilesystems is a map of filesystem types to not warn against.
@@ -137,6 +145,12 @@ var mountCmd = &cobra.Command{
 			exit.Message(reason.Unimplemented, msg)
 		}
 
+		if mountDaemon {
+			startManagedMount(co, hostPath, vmPath)
+			return
+		}
+		n, h, runner := mountTarget(co)
+
 		var ip net.IP
 		var err error
 		if mountIP == "" {
@@ -151,7 +165,7 @@ var mountCmd = &cobra.Command{
 					return conn.LocalAddr().(*net.UDPAddr).IP, nil
 				}()
 			} else {
-				ip, err = cluster.HostIP(co.CP.Host, co.Config.Name)
+				ip, err = cluster.HostIP(h, co.Config.Name)
 			}
 			if err != nil {
 				exit.Error(reason.IfHostIP, "Error getting the host IP address to use from within the VM", err)
@@ -197,7 +211,7 @@ var mountCmd = &cobra.Command{
 		}
 
 		bindIP := ip.String() // the ip to listen on the user's host machine
-		if driver.IsKIC(co.CP.Host.Driver.DriverName()) && runtime.GOOS != "linux" {
+		if driver.IsKIC(h.Driver.DriverName()) && runtime.GOOS != "linux" {
 			bindIP = "127.0.0.1"
 		}
 		out.Step(style.Mounting, "Mounting host path {{.sourcePath}} into VM as {{.destinationPath}} ...", out.V{"sourcePath": hostPath, "destinationPath": vmPath})
@@ -208,6 +222,10 @@ var mountCmd = &cobra.Command{
 		out.Infof("Message Size: {{.size}}", out.V{"size": cfg.MSize})
 		out.Infof("Options:      {{.options}}", out.V{"options": cfg.Options})
 		out.Infof("Bind Address: {{.Address}}", out.V{"Address": net.JoinHostPort(bindIP, fmt.Sprint(port))})
+		ufsOpts := ufs.Options{}
+		if cfg.Type == nineP && strings.EqualFold(cfg.Version, "9p2000.L") {
+			ufsOpts.Owner = mountOwner(runner, cfg)
+		}
 
 		var wg sync.WaitGroup
 		pidchan := make(chan int)
@@ -216,7 +234,13 @@ var mountCmd = &cobra.Command{
 			go func(pid chan int) {
 				pid <- os.Getpid()
 				out.Styled(style.Fileserver, "Userspace file server: ")
-				ufs.StartServer(net.JoinHostPort(bindIP, strconv.Itoa(port)), debugVal, hostPath)
+				ufs.StartServerWithOptions(net.JoinHostPort(bindIP, strconv.Itoa(port)), debugVal, hostPath, ufsOpts)
+				// background mounts restart the file server, the watchdog mounts the directory again
+				for mountDaemonChild {
+					out.Step(style.Waiting, "Userspace file server stopped, restarting it ...")
+					time.Sleep(time.Second)
+					ufs.StartServerWithOptions(net.JoinHostPort(bindIP, strconv.Itoa(port)), debugVal, hostPath, ufsOpts)
+				}
 				out.Step(style.Stopped, "Userspace file server is shutdown")
 				wg.Done()
 			}(pidchan)
@@ -229,7 +253,7 @@ var mountCmd = &cobra.Command{
 		go func() {
 			for sig := range c {
 				out.Step(style.Unmount, "Unmounting {{.path}} ...", out.V{"path": vmPath})
-				err := cluster.Unmount(co.CP.Runner, vmPath)
+				err := cluster.Unmount(runner, vmPath)
 				if err != nil {
 					out.FailureT("Failed unmount: {{.error}}", out.V{"error": err})
 				}
@@ -243,7 +267,7 @@ var mountCmd = &cobra.Command{
 			}
 		}()
 
-		err = cluster.Mount(co.CP.Runner, ip.String(), vmPath, cfg, pid)
+		err = cluster.Mount(runner, ip.String(), vmPath, cfg, pid)
 		if err != nil {
 			if rtErr, ok := err.(*cluster.MountError); ok && rtErr.ErrorType == cluster.MountErrorConnect {
 				exit.Error(reason.GuestMountCouldNotConnect, "mount could not connect", rtErr)
@@ -251,6 +275,14 @@ var mountCmd = &cobra.Command{
 			exit.Error(reason.GuestMount, "mount failed", err)
 		}
 		out.Step(style.Success, "Successfully mounted {{.sourcePath}} to {{.destinationPath}}", out.V{"sourcePath": hostPath, "destinationPath": vmPath})
+		if mountNotify {
+			go notifyMountChanges(hostPath, vmPath, runner)
+		}
+		if mountDaemonChild {
+			go watchManagedMount(co, n, runner, func(r command.Runner) error {
+				return cluster.Mount(r, ip.String(), vmPath, cfg, pid)
+			}, vmPath, cfg.Type)
+		}
 		out.Ln("")
 		out.Styled(style.Notice, "NOTE: This process must stay alive for the mount to be accessible ...")
 		wg.Wait()
@@ -267,6 +299,38 @@ func init() {
 	mountCmd.Flags().StringVar(&gid, constants.MountGIDFlag, defaultMountGID, mountGIDDescription)
 	mountCmd.Flags().StringSliceVar(&options, constants.MountOptionsFlag, defaultMountOptions(), mountOptionsDescription)
 	mountCmd.Flags().IntVar(&mSize, constants.MountMSizeFlag, defaultMountMSize, mountMSizeDescription)
+	mountCmd.Flags().BoolVar(&mountNotify, "notify", false, "Replay the changes of the host directory in the node, for tools relying on inotify such as hot reloading dev servers")
+	mountCmd.Flags().StringSliceVar(&mountNotifyIgnore, "notify-ignore", cluster.DefaultNotifyIgnore, "With --notify, globs of the paths whose changes are not replayed, relative to the source directory. A glob without '/' matches any path element, 'dir/**' matches a subtree.")
+	mountCmd.Flags().DurationVar(&mountNotifyDebounce, "notify-debounce", cluster.DefaultNotifyDebounce, "With --notify, how long changes are collected before being replayed")
+}
+
+// mountOwner returns the owner the file server reports for the mounted files, so that
+// they are owned by --uid and --gid in the node
+func mountOwner(runner command.Runner, cfg *cluster.MountConfig) *go9p.FileOwner {
+	uid, gid, err := cluster.MountOwner(runner, cfg)
+	if err != nil {
+		out.WarningT("Unable to resolve the owner {{.uid}}:{{.gid}} in the node, the mounted files are owned by their owner on the host: {{.error}}", out.V{"uid": cfg.UID, "gid": cfg.GID, "error": err})
+		return nil
+	}
+	return &go9p.FileOwner{Uid: uid, Gid: gid}
+}
+
+// notifyMountChanges replays the changes of the host directory in the node until minikube exits
+func notifyMountChanges(hostPath, vmPath string, runner command.Runner) {
+	n := &cluster.MountNotifier{
+		Source:   hostPath,
+		Target:   vmPath,
+		Ignore:   mountNotifyIgnore,
+		Debounce: mountNotifyDebounce,
+		Replay: func(paths []string) error {
+			klog.Infof("replaying changes of %v", paths)
+			return cluster.ReplayChanges(runner, paths)
+		},
+	}
+	out.Styled(style.Fileserver, "Replaying the changes of {{.sourcePath}} in {{.destinationPath}}", out.V{"sourcePath": hostPath, "destinationPath": vmPath})
+	if err := n.Run(context.Background()); err != nil {
+		out.FailureT("Unable to watch {{.path}} for changes: {{.error}}", out.V{"path": hostPath, "error": err})
+	}
 }
 
 // getPort uses the requested port or asks the kernel for a free open port that is ready to use
@@ -0,0 +1,448 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine/host"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/node"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+const (
+	// mountWatchInterval is the delay between two checks of a background mount
+	mountWatchInterval = 10 * time.Second
+	// mountStartTimeout is how long 'mount --daemon' waits for the directory to be mounted
+	mountStartTimeout = time.Minute
+	// mountStopTimeout is how long 'mount stop' waits for the mount process to unmount the directory
+	mountStopTimeout = 10 * time.Second
+)
+
+var (
+	mountDaemon      bool
+	mountDaemonChild bool
+	mountName        string
+	mountNodeName    string
+	mountListOutput  string
+	mountStopAll     bool
+)
+
+var mountNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+var mountListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the background mounts",
+	Long:  "Lists the mounts created with 'minikube mount --daemon', with the health reported by their watchdog",
+	Run: func(_ *cobra.Command, _ []string) {
+		_, cc := mustload.Partial(ClusterFlagValue())
+		mounts := managedMountStatuses(*cc)
+
+		switch strings.ToLower(mountListOutput) {
+		case "table":
+			printMountsTable(mounts)
+		case "json":
+			b, err := json.Marshal(mounts)
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "Failed to marshal mounts", err)
+			}
+			out.String(string(b))
+		default:
+			exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'table', 'json'", mountListOutput))
+		}
+	},
+}
+
+var mountStopCmd = &cobra.Command{
+	Use:     "stop [NAME ...]",
+	Short:   "Stops background mounts",
+	Long:    "Stops mounts created with 'minikube mount --daemon': the directories are unmounted and not mounted again on start",
+	Example: "minikube mount stop src",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) == 0 && !mountStopAll || len(args) > 0 && mountStopAll {
+			exit.Message(reason.Usage, "Usage: minikube mount stop [NAME ...|--all]")
+		}
+		api, cc := mustload.Partial(ClusterFlagValue())
+		defer api.Close()
+
+		names := args
+		if mountStopAll {
+			names = nil
+			for _, m := range cc.ManagedMounts {
+				names = append(names, m.Name)
+			}
+		}
+		for _, name := range names {
+			m, ok := findManagedMount(*cc, name)
+			if !ok {
+				exit.Message(reason.Usage, "Mount {{.name}} does not exist, see 'minikube mount list'", out.V{"name": name})
+			}
+			if _, err := cluster.StopMountDaemon(cc.Name, name, mountStopTimeout); err != nil {
+				exit.Error(reason.HostKillMountProc, "Error stopping mount process", err)
+			}
+			unmountManagedMount(cc, m)
+			if err := os.Remove(localpath.MountLog(cc.Name, name)); err != nil && !os.IsNotExist(err) {
+				klog.Warningf("unable to remove log of mount %s: %v", name, err)
+			}
+			cc.ManagedMounts = removeManagedMount(cc.ManagedMounts, name)
+			if err := config.SaveProfile(cc.Name, cc); err != nil {
+				exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+			}
+			out.Step(style.Unmount, "Stopped mount {{.name}} of {{.source}}", out.V{"name": name, "source": m.Source})
+		}
+	},
+}
+
+func init() {
+	mountCmd.Flags().BoolVar(&mountDaemon, "daemon", false, "Run the mount in the background, it is mounted again after 'minikube start' until stopped with 'minikube mount stop'")
+	mountCmd.Flags().StringVar(&mountName, "name", "", "The name of the background mount, defaults to the target directory")
+	mountCmd.Flags().StringVar(&mountNodeName, "node", "", "The node to mount the directory into. Defaults to the primary control plane.")
+	mountCmd.Flags().BoolVar(&mountDaemonChild, "daemon-child", false, "Serve a background mount, used by 'minikube mount --daemon'")
+	if err := mountCmd.Flags().MarkHidden("daemon-child"); err != nil {
+		klog.Warningf("unable to hide flag: %v", err)
+	}
+
+	mountListCmd.Flags().StringVarP(&mountListOutput, "output", "o", "table", "Output format. Accepted values: [table, json]")
+	mountStopCmd.Flags().BoolVar(&mountStopAll, "all", false, "Stop all the background mounts of the profile")
+	mountCmd.AddCommand(mountListCmd)
+	mountCmd.AddCommand(mountStopCmd)
+}
+
+// mountTarget returns the node to mount into, with its host and command runner
+func mountTarget(co mustload.ClusterController) (*config.Node, *host.Host, command.Runner) {
+	if mountNodeName == "" {
+		return co.CP.Node, co.CP.Host, co.CP.Runner
+	}
+	n, _, err := node.Retrieve(*co.Config, mountNodeName)
+	if err != nil {
+		exit.Message(reason.GuestNodeRetrieve, "Node {{.nodeName}} does not exist.", out.V{"nodeName": mountNodeName})
+	}
+	h, err := machine.GetHost(co.API, *co.Config, *n)
+	if err != nil {
+		exit.Error(reason.GuestLoadHost, "Error getting host", err)
+	}
+	runner, err := machine.CommandRunner(h)
+	if err != nil {
+		exit.Error(reason.InternalCommandRunner, "Failed to get command runner", err)
+	}
+	return n, h, runner
+}
+
+// defaultMountName returns the name of a background mount from its target directory
+func defaultMountName(target string) string {
+	name := strings.ReplaceAll(strings.Trim(target, "/"), "/", "-")
+	if name == "" {
+		return "root"
+	}
+	return name
+}
+
+// managedMountNode returns the name of the node of a background mount given with --node, which may be
+// its machine name, as the nodes are compared by name on start
+func managedMountNode(cc config.ClusterConfig, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	n, _, err := node.Retrieve(cc, name)
+	if err != nil {
+		return "", err
+	}
+	return n.Name, nil
+}
+
+// startManagedMount saves a background mount in the profile, and starts its process
+func startManagedMount(co mustload.ClusterController, hostPath, vmPath string) {
+	name := mountName
+	if name == "" {
+		name = defaultMountName(vmPath)
+	}
+	if !mountNameRegexp.MatchString(name) {
+		exit.Message(reason.Usage, "Invalid mount name {{.name}}, use letters, digits, '.', '_' and '-'", out.V{"name": name})
+	}
+	nodeName, err := managedMountNode(*co.Config, mountNodeName)
+	if err != nil {
+		exit.Message(reason.GuestNodeRetrieve, "Node {{.nodeName}} does not exist.", out.V{"nodeName": mountNodeName})
+	}
+	source, err := filepath.Abs(hostPath)
+	if err != nil {
+		exit.Error(reason.HostPathStat, "Failed to get absolute path", err)
+	}
+	cc := co.Config
+	for _, m := range cc.ManagedMounts {
+		if m.Name != name && (m.Target != vmPath || m.Node != nodeName) {
+			continue
+		}
+		if st := cluster.ReadMountDaemonState(cc.Name, m.Name); st.Health != cluster.MountStopped {
+			exit.Message(reason.Usage, "Mount {{.name}} of {{.source}} into {{.target}} is already running, stop it with 'minikube mount stop {{.name}}'", out.V{"name": m.Name, "source": m.Source, "target": m.Target})
+		}
+		cc.ManagedMounts = removeManagedMount(cc.ManagedMounts, m.Name)
+	}
+
+	m := config.ManagedMount{
+		Name:    name,
+		Source:  source,
+		Target:  vmPath,
+		Node:    nodeName,
+		Type:    mountType,
+		UID:     uid,
+		GID:     gid,
+		Version: mountVersion,
+		MSize:   mSize,
+		IP:      mountIP,
+		Port:    mountPort,
+		Options: options,
+	}
+	if mountNotify {
+		m.Notify = true
+		m.NotifyIgnore = mountNotifyIgnore
+		m.NotifyDebounce = mountNotifyDebounce
+	}
+	cc.ManagedMounts = append(cc.ManagedMounts, m)
+	if err := config.SaveProfile(cc.Name, cc); err != nil {
+		exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+	}
+
+	out.Step(style.Mounting, "Mounting host path {{.sourcePath}} into VM as {{.destinationPath}} in the background ...", out.V{"sourcePath": source, "destinationPath": vmPath})
+	if _, err := cluster.StartMountDaemon(cc.Name, m); err != nil {
+		exit.Error(reason.GuestMount, "Error starting mount", err)
+	}
+	deadline := time.Now().Add(mountStartTimeout)
+	for {
+		st := cluster.ReadMountDaemonState(cc.Name, name)
+		if st.Health == cluster.MountHealthy {
+			break
+		}
+		if st.Health == cluster.MountStopped || time.Now().After(deadline) {
+			if _, err := cluster.StopMountDaemon(cc.Name, name, 0); err != nil {
+				klog.Warningf("unable to stop mount %s: %v", name, err)
+			}
+			cc.ManagedMounts = removeManagedMount(cc.ManagedMounts, name)
+			if err := config.SaveProfile(cc.Name, cc); err != nil {
+				klog.Warningf("unable to save config: %v", err)
+			}
+			exit.Message(reason.GuestMount, "Mount {{.name}} failed, see the logs of the previous attempts in {{.log}}", out.V{"name": name, "log": localpath.MountLog(cc.Name, name)})
+		}
+		time.Sleep(time.Second)
+	}
+	out.Step(style.Success, "Successfully mounted {{.sourcePath}} to {{.destinationPath}} as {{.name}}", out.V{"sourcePath": source, "destinationPath": vmPath, "name": name})
+	out.Styled(style.Tip, "To list the background mounts, run: 'minikube mount list'. To stop this mount, run: 'minikube mount stop {{.name}}'", out.V{"name": name})
+}
+
+// watchManagedMount reports the health of the mount served by this process, and mounts it again when needed
+func watchManagedMount(co mustload.ClusterController, n *config.Node, runner command.Runner, remount func(command.Runner) error, target, fsType string) {
+	st := &cluster.MountDaemonState{Name: mountName, Pid: os.Getpid(), Health: cluster.MountHealthy, LastCheck: time.Now()}
+	if err := cluster.WriteMountDaemonState(co.Config.Name, st); err != nil {
+		klog.Warningf("unable to write mount state: %v", err)
+	}
+
+	// the runner is created again after errors, as the node might have rebooted with another address
+	currentRunner := func() (command.Runner, error) {
+		if runner != nil {
+			return runner, nil
+		}
+		h, err := machine.GetHost(co.API, *co.Config, *n)
+		if err != nil {
+			return nil, err
+		}
+		runner, err = machine.CommandRunner(h)
+		return runner, err
+	}
+	w := &cluster.MountWatchdog{
+		Interval: mountWatchInterval,
+		Check: func() (bool, error) {
+			r, err := currentRunner()
+			if err != nil {
+				return false, err
+			}
+			ok, err := cluster.IsMounted(r, target, fsType)
+			if err != nil {
+				runner = nil
+			}
+			return ok, err
+		},
+		Remount: func() error {
+			r, err := currentRunner()
+			if err != nil {
+				return err
+			}
+			out.Step(style.Mounting, "Mounting {{.target}} again ...", out.V{"target": target})
+			st.Remounts++
+			return remount(r)
+		},
+		Report: func(health string, err error) {
+			if err != nil {
+				klog.Warningf("mount %s is %s: %v", target, health, err)
+			}
+			st.Health, st.Error, st.LastCheck = health, "", time.Now()
+			if err != nil {
+				st.Error = err.Error()
+			}
+			if err := cluster.WriteMountDaemonState(co.Config.Name, st); err != nil {
+				klog.Warningf("unable to write mount state: %v", err)
+			}
+		},
+	}
+	w.Run(context.Background())
+}
+
+// unmountManagedMount unmounts the directory of a stopped mount, in case its process could not do it
+func unmountManagedMount(cc *config.ClusterConfig, m config.ManagedMount) {
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		klog.Warningf("unable to create api client: %v", err)
+		return
+	}
+	defer api.Close()
+	n, err := config.ControlPlane(*cc)
+	if m.Node != "" {
+		var np *config.Node
+		np, _, err = node.Retrieve(*cc, m.Node)
+		if np != nil {
+			n = *np
+		}
+	}
+	if err != nil {
+		klog.Warningf("unable to find node of mount %s: %v", m.Name, err)
+		return
+	}
+	h, err := machine.GetHost(api, *cc, n)
+	if err != nil {
+		klog.Infof("not unmounting %s: %v", m.Target, err)
+		return
+	}
+	r, err := machine.CommandRunner(h)
+	if err == nil {
+		err = cluster.Unmount(r, m.Target)
+	}
+	if err != nil {
+		klog.Warningf("unable to unmount %s: %v", m.Target, err)
+	}
+}
+
+// stopManagedMounts stops the processes of the background mounts of the profile, which are kept in its config
+// to be mounted again on start
+func stopManagedMounts(cc *config.ClusterConfig) {
+	for _, m := range cc.ManagedMounts {
+		if _, err := cluster.StopMountDaemon(cc.Name, m.Name, mountStopTimeout); err != nil {
+			out.FailureT("Failed to stop mount {{.name}}: {{.error}}", out.V{"name": m.Name, "error": err})
+		}
+	}
+}
+
+func findManagedMount(cc config.ClusterConfig, name string) (config.ManagedMount, bool) {
+	for _, m := range cc.ManagedMounts {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return config.ManagedMount{}, false
+}
+
+func removeManagedMount(mounts []config.ManagedMount, name string) []config.ManagedMount {
+	remaining := []config.ManagedMount{}
+	for _, m := range mounts {
+		if m.Name != name {
+			remaining = append(remaining, m)
+		}
+	}
+	return remaining
+}
+
+// managedMountStatus is a background mount as shown by 'minikube mount list'
+type managedMountStatus struct {
+	Name     string `json:"name"`
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	Type     string `json:"type"`
+	Node     string `json:"node"`
+	Health   string `json:"health"`
+	Error    string `json:"error,omitempty"`
+	Pid      int    `json:"pid,omitempty"`
+	Remounts int    `json:"remounts"`
+}
+
+func managedMountStatuses(cc config.ClusterConfig) []managedMountStatus {
+	mounts := []managedMountStatus{}
+	for _, m := range cc.ManagedMounts {
+		st := cluster.ReadMountDaemonState(cc.Name, m.Name)
+		nodeName := m.Node
+		if nodeName == "" {
+			if cp, err := config.ControlPlane(cc); err == nil {
+				nodeName = config.MachineName(cc, cp)
+			}
+		}
+		mounts = append(mounts, managedMountStatus{
+			Name:     m.Name,
+			Source:   m.Source,
+			Target:   m.Target,
+			Type:     m.Type,
+			Node:     nodeName,
+			Health:   st.Health,
+			Error:    st.Error,
+			Pid:      st.Pid,
+			Remounts: st.Remounts,
+		})
+	}
+	return mounts
+}
+
+func printMountsTable(mounts []managedMountStatus) {
+	if len(mounts) == 0 {
+		out.Styled(style.Empty, "No background mounts, create one with 'minikube mount --daemon'")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Name", "Source", "Target", "Type", "Node", "Health", "Remounts")
+	table.Options(
+		tablewriter.WithHeaderAutoFormat(tw.On),
+	)
+	var data [][]string
+	for _, m := range mounts {
+		health := m.Health
+		if m.Error != "" {
+			health += ": " + m.Error
+		}
+		data = append(data, []string{m.Name, m.Source, m.Target, m.Type, m.Node, health, strconv.Itoa(m.Remounts)})
+	}
+	if err := table.Bulk(data); err != nil {
+		klog.Error("Error rendering table (bulk)", err)
+	}
+	if err := table.Render(); err != nil {
+		klog.Error("Error rendering table", err)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestManagedMountNode(t *testing.T) {
+	cc := config.ClusterConfig{
+		Name: "minikube",
+		Nodes: []config.Node{
+			{Name: "", ControlPlane: true, Worker: true},
+			{Name: "m02", Worker: true},
+		},
+	}
+
+	tests := []struct {
+		description string
+		node        string
+		want        string
+		wantErr     bool
+	}{
+		{"default", "", "", false},
+		{"node name", "m02", "m02", false},
+		{"machine name", "minikube-m02", "m02", false},
+		{"primary machine name", "minikube", "", false},
+		{"unknown node", "minikube-m03", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			got, err := managedMountNode(cc, test.node)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("managedMountNode(%q) error = %v, wantErr %v", test.node, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("managedMountNode(%q) = %q, want %q", test.node, got, test.want)
+			}
+		})
+	}
+}
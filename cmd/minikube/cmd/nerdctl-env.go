@@ -0,0 +1,333 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
+	"gopkg.in/yaml.v2"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/shell"
+	"k8s.io/minikube/pkg/minikube/sshutil"
+)
+
+const (
+	// nerdctlForward is the name of the process forwarding the sockets for nerdctl-env
+	nerdctlForward = "nerdctl"
+	// containerdSocket is the socket of containerd in the node
+	containerdSocket = "/run/containerd/containerd.sock"
+	// buildkitSocket is the socket of buildkitd in the node, activated by systemd
+	buildkitSocket = "/run/buildkit/buildkitd.sock"
+	// containerdNamespace is the namespace of the images and containers of Kubernetes
+	containerdNamespace = "k8s.io"
+)
+
+var nerdctlEnvTmpl = fmt.Sprintf(
+	"{{ .Prefix }}%s{{ .Delimiter }}{{ .ContainerdAddress }}{{ .Suffix }}"+
+		"{{ .Prefix }}%s{{ .Delimiter }}{{ .ContainerdNamespace }}{{ .Suffix }}"+
+		"{{ .Prefix }}%s{{ .Delimiter }}{{ .BuildkitHost }}{{ .Suffix }}"+
+		"{{ .Prefix }}%s{{ .Delimiter }}{{ .MinikubeNerdctlProfile }}{{ .Suffix }}"+
+		"{{ .UsageHint }}",
+	constants.ContainerdAddressEnv,
+	constants.ContainerdNamespaceEnv,
+	constants.BuildkitHostEnv,
+	constants.MinikubeActiveNerdctlEnv)
+
+// NerdctlShellConfig represents the shell config for nerdctl
+type NerdctlShellConfig struct {
+	shell.Config
+	ContainerdAddress      string
+	ContainerdNamespace    string
+	BuildkitHost           string
+	MinikubeNerdctlProfile string
+}
+
+// NerdctlEnvConfig encapsulates all external inputs into shell generation for nerdctl
+type NerdctlEnvConfig struct {
+	shell.EnvConfig
+	profile string
+}
+
+var (
+	nerdctlUnset          bool
+	nerdctlForwardSockets bool
+)
+
+// nerdctlShellCfgSet generates context variables for "nerdctl-env"
+func nerdctlShellCfgSet(ec NerdctlEnvConfig, envMap map[string]string) *NerdctlShellConfig {
+	const usgPlz = "To point your shell to minikube's containerd and buildkitd, run:"
+	usgCmd := fmt.Sprintf("minikube -p %s nerdctl-env", ec.profile)
+	s := &NerdctlShellConfig{
+		Config: *shell.CfgSet(ec.EnvConfig, usgPlz, usgCmd),
+	}
+	s.ContainerdAddress = envMap[constants.ContainerdAddressEnv]
+	s.ContainerdNamespace = envMap[constants.ContainerdNamespaceEnv]
+	s.BuildkitHost = envMap[constants.BuildkitHostEnv]
+	s.MinikubeNerdctlProfile = envMap[constants.MinikubeActiveNerdctlEnv]
+
+	return s
+}
+
+// nerdctlSockets returns the sockets of the node forwarded to the host for profile
+func nerdctlSockets(profile string) []cluster.ForwardedSocket {
+	return []cluster.ForwardedSocket{
+		{Local: localpath.ForwardedSocket(profile, "containerd"), Remote: containerdSocket},
+		{Local: localpath.ForwardedSocket(profile, "buildkitd"), Remote: buildkitSocket},
+	}
+}
+
+// nerdctlEnvVars gets the nerdctl env variables pointing to the forwarded sockets of profile
+func nerdctlEnvVars(ec NerdctlEnvConfig) map[string]string {
+	sockets := nerdctlSockets(ec.profile)
+	return map[string]string{
+		constants.ContainerdAddressEnv:     sockets[0].Local,
+		constants.ContainerdNamespaceEnv:   containerdNamespace,
+		constants.BuildkitHostEnv:          "unix://" + sockets[1].Local,
+		constants.MinikubeActiveNerdctlEnv: ec.profile,
+	}
+}
+
+// nerdctlEnvNames gets the nerdctl env variables to reset after using minikube's containerd
+func nerdctlEnvNames() []string {
+	return []string{
+		constants.ContainerdAddressEnv,
+		constants.ContainerdNamespaceEnv,
+		constants.BuildkitHostEnv,
+		constants.MinikubeActiveNerdctlEnv,
+	}
+}
+
+// writeEnvOutput writes v in the output format, for the "none" shell
+func writeEnvOutput(w io.Writer, v interface{}) error {
+	switch outputFormat {
+	case "json":
+		jsondata, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(jsondata, '\n'))
+		return err
+	case "yaml":
+		yamldata, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(yamldata)
+		return err
+	}
+	exit.Message(reason.InternalOutputUsage, "error: --output must be 'text', 'yaml' or 'json'")
+	return nil
+}
+
+// nerdctlSetScript writes out a shell-compatible 'nerdctl-env' script
+func nerdctlSetScript(ec NerdctlEnvConfig, w io.Writer) error {
+	envVars := nerdctlEnvVars(ec)
+	if ec.Shell == "none" && outputFormat != "" {
+		if outputFormat == "text" {
+			for _, k := range nerdctlEnvNames() {
+				if _, err := fmt.Fprintf(w, "%s=%s\n", k, envVars[k]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return writeEnvOutput(w, envVars)
+	}
+	return shell.SetScript(w, nerdctlEnvTmpl, nerdctlShellCfgSet(ec, envVars))
+}
+
+// nerdctlUnsetScript writes out a shell-compatible 'nerdctl-env unset' script
+func nerdctlUnsetScript(ec NerdctlEnvConfig, w io.Writer) error {
+	vars := nerdctlEnvNames()
+	if ec.Shell == "none" && outputFormat != "" {
+		if outputFormat == "text" {
+			for _, n := range vars {
+				if _, err := fmt.Fprintf(w, "%s\n", n); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return writeEnvOutput(w, vars)
+	}
+	return shell.UnsetScript(ec.EnvConfig, w, vars)
+}
+
+// isContainerdAvailable checks that the socket of containerd, and socat to connect to it, are in the node
+func isContainerdAvailable(r command.Runner) bool {
+	if _, err := r.RunCmd(exec.Command("which", "socat")); err != nil {
+		return false
+	}
+	if _, err := r.RunCmd(exec.Command("sudo", "test", "-S", containerdSocket)); err != nil {
+		return false
+	}
+	return true
+}
+
+// ensureNerdctlForward starts the process forwarding the sockets of the node, unless it is running
+func ensureNerdctlForward(profile string) {
+	if cluster.SocketForwardDaemonRunning(profile, nerdctlForward) {
+		return
+	}
+	args := []string{"nerdctl-env", "--forward-sockets", "--profile", profile}
+	if err := cluster.StartSocketForwardDaemon(profile, nerdctlForward, args, nerdctlSockets(profile)[0].Local, 30*time.Second); err != nil {
+		exit.Error(reason.IfSocketForward, "Failed to forward the sockets of containerd", err)
+	}
+}
+
+// forwardNerdctlSockets forwards the sockets of the control plane of profile until it is interrupted
+func forwardNerdctlSockets(profile string) {
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		exit.Error(reason.NewAPIClient, "Failed to get machine client", err)
+	}
+	defer api.Close()
+
+	cc, err := config.Load(profile)
+	if err != nil {
+		exit.Error(reason.HostConfigLoad, "Error getting config", err)
+	}
+	cp, err := config.ControlPlane(*cc)
+	if err != nil {
+		exit.Error(reason.GuestCpConfig, "Error getting primary control plane", err)
+	}
+
+	dialer := &cluster.SSHSocketDialer{
+		// the host is loaded again, as its SSH port changes when the node is restarted
+		NewClient: func() (*ssh.Client, error) {
+			h, err := machine.LoadHost(api, config.MachineName(*cc, cp))
+			if err != nil {
+				return nil, err
+			}
+			return sshutil.NewSSHClient(h.Driver)
+		},
+	}
+	f := &cluster.SocketForwarder{Sockets: nerdctlSockets(profile), Dial: dialer.Dial}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	klog.Infof("forwarding %v", f.Sockets)
+	if err := f.Run(ctx); err != nil {
+		exit.Error(reason.IfSocketForward, "Failed to forward the sockets of containerd", err)
+	}
+}
+
+// nerdctlEnvCmd represents the nerdctl-env command
+var nerdctlEnvCmd = &cobra.Command{
+	Use:   "nerdctl-env",
+	Short: "Configure environment to use minikube's containerd and buildkitd",
+	Long: `Sets up nerdctl env variables, pointing to the containerd and buildkitd sockets of minikube, forwarded to the host.
+
+The sockets are forwarded over SSH by a process running in the background, until the cluster is deleted.
+
+Note: You need nerdctl (or buildctl) to be installed on your machine.`,
+	Example: `# build an image in the containerd of the cluster
+eval $(minikube nerdctl-env)
+nerdctl build -t my-image .`,
+	Run: func(_ *cobra.Command, _ []string) {
+		cname := ClusterFlagValue()
+		if nerdctlForwardSockets {
+			forwardNerdctlSockets(cname)
+			return
+		}
+
+		var err error
+		shl := shell.ForceShell
+		if outputFormat != "" {
+			shl = "none"
+		}
+		if shl == "" {
+			shl, err = shell.Detect()
+			if err != nil {
+				exit.Error(reason.InternalShellDetect, "Error detecting shell", err)
+			}
+		}
+		ec := NerdctlEnvConfig{
+			EnvConfig: shell.EnvConfig{Shell: shl},
+			profile:   cname,
+		}
+
+		if nerdctlUnset {
+			if err := nerdctlUnsetScript(ec, os.Stdout); err != nil {
+				exit.Error(reason.InternalEnvScript, "Error generating unset output", err)
+			}
+			return
+		}
+
+		if !out.IsTerminal(os.Stdout) {
+			out.SetSilent(true)
+			exit.SetShell(true)
+		}
+
+		co := mustload.Running(cname)
+
+		if co.CP.Host.DriverName == driver.None {
+			exit.Message(reason.Usage, `'none' driver does not support 'minikube nerdctl-env' command`)
+		}
+
+		if len(co.Config.Nodes) > 1 {
+			exit.Message(reason.EnvMultiConflict, `The nerdctl-env command is incompatible with multi-node clusters. Use the 'registry' add-on: https://minikube.sigs.k8s.io/docs/handbook/registry/`)
+		}
+
+		if co.Config.KubernetesConfig.ContainerRuntime != constants.Containerd {
+			exit.Message(reason.Usage, `The nerdctl-env command is only compatible with the "containerd" runtime, but this cluster was configured to use the "{{.runtime}}" runtime.`,
+				out.V{"runtime": co.Config.KubernetesConfig.ContainerRuntime})
+		}
+
+		if !isContainerdAvailable(co.CP.Runner) {
+			exit.Message(reason.EnvNerdctlUnavailable, `The containerd service within '{{.cluster}}' is not active`, out.V{"cluster": cname})
+		}
+
+		ensureNerdctlForward(cname)
+
+		if err := nerdctlSetScript(ec, os.Stdout); err != nil {
+			exit.Error(reason.InternalEnvScript, "Error generating set output", err)
+		}
+	},
+}
+
+func init() {
+	nerdctlEnvCmd.Flags().StringVar(&shell.ForceShell, "shell", "", "Force environment to be configured for a specified shell: [fish, cmd, powershell, tcsh, bash, zsh], default is auto-detect")
+	nerdctlEnvCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "One of 'text', 'yaml' or 'json'.")
+	nerdctlEnvCmd.Flags().BoolVarP(&nerdctlUnset, "unset", "u", false, "Unset variables instead of setting them")
+	nerdctlEnvCmd.Flags().BoolVar(&nerdctlForwardSockets, "forward-sockets", false, "Forward the sockets of the node, used by 'minikube nerdctl-env'")
+	if err := nerdctlEnvCmd.Flags().MarkHidden("forward-sockets"); err != nil {
+		klog.Warningf("unable to hide flag: %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func TestGenerateNerdctlScripts(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, "/home/user/.minikube")
+
+	var tests = []struct {
+		shell     string
+		output    string
+		config    NerdctlEnvConfig
+		wantSet   string
+		wantUnset string
+	}{
+		{
+			"bash",
+			"",
+			NerdctlEnvConfig{profile: "bash"},
+			`export CONTAINERD_ADDRESS="/home/user/.minikube/profiles/bash/sockets/containerd.sock"
+export CONTAINERD_NAMESPACE="k8s.io"
+export BUILDKIT_HOST="unix:///home/user/.minikube/profiles/bash/sockets/buildkitd.sock"
+export MINIKUBE_ACTIVE_NERDCTL="bash"
+
+# To point your shell to minikube's containerd and buildkitd, run:
+# eval $(minikube -p bash nerdctl-env)
+`,
+			`unset CONTAINERD_ADDRESS;
+unset CONTAINERD_NAMESPACE;
+unset BUILDKIT_HOST;
+unset MINIKUBE_ACTIVE_NERDCTL;
+`,
+		},
+		{
+			"fish",
+			"",
+			NerdctlEnvConfig{profile: "fish"},
+			`set -gx CONTAINERD_ADDRESS "/home/user/.minikube/profiles/fish/sockets/containerd.sock";
+set -gx CONTAINERD_NAMESPACE "k8s.io";
+set -gx BUILDKIT_HOST "unix:///home/user/.minikube/profiles/fish/sockets/buildkitd.sock";
+set -gx MINIKUBE_ACTIVE_NERDCTL "fish";
+
+# To point your shell to minikube's containerd and buildkitd, run:
+# minikube -p fish nerdctl-env | source
+`,
+			`set -e CONTAINERD_ADDRESS;
+set -e CONTAINERD_NAMESPACE;
+set -e BUILDKIT_HOST;
+set -e MINIKUBE_ACTIVE_NERDCTL;
+`,
+		},
+		{
+			"powershell",
+			"",
+			NerdctlEnvConfig{profile: "powershell"},
+			`$Env:CONTAINERD_ADDRESS = "/home/user/.minikube/profiles/powershell/sockets/containerd.sock"
+$Env:CONTAINERD_NAMESPACE = "k8s.io"
+$Env:BUILDKIT_HOST = "unix:///home/user/.minikube/profiles/powershell/sockets/buildkitd.sock"
+$Env:MINIKUBE_ACTIVE_NERDCTL = "powershell"
+# To point your shell to minikube's containerd and buildkitd, run:
+# & minikube -p powershell nerdctl-env --shell powershell | Invoke-Expression
+`,
+			`Remove-Item Env:\\CONTAINERD_ADDRESS
+Remove-Item Env:\\CONTAINERD_NAMESPACE
+Remove-Item Env:\\BUILDKIT_HOST
+Remove-Item Env:\\MINIKUBE_ACTIVE_NERDCTL
+`,
+		},
+		{
+			"none",
+			"text",
+			NerdctlEnvConfig{profile: "text"},
+			`CONTAINERD_ADDRESS=/home/user/.minikube/profiles/text/sockets/containerd.sock
+CONTAINERD_NAMESPACE=k8s.io
+BUILDKIT_HOST=unix:///home/user/.minikube/profiles/text/sockets/buildkitd.sock
+MINIKUBE_ACTIVE_NERDCTL=text
+`,
+			`CONTAINERD_ADDRESS
+CONTAINERD_NAMESPACE
+BUILDKIT_HOST
+MINIKUBE_ACTIVE_NERDCTL
+`,
+		},
+		{
+			"none",
+			"json",
+			NerdctlEnvConfig{profile: "json"},
+			`{"BUILDKIT_HOST":"unix:///home/user/.minikube/profiles/json/sockets/buildkitd.sock","CONTAINERD_ADDRESS":"/home/user/.minikube/profiles/json/sockets/containerd.sock","CONTAINERD_NAMESPACE":"k8s.io","MINIKUBE_ACTIVE_NERDCTL":"json"}
+`,
+			`["CONTAINERD_ADDRESS","CONTAINERD_NAMESPACE","BUILDKIT_HOST","MINIKUBE_ACTIVE_NERDCTL"]
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.config.profile, func(t *testing.T) {
+			tc.config.EnvConfig.Shell = tc.shell
+			// set global variable
+			outputFormat = tc.output
+			defer func() { outputFormat = "" }()
+
+			var b []byte
+			buf := bytes.NewBuffer(b)
+			if err := nerdctlSetScript(tc.config, buf); err != nil {
+				t.Errorf("setScript(%+v) error: %v", tc.config, err)
+			}
+			got := buf.String()
+			if diff := cmp.Diff(tc.wantSet, got); diff != "" {
+				t.Errorf("setScript(%+v) mismatch (-want +got):\n%s\n\nraw output:\n%s\nquoted: %q", tc.config, diff, got, got)
+			}
+
+			buf = bytes.NewBuffer(b)
+			if err := nerdctlUnsetScript(tc.config, buf); err != nil {
+				t.Errorf("unsetScript(%+v) error: %v", tc.config, err)
+			}
+			got = buf.String()
+			if diff := cmp.Diff(tc.wantUnset, got); diff != "" {
+				t.Errorf("unsetScript(%+v) mismatch (-want +got):\n%s\n\nraw output:\n%s\nquoted: %q", tc.config, diff, got, got)
+			}
+		})
+	}
+}
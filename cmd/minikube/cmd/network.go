@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/node"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var networkConnectDNS bool
+
+// networkCmd represents the set of network subcommands
+var networkCmd = &cobra.Command{
+	Use:   "network",
+	Short: "Connect clusters of different profiles",
+	Long:  "Connect the pod and service networks of clusters of different profiles using the docker or podman driver, to test multi-cluster setups",
+	Run: func(_ *cobra.Command, _ []string) {
+		exit.Message(reason.Usage, "Usage: minikube network [connect|disconnect] PROFILE_A PROFILE_B")
+	},
+}
+
+var networkConnectCmd = &cobra.Command{
+	Use:   "connect PROFILE_A PROFILE_B",
+	Short: "Connects the clusters of two profiles",
+	Long: `Connects the clusters of two profiles using the same docker or podman driver: their nodes are attached to a shared network,
+and the pod and service CIDRs of each cluster are routed through the nodes of the other, which must not overlap.
+With --dns, the DNS domain of each cluster is also resolved from the other, which must differ.
+The connection is restored when either cluster is started again.`,
+	Example: "minikube network connect cluster-a cluster-b --dns",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 2 {
+			exit.Message(reason.Usage, "Usage: minikube network connect PROFILE_A PROFILE_B")
+		}
+
+		a, b := mustload.Running(args[0]).Config, mustload.Running(args[1]).Config
+		out.Step(style.Connectivity, "Connecting clusters {{.a}} and {{.b}} ...", out.V{"a": a.Name, "b": b.Name})
+		if err := node.ConnectProfiles(a, b, networkConnectDNS); err != nil {
+			exit.Error(reason.GuestNetworkConnect, "Unable to connect the clusters", err)
+		}
+
+		out.Step(style.Ready, "Clusters {{.a}} and {{.b}} are connected.", out.V{"a": a.Name, "b": b.Name})
+		if networkConnectDNS {
+			out.Styled(style.Tip, "Services of {{.a}} resolve as <service>.<namespace>.svc.{{.domainA}} in {{.b}}, and services of {{.b}} as <service>.<namespace>.svc.{{.domainB}} in {{.a}}",
+				out.V{"a": a.Name, "b": b.Name, "domainA": node.DNSDomain(*a), "domainB": node.DNSDomain(*b)})
+		}
+	},
+}
+
+var networkDisconnectCmd = &cobra.Command{
+	Use:     "disconnect PROFILE_A PROFILE_B",
+	Short:   "Disconnects the clusters of two profiles",
+	Long:    "Disconnects the clusters of two profiles connected by 'minikube network connect', removing their routes, DNS domains and shared network",
+	Example: "minikube network disconnect cluster-a cluster-b",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 2 {
+			exit.Message(reason.Usage, "Usage: minikube network disconnect PROFILE_A PROFILE_B")
+		}
+
+		a, b := mustload.Running(args[0]).Config, mustload.Running(args[1]).Config
+		if err := node.DisconnectProfiles(a, b); err != nil {
+			exit.Error(reason.GuestNetworkDisconnect, "Unable to disconnect the clusters", err)
+		}
+		out.Step(style.Deleted, "Clusters {{.a}} and {{.b}} are disconnected.", out.V{"a": a.Name, "b": b.Name})
+	},
+}
+
+func init() {
+	networkConnectCmd.Flags().BoolVar(&networkConnectDNS, "dns", false, "Resolve the DNS domain of each cluster from the other, using CoreDNS stub domains")
+	networkCmd.AddCommand(networkConnectCmd)
+	networkCmd.AddCommand(networkDisconnectCmd)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/node"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var nodePromoteCmd = &cobra.Command{
+	Use:   "promote",
+	Short: "Promotes a worker node to a control-plane node.",
+	Long:  "Promotes a worker node of an HA (multi-control plane) cluster to a control-plane node, which joins the control plane and etcd.",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) == 0 {
+			exit.Message(reason.Usage, "Usage: minikube node promote [name]")
+		}
+		name := args[0]
+
+		co := mustload.Healthy(ClusterFlagValue())
+		out.Step(style.Restarting, "Promoting node {{.name}} to a control-plane node in cluster {{.cluster}}", out.V{"name": name, "cluster": co.Config.Name})
+
+		if _, err := node.Promote(co.Config, name); err != nil {
+			exit.Error(reason.GuestNodePromote, "promoting node", err)
+		}
+
+		out.Step(style.Ready, "Node {{.name}} was successfully promoted to a control-plane node.", out.V{"name": name})
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodePromoteCmd)
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/node"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var portsOutput string
+
+// portsCmd represents the set of ports subcommands
+var portsCmd = &cobra.Command{
+	Use:   "ports",
+	Short: "Publish ports of a running docker or podman cluster on the host",
+	Long:  "Add, remove and list the node ports published on the host for clusters using the docker or podman driver, without recreating the cluster",
+	Run: func(_ *cobra.Command, _ []string) {
+		exit.Message(reason.Usage, "Usage: minikube ports [add|rm|list]")
+	},
+}
+
+var portsAddCmd = &cobra.Command{
+	Use:   "add [listen address:]HOST_PORT:NODE_PORT[/PROTOCOL] ...",
+	Short: "Publishes ports of the node on the host",
+	Long: `Publishes ports of the node on the host, using the same format as 'minikube start --ports'.
+The ports are kept in the profile and published again after 'minikube stop' and 'minikube start'.`,
+	Example: "minikube ports add 8443:30443/tcp",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) == 0 {
+			exit.Message(reason.Usage, "Usage: minikube ports add [listen address:]HOST_PORT:NODE_PORT[/PROTOCOL] ...")
+		}
+		co := mustload.Running(ClusterFlagValue())
+		cc := co.Config
+		validatePortsDriver(cc)
+
+		for _, spec := range args {
+			p, err := oci.ParsePortForwarder(spec)
+			if err != nil {
+				exit.Message(reason.Usage, "{{.err}}", out.V{"err": err})
+			}
+			if hostPort, ok := nodePublishedPort(*cc, p); ok && hostPort == p.HostPort {
+				out.Styled(style.Check, "Port {{.port}} is already published on host port {{.hostPort}}", out.V{"port": p.ContainerPort, "hostPort": hostPort})
+				continue
+			}
+			out.Step(style.Connectivity, "Publishing port {{.port}} ...", out.V{"port": p})
+			if err := node.PublishPort(*cc, p); err != nil {
+				exit.Error(reason.GuestPortsPublish, "Failed to publish port", err)
+			}
+			cc.PublishedPorts = append(removePublishedPort(cc.PublishedPorts, p.HostPort, p.Protocol), p.String())
+			if err := config.SaveProfile(cc.Name, cc); err != nil {
+				exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+			}
+		}
+	},
+}
+
+var portsRmCmd = &cobra.Command{
+	Use:     "rm HOST_PORT[/PROTOCOL] ...",
+	Aliases: []string{"remove"},
+	Short:   "Stops publishing ports added with 'minikube ports add'",
+	Example: "minikube ports rm 8443/tcp",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) == 0 {
+			exit.Message(reason.Usage, "Usage: minikube ports rm HOST_PORT[/PROTOCOL] ...")
+		}
+		co := mustload.Running(ClusterFlagValue())
+		cc := co.Config
+		validatePortsDriver(cc)
+
+		for _, arg := range args {
+			hostPort, proto, err := parseHostPort(arg)
+			if err != nil {
+				exit.Message(reason.Usage, "{{.err}}", out.V{"err": err})
+			}
+			remaining := removePublishedPort(cc.PublishedPorts, hostPort, proto)
+			if len(remaining) == len(cc.PublishedPorts) {
+				exit.Message(reason.Usage, "Host port {{.port}} was not published by 'minikube ports add'", out.V{"port": arg})
+			}
+			p := oci.PortForwarder{HostPort: hostPort, Protocol: proto}
+			if err := oci.DeletePortForwarder(cc.Driver, cc.Name, p); err != nil {
+				exit.Error(reason.GuestPortsPublish, "Failed to stop publishing port", err)
+			}
+			cc.PublishedPorts = remaining
+			if err := config.SaveProfile(cc.Name, cc); err != nil {
+				exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+			}
+			out.Step(style.Deleted, "Stopped publishing host port {{.port}}", out.V{"port": arg})
+		}
+	},
+}
+
+var portsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the ports published on the host",
+	Long:  "Lists the ports published on the host, both at creation with 'minikube start --ports' and afterwards with 'minikube ports add'",
+	Run: func(_ *cobra.Command, _ []string) {
+		co := mustload.Running(ClusterFlagValue())
+		validatePortsDriver(co.Config)
+		ports := publishedPorts(*co.Config)
+
+		switch strings.ToLower(portsOutput) {
+		case "table":
+			printPortsTable(ports)
+		case "json":
+			printPortsJSON(ports)
+		default:
+			exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'table', 'json'", portsOutput))
+		}
+	},
+}
+
+// publishedPort is a port of the node published on the host
+type publishedPort struct {
+	ListenAddress string `json:"listenAddress"`
+	HostPort      string `json:"hostPort"`
+	NodePort      int    `json:"nodePort"`
+	Protocol      string `json:"protocol"`
+	Source        string `json:"source"`
+	Status        string `json:"status"`
+}
+
+func validatePortsDriver(cc *config.ClusterConfig) {
+	if !driver.IsKIC(cc.Driver) {
+		exit.Message(reason.Usage, "'minikube ports' is only supported with the docker and podman drivers")
+	}
+}
+
+// nodePublishedPort returns the host port the node container already publishes for a tcp node port
+func nodePublishedPort(cc config.ClusterConfig, p oci.PortForwarder) (int, bool) {
+	if p.Protocol != "tcp" {
+		return 0, false
+	}
+	cp, err := config.ControlPlane(cc)
+	if err != nil {
+		return 0, false
+	}
+	hostPort, err := oci.ForwardedPort(cc.Driver, config.MachineName(cc, cp), p.ContainerPort)
+	if err != nil {
+		klog.Infof("port %d is not published by the node container: %v", p.ContainerPort, err)
+		return 0, false
+	}
+	return hostPort, true
+}
+
+// parseHostPort parses a HOST_PORT[/PROTOCOL] argument, the protocol defaults to tcp
+func parseHostPort(arg string) (int, string, error) {
+	proto, port := nat.SplitProtoPort(arg)
+	hostPort, err := strconv.Atoi(port)
+	if err != nil || hostPort < 1 || hostPort > 65535 {
+		return 0, "", fmt.Errorf("invalid host port %q", arg)
+	}
+	return hostPort, proto, nil
+}
+
+// removePublishedPort returns the published ports without the one using the given host port and protocol
+func removePublishedPort(specs []string, hostPort int, proto string) []string {
+	remaining := []string{}
+	for _, spec := range specs {
+		p, err := oci.ParsePortForwarder(spec)
+		if err == nil && p.HostPort == hostPort && p.Protocol == proto {
+			continue
+		}
+		remaining = append(remaining, spec)
+	}
+	return remaining
+}
+
+// publishedPorts returns the ports published at creation and the ones added afterwards
+func publishedPorts(cc config.ClusterConfig) []publishedPort {
+	ports := []publishedPort{}
+	for _, spec := range cc.ExposedPorts {
+		mappings, err := nat.ParsePortSpec(spec)
+		if err != nil {
+			klog.Warningf("skipping invalid port %q: %v", spec, err)
+			continue
+		}
+		for _, m := range mappings {
+			pp := publishedPort{ListenAddress: m.Binding.HostIP, HostPort: m.Binding.HostPort, NodePort: m.Port.Int(), Protocol: m.Port.Proto(), Source: "start", Status: "Running"}
+			if pp.HostPort == "" {
+				pp.HostPort = "-"
+				if hostPort, ok := nodePublishedPort(cc, oci.PortForwarder{ContainerPort: pp.NodePort, Protocol: pp.Protocol}); ok {
+					pp.HostPort = strconv.Itoa(hostPort)
+				}
+			}
+			ports = append(ports, pp)
+		}
+	}
+	for _, spec := range cc.PublishedPorts {
+		p, err := oci.ParsePortForwarder(spec)
+		if err != nil {
+			klog.Warningf("skipping invalid published port %q: %v", spec, err)
+			continue
+		}
+		pp := publishedPort{ListenAddress: p.ListenAddress, HostPort: strconv.Itoa(p.HostPort), NodePort: p.ContainerPort, Protocol: p.Protocol, Source: "ports add"}
+		st, err := oci.ContainerStatus(cc.Driver, oci.PortForwarderName(cc.Name, p))
+		if err != nil {
+			pp.Status = "Missing"
+		} else {
+			pp.Status = st.String()
+		}
+		ports = append(ports, pp)
+	}
+	return ports
+}
+
+func printPortsTable(ports []publishedPort) {
+	if len(ports) == 0 {
+		out.Styled(style.Empty, "No ports are published")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Listen Address", "Host Port", "Node Port", "Protocol", "Source", "Status")
+	table.Options(
+		tablewriter.WithHeaderAutoFormat(tw.On),
+	)
+	var data [][]string
+	for _, p := range ports {
+		data = append(data, []string{p.ListenAddress, p.HostPort, strconv.Itoa(p.NodePort), p.Protocol, p.Source, p.Status})
+	}
+	if err := table.Bulk(data); err != nil {
+		klog.Error("Error rendering table (bulk)", err)
+	}
+	if err := table.Render(); err != nil {
+		klog.Error("Error rendering table", err)
+	}
+}
+
+func printPortsJSON(ports []publishedPort) {
+	b, err := json.Marshal(ports)
+	if err != nil {
+		exit.Error(reason.InternalJSONMarshal, "Failed to marshal ports", err)
+	}
+	out.String(string(b))
+}
+
+func init() {
+	portsListCmd.Flags().StringVarP(&portsOutput, "output", "o", "table", "The output format. One of 'json', 'table'")
+	portsCmd.AddCommand(portsAddCmd)
+	portsCmd.AddCommand(portsRmCmd)
+	portsCmd.AddCommand(portsListCmd)
+}
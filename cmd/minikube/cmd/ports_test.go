@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHostPort(t *testing.T) {
+	tests := []struct {
+		arg       string
+		wantPort  int
+		wantProto string
+		wantErr   bool
+	}{
+		{arg: "8443", wantPort: 8443, wantProto: "tcp"},
+		{arg: "5353/udp", wantPort: 5353, wantProto: "udp"},
+		{arg: "0", wantErr: true},
+		{arg: "http", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.arg, func(t *testing.T) {
+			port, proto, err := parseHostPort(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseHostPort(%q) error = %v, wantErr %v", tc.arg, err, tc.wantErr)
+			}
+			if port != tc.wantPort || proto != tc.wantProto {
+				t.Errorf("parseHostPort(%q) = %d, %q, want %d, %q", tc.arg, port, proto, tc.wantPort, tc.wantProto)
+			}
+		})
+	}
+}
+
+func TestRemovePublishedPort(t *testing.T) {
+	specs := []string{"127.0.0.1:8443:30443/tcp", "127.0.0.1:8443:30053/udp", "0.0.0.0:8080:30080/tcp"}
+	got := removePublishedPort(specs, 8443, "tcp")
+	want := []string{"127.0.0.1:8443:30053/udp", "0.0.0.0:8080:30080/tcp"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("removePublishedPort() = %v, want %v", got, want)
+	}
+	if got := removePublishedPort(specs, 9000, "tcp"); len(got) != len(specs) {
+		t.Errorf("removePublishedPort() removed a port not published: %v", got)
+	}
+}
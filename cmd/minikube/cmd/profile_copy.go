@@ -0,0 +1,282 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"slices"
+
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/delete"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/kubeconfig"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/node"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/minikube/tunnel"
+)
+
+// kubeletPKIDir is the directory of the certificates of the kubelet, issued for the name of its node
+const kubeletPKIDir = "/var/lib/kubelet/pki"
+
+var profileCloneKeepData bool
+
+var profileRenameCmd = &cobra.Command{
+	Use:   "rename OLD NEW",
+	Short: "Renames a profile",
+	Long: `Renames a stopped profile using the docker or podman driver, keeping the data of its cluster.
+The volumes of its nodes are copied to volumes of the new name, then its containers and network are removed,
+and created again with the new name on next 'minikube start -p NEW', along with certificates for the new name.
+The Kubernetes nodes are registered again under their new names on that start, and the nodes of the old names are removed.
+The background mounts, tunnel and socket forwarding of the profile are stopped first, the background mounts are mounted again on start.
+The kubectl contexts and tunnels of the profile are renamed, and the profile stays the active one if it was.
+The profiles using other drivers, such as the VM drivers, cannot be renamed: clone them without --keep-data, and delete them, instead.`,
+	Example: "minikube profile rename minikube team-a",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 2 {
+			exit.Message(reason.Usage, "Usage: minikube profile rename OLD NEW")
+		}
+		cc, name := loadProfileToCopy(args[0], args[1], true)
+		active := ClusterFlagValue() == cc.Name
+
+		out.Step(style.Copying, "Renaming profile {{.old}} to {{.new}} ...", out.V{"old": cc.Name, "new": name})
+		nc, err := renameProfile(cc, name)
+		if err != nil {
+			exit.Error(reason.GuestProfileRename, "Unable to rename the profile", err)
+		}
+		if active {
+			if err := cmdcfg.Set(config.ProfileName, name); err != nil {
+				exit.Error(reason.InternalConfigSet, "Setting profile failed", err)
+			}
+		}
+
+		out.Step(style.Ready, "Profile {{.old}} was renamed to {{.new}}.", out.V{"old": cc.Name, "new": nc.Name})
+		out.Styled(style.Tip, "To start the cluster, run: minikube start -p {{.new}}", out.V{"new": nc.Name})
+	},
+}
+
+var profileCloneCmd = &cobra.Command{
+	Use:   "clone SRC DST",
+	Short: "Clones a profile",
+	Long: `Clones the configuration of a profile into a new profile, whose cluster is created on 'minikube start -p DST'.
+With --keep-data, the data of the stopped cluster of SRC, which must use the docker or podman driver, is copied too,
+and its Kubernetes nodes are replaced by the nodes of DST on first start.
+The static IP and subnet of SRC are not cloned, so that both clusters can run at the same time.`,
+	Example: "minikube profile clone minikube team-b --keep-data",
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 2 {
+			exit.Message(reason.Usage, "Usage: minikube profile clone SRC DST [--keep-data]")
+		}
+		cc, name := loadProfileToCopy(args[0], args[1], profileCloneKeepData)
+
+		out.Step(style.Copying, "Cloning profile {{.src}} to {{.dst}} ...", out.V{"src": cc.Name, "dst": name})
+		nc, err := cloneProfile(cc, name, profileCloneKeepData)
+		if err != nil {
+			exit.Error(reason.GuestProfileClone, "Unable to clone the profile", err)
+		}
+
+		out.Step(style.Ready, "Profile {{.src}} was cloned to {{.dst}}.", out.V{"src": cc.Name, "dst": nc.Name})
+		out.Styled(style.Tip, "To start the cluster, run: minikube start -p {{.dst}}", out.V{"dst": nc.Name})
+	},
+}
+
+// loadProfileToCopy loads the profile to rename or clone, and checks the new name is available.
+// Its data can only be copied from the volumes of the nodes of a stopped docker or podman cluster.
+func loadProfileToCopy(profile, name string, copyData bool) (*config.ClusterConfig, string) {
+	if !config.ProfileNameValid(name) {
+		out.WarningT("Profile name '{{.profilename}}' is not valid", out.V{"profilename": name})
+		exit.Message(reason.Usage, "Only alphanumeric and dashes '-' are permitted. Minimum 1 character, starting with alphanumeric.")
+	}
+	if config.ProfileNameInReservedKeywords(name) {
+		exit.Message(reason.InternalReservedProfile, `Profile name "{{.profilename}}" is reserved keyword.`, out.V{"profilename": name})
+	}
+	if config.ProfileExists(name) {
+		exit.Message(reason.Usage, `Profile "{{.profilename}}" already exists.`, out.V{"profilename": name})
+	}
+
+	api, cc := mustload.Partial(profile)
+	if !copyData {
+		return cc, name
+	}
+	if !driver.IsKIC(cc.Driver) {
+		exit.Message(reason.Unimplemented, "Copying the data of a cluster is only supported with the docker and podman drivers, not {{.driver}}", out.V{"driver": cc.Driver})
+	}
+	for _, n := range cc.Nodes {
+		if st, err := machine.Status(api, config.MachineName(*cc, n)); err == nil && st == state.Running.String() {
+			exit.Message(reason.Usage, `The cluster must be stopped first, run: "{{.cmd}}"`, out.V{"cmd": mustload.ExampleCmd(cc.Name, "stop")})
+		}
+	}
+	return cc, name
+}
+
+// copiedConfig returns the config of the cluster under the new name, not connected to other clusters
+func copiedConfig(cc config.ClusterConfig, name string) *config.ClusterConfig {
+	nc := cc
+	nc.Name = name
+	nc.KubernetesConfig.ClusterName = name
+	if nc.Network == cc.Name {
+		nc.Network = ""
+	}
+	nc.Nodes = slices.Clone(cc.Nodes)
+	nc.ConnectedProfiles = nil
+	nc.ScheduledStop = nil
+	nc.CopiedFrom = ""
+	return &nc
+}
+
+// copiedFrom returns the profile whose nodes are in the data of the cluster, which is the profile itself
+// unless its data was copied and it was not started since
+func copiedFrom(cc config.ClusterConfig) string {
+	if cc.CopiedFrom != "" {
+		return cc.CopiedFrom
+	}
+	return cc.Name
+}
+
+// renameProfile moves the profile to the new name: the data of the cluster is moved to the volumes of the new name,
+// and the containers of the old name are removed, to be created again with the new name, its certificates included, on next start.
+// The containers and volumes of the old name are only removed once the profile is saved under the new name.
+func renameProfile(cc *config.ClusterConfig, name string) (*config.ClusterConfig, error) {
+	nc := copiedConfig(*cc, name)
+	if err := stopProfileDaemons(cc); err != nil {
+		return nil, err
+	}
+
+	if err := copyVolumes(*cc, *nc); err != nil {
+		removeCopiedVolumes(*nc)
+		return nil, err
+	}
+	nc.CopiedFrom = copiedFrom(*cc)
+	if err := os.Rename(localpath.Profile(cc.Name), localpath.Profile(name)); err != nil {
+		removeCopiedVolumes(*nc)
+		return nil, errors.Wrap(err, "move profile directory")
+	}
+	if err := config.SaveProfile(name, nc); err != nil {
+		if err := os.Rename(localpath.Profile(name), localpath.Profile(cc.Name)); err != nil {
+			klog.Warningf("unable to move the profile directory back to %s: %v", localpath.Profile(cc.Name), err)
+		}
+		removeCopiedVolumes(*nc)
+		return nil, errors.Wrap(err, "save profile")
+	}
+
+	// the shared networks are named after the profiles
+	node.DisconnectAll(*cc)
+	for _, n := range cc.Nodes {
+		machineName := config.MachineName(*cc, n)
+		delete.PossibleLeftOvers(context.Background(), machineName, cc.Driver)
+		deleteProfileDirectory(machineName)
+	}
+
+	if err := kubeconfig.RenameContext(cc.Name, name, localpath.Profile(cc.Name), localpath.Profile(name), config.KubeconfigPath(nc)); err != nil {
+		return nil, errors.Wrap(err, "rename kubeconfig context")
+	}
+	if err := tunnel.NewManager().RenameMachine(cc.Name, name); err != nil {
+		klog.Warningf("unable to rename the tunnels of %s: %v", cc.Name, err)
+	}
+	return nc, nil
+}
+
+// removeCopiedVolumes removes the volumes of the nodes of a copy of a cluster, when the copy failed
+func removeCopiedVolumes(nc config.ClusterConfig) {
+	for _, n := range nc.Nodes {
+		volume := config.MachineName(nc, n)
+		if err := oci.RemoveVolume(nc.Driver, volume); err != nil {
+			klog.Warningf("unable to remove the volume %s: %v", volume, err)
+		}
+	}
+}
+
+// stopProfileDaemons stops the processes running in the background for the profile, which are bound to its name
+// and would be orphaned by the move of the profile directory holding their pid files.
+// The background mounts are kept in the config, to be mounted again on start.
+func stopProfileDaemons(cc *config.ClusterConfig) error {
+	for _, m := range cc.ManagedMounts {
+		if _, err := cluster.StopMountDaemon(cc.Name, m.Name, mountStopTimeout); err != nil {
+			return errors.Wrapf(err, "stop mount %s", m.Name)
+		}
+	}
+	if err := cluster.StopSocketForwardDaemon(cc.Name, nerdctlForward); err != nil {
+		return errors.Wrap(err, "stop socket forwarding")
+	}
+	if _, err := tunnel.StopDaemon(cc.Name, tunnelStopTimeout); err != nil {
+		return errors.Wrap(err, "stop tunnel")
+	}
+	return nil
+}
+
+// cloneProfile saves the config of the cluster under the new name, and copies the data of the cluster if keepData.
+// The static IP and subnet are cleared, as the cloned cluster gets a network of its own.
+func cloneProfile(cc *config.ClusterConfig, name string, keepData bool) (*config.ClusterConfig, error) {
+	nc := copiedConfig(*cc, name)
+	nc.StaticIP = ""
+	nc.Subnet = ""
+	for i := range nc.Nodes {
+		nc.Nodes[i].IP = ""
+	}
+
+	if keepData {
+		if err := copyVolumes(*cc, *nc); err != nil {
+			removeCopiedVolumes(*nc)
+			return nil, err
+		}
+		nc.CopiedFrom = copiedFrom(*cc)
+	}
+	if err := config.SaveProfile(name, nc); err != nil {
+		if keepData {
+			removeCopiedVolumes(*nc)
+		}
+		return nil, errors.Wrap(err, "save profile")
+	}
+	return nc, nil
+}
+
+// copyVolumes copies the volumes of the nodes of the cluster to the volumes of the nodes of its copy.
+// The client certificates of the kubelets are not copied, as they are issued for the names of the nodes,
+// so that the kubelets register the nodes of the copy under their new names on next start.
+func copyVolumes(cc, nc config.ClusterConfig) error {
+	for i, n := range cc.Nodes {
+		src, dst := config.MachineName(cc, n), config.MachineName(nc, nc.Nodes[i])
+		out.Styled(style.Copying, "Copying the data of {{.src}} to {{.dst}} ...", out.V{"src": src, "dst": dst})
+		if err := oci.CopyVolume(cc.Driver, src, dst, cc.KicBaseImage); err != nil {
+			return err
+		}
+		if err := oci.RemoveVolumePaths(cc.Driver, dst, cc.KicBaseImage, kubeletPKIDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	profileCloneCmd.Flags().BoolVar(&profileCloneKeepData, "keep-data", false, "Copy the data of the stopped cluster, only supported with the docker and podman drivers")
+	cmdcfg.ProfileCmd.AddCommand(profileRenameCmd)
+	cmdcfg.ProfileCmd.AddCommand(profileCloneCmd)
+}
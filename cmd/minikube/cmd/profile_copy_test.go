@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestCopiedConfig(t *testing.T) {
+	tests := []struct {
+		description string
+		network     string
+		wantNetwork string
+	}{
+		{"default network", "", ""},
+		{"network named after the profile", "minikube", ""},
+		{"user network", "shared", "shared"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			cc := config.ClusterConfig{
+				Name:              "minikube",
+				Network:           tc.network,
+				KubernetesConfig:  config.KubernetesConfig{ClusterName: "minikube"},
+				Nodes:             []config.Node{{Name: "", IP: "192.168.49.2"}, {Name: "m02", IP: "192.168.49.3"}},
+				ConnectedProfiles: []string{"other"},
+				CopiedFrom:        "old",
+			}
+			nc := copiedConfig(cc, "team-a")
+
+			if nc.Name != "team-a" || nc.KubernetesConfig.ClusterName != "team-a" {
+				t.Errorf("got name %q and cluster name %q, want team-a", nc.Name, nc.KubernetesConfig.ClusterName)
+			}
+			if nc.Network != tc.wantNetwork {
+				t.Errorf("got network %q, want %q", nc.Network, tc.wantNetwork)
+			}
+			if nc.ConnectedProfiles != nil {
+				t.Errorf("got connected profiles %v, want none", nc.ConnectedProfiles)
+			}
+			if nc.CopiedFrom != "" {
+				t.Errorf("got copied from %q, want none", nc.CopiedFrom)
+			}
+			if got := config.MachineName(*nc, nc.Nodes[1]); got != "team-a-m02" {
+				t.Errorf("got machine name %q, want team-a-m02", got)
+			}
+			// the nodes of the copy must not alias those of the profile
+			nc.Nodes[0].IP = ""
+			if cc.Nodes[0].IP != "192.168.49.2" {
+				t.Errorf("the nodes of the profile were modified: %+v", cc.Nodes)
+			}
+		})
+	}
+}
+
+func TestCopiedFrom(t *testing.T) {
+	if got := copiedFrom(config.ClusterConfig{Name: "team-a"}); got != "team-a" {
+		t.Errorf("copiedFrom() = %q, want team-a", got)
+	}
+	// the data of a copy not started since still has the nodes of the first profile
+	if got := copiedFrom(config.ClusterConfig{Name: "team-b", CopiedFrom: "team-a"}); got != "team-a" {
+		t.Errorf("copiedFrom() of a copy = %q, want team-a", got)
+	}
+}
@@ -246,6 +246,7 @@ func init() {
 			Commands: []*cobra.Command{
 				dockerEnvCmd,
 				podmanEnvCmd,
+				nerdctlEnvCmd,
 				cacheCmd,
 				imageCmd,
 			},
@@ -257,6 +258,7 @@ func init() {
 				configCmd.ConfigCmd,
 				configCmd.ProfileCmd,
 				updateContextCmd,
+				kubeconfigCmd,
 			},
 		},
 		{
@@ -264,6 +266,8 @@ func init() {
 			Commands: []*cobra.Command{
 				serviceCmd,
 				tunnelCmd,
+				portsCmd,
+				cniCmd,
 			},
 		},
 		{
@@ -274,6 +278,8 @@ func init() {
 				kubectlCmd,
 				nodeCmd,
 				cpCmd,
+				etcdCmd,
+				networkCmd,
 			},
 		},
 		{
@@ -283,6 +289,7 @@ func init() {
 				sshHostCmd,
 				ipCmd,
 				logsCmd,
+				topCmd,
 				updateCheckCmd,
 				versionCmd,
 				optionsCmd,
@@ -75,7 +75,8 @@ var serviceCmd = &cobra.Command{
 		RootCmd.PersistentPreRun(cmd, args)
 	},
 	Run: func(_ *cobra.Command, args []string) {
-		if len(args) == 0 && !all || (len(args) > 0 && all) {
+		forwardFromConfig := serviceForward && serviceForwardConfig != ""
+		if !forwardFromConfig && (len(args) == 0 && !all || (len(args) > 0 && all)) {
 			exit.Message(reason.Usage, "You must specify service name(s) or --all")
 		}
 
@@ -95,6 +96,14 @@ var serviceCmd = &cobra.Command{
 			exit.Message(reason.Unimplemented, msg)
 		}
 
+		if serviceForward {
+			forwardServices(co, args)
+			return
+		}
+		if len(serviceLocalPorts) > 0 || serviceForwardConfig != "" {
+			exit.Message(reason.Usage, "--local-port and --forward-config require --forward")
+		}
+
 		var services service.URLs
 		services, err := service.GetServiceURLs(co.API, co.Config.Name, namespace, serviceURLTemplate)
 		if err != nil {
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/service"
+	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/minikube/tunnel/kic"
+)
+
+var (
+	serviceForward       bool
+	serviceLocalPorts    []string
+	serviceForwardConfig string
+)
+
+func init() {
+	serviceCmd.Flags().BoolVar(&serviceForward, "forward", false, "Forward the service(s) to stable local ports over SSH, reconnecting when the connection breaks or the endpoints change")
+	serviceCmd.Flags().StringSliceVar(&serviceLocalPorts, "local-port", nil, "With --forward, the local port of the service, as LOCAL_PORT for its first port or LOCAL_PORT:SERVICE_PORT (can be repeated)")
+	serviceCmd.Flags().StringVar(&serviceForwardConfig, "forward-config", "", "With --forward, a YAML file listing the services to forward and their local ports")
+}
+
+// forwardServices forwards the services to local ports until interrupted
+func forwardServices(co mustload.ClusterController, args []string) {
+	clientset, err := kapi.Client(co.Config.Name)
+	if err != nil {
+		exit.Error(reason.InternalKubernetesClient, "error creating clientset", err)
+	}
+	specs := forwardSpecs(clientset, args)
+
+	h, err := machine.GetHost(co.API, *co.Config, *co.CP.Node)
+	if err != nil {
+		exit.Error(reason.GuestStatus, "error getting host", err)
+	}
+	ssh := kic.SSHTarget{}
+	if ssh.User = h.Driver.GetSSHUsername(); ssh.User == "" {
+		ssh.User = "docker"
+	}
+	if ssh.Host, err = h.Driver.GetSSHHostname(); err != nil {
+		exit.Error(reason.DrvPortForward, "error getting ssh host", err)
+	}
+	if ssh.Port, err = h.Driver.GetSSHPort(); err != nil {
+		exit.Error(reason.DrvPortForward, "error getting ssh port", err)
+	}
+	ssh.Key = h.Driver.GetSSHKeyPath()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	var data [][]string
+	var forwarders []*kic.ServiceForwarder
+	for _, spec := range specs {
+		f := kic.NewServiceForwarder(spec, ssh, clientset)
+		ports, err := f.Prepare(ctx)
+		if err != nil {
+			exit.Error(reason.SvcTunnelStart, fmt.Sprintf("error forwarding %s/%s", spec.Namespace, spec.Name), err)
+		}
+		locals := []int{}
+		for local := range ports {
+			locals = append(locals, local)
+		}
+		sort.Ints(locals)
+		var targets, urls []string
+		for _, local := range locals {
+			urlTemplate, err := mutateURLs(spec.Name, []string{fmt.Sprintf("http://127.0.0.1:%d", local)})
+			if err != nil {
+				exit.Error(reason.SvcTunnelStart, "error creating urls", err)
+			}
+			targets = append(targets, strconv.Itoa(int(ports[local])))
+			urls = append(urls, urlTemplate...)
+		}
+		data = append(data, []string{spec.Namespace, spec.Name, strings.Join(targets, "\n"), strings.Join(urls, "\n")})
+		forwarders = append(forwarders, f)
+	}
+
+	if serviceURLMode {
+		for _, row := range data {
+			out.Stringf("%s\n", row[3])
+		}
+	} else {
+		service.PrintServiceList(os.Stdout, data)
+	}
+	out.Styled(style.Connectivity, "Forwarding {{.count}} service(s), press Ctrl-C to stop.", out.V{"count": len(forwarders)})
+
+	var wg sync.WaitGroup
+	for _, f := range forwarders {
+		wg.Add(1)
+		go func(f *kic.ServiceForwarder) {
+			defer wg.Done()
+			f.Run(ctx)
+		}(f)
+	}
+	wg.Wait()
+	out.Step(style.Stopping, "Stopped forwarding services.")
+}
+
+// forwardSpecs returns the services to forward, from the config file, the arguments or --all
+func forwardSpecs(clientset kubernetes.Interface, args []string) []kic.ForwardSpec {
+	if serviceForwardConfig != "" {
+		if len(args) > 0 || all || len(serviceLocalPorts) > 0 {
+			exit.Message(reason.Usage, "--forward-config cannot be used with service names, --all or --local-port")
+		}
+		specs, err := kic.LoadForwardConfig(serviceForwardConfig, namespace)
+		if err != nil {
+			exit.Error(reason.Usage, "invalid --forward-config", err)
+		}
+		return specs
+	}
+	if len(serviceLocalPorts) > 0 && len(args) != 1 {
+		exit.Message(reason.Usage, "--local-port can only be used when forwarding a single service")
+	}
+
+	names := args
+	if all {
+		svcs, err := clientset.CoreV1().Services(namespace).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			exit.Error(reason.SvcTunnelStart, "error listing services", err)
+		}
+		for _, svc := range svcs.Items {
+			names = append(names, svc.Name)
+		}
+		if len(names) == 0 {
+			exit.Message(reason.SvcNotFound, `No services were found in the '{{.namespace}}' namespace.
+You may select another namespace by using 'minikube service --all -n <namespace>'`, out.V{"namespace": namespace})
+		}
+	}
+
+	specs := []kic.ForwardSpec{}
+	for _, name := range names {
+		svc, err := clientset.CoreV1().Services(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			exit.Message(reason.SvcNotFound, `Service '{{.service}}' was not found in '{{.namespace}}' namespace.
+You may select another namespace by using 'minikube service {{.service}} -n <namespace>'. Or list out all the services using 'minikube service list'`, out.V{"service": name, "namespace": namespace})
+		}
+		spec := kic.ForwardSpec{Namespace: namespace, Name: name, LocalPorts: map[int32]int{}}
+		for _, p := range serviceLocalPorts {
+			local, remote, err := kic.ParseLocalPort(p)
+			if err != nil {
+				exit.Message(reason.Usage, "invalid --local-port: {{.error}}", out.V{"error": err})
+			}
+			if remote == 0 {
+				if len(svc.Spec.Ports) == 0 {
+					exit.Message(reason.SvcTunnelStart, "Service '{{.service}}' has no ports", out.V{"service": name})
+				}
+				remote = svc.Spec.Ports[0].Port
+			}
+			spec.LocalPorts[remote] = local
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
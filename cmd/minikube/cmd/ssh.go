@@ -17,7 +17,10 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -31,13 +34,22 @@ import (
 	"k8s.io/minikube/pkg/minikube/reason"
 )
 
-var nativeSSHClient bool
+var (
+	nativeSSHClient bool
+	sshAllNodes     bool
+	sshNodes        []string
+	sshOutput       string
+)
 
 // sshCmd represents the docker-ssh command
 var sshCmd = &cobra.Command{
 	Use:   "ssh",
 	Short: "Log into the minikube environment (for debugging)",
-	Long:  "Log into or run a command on a machine with SSH; similar to 'docker-machine ssh'.",
+	Long:  "Log into or run a command on a machine with SSH; similar to 'docker-machine ssh'. With --all-nodes or --nodes, the command is run concurrently on the nodes, with its output prefixed by the name of the node.",
+	Example: `minikube ssh
+minikube ssh -n minikube-m02 -- sudo crictl ps
+minikube ssh --all-nodes -- sudo sysctl net.ipv4.ip_forward
+minikube ssh --nodes minikube,minikube-m03 -o json -- uptime`,
 	Run: func(_ *cobra.Command, args []string) {
 		cname := ClusterFlagValue()
 		co := mustload.Running(cname)
@@ -45,6 +57,14 @@ var sshCmd = &cobra.Command{
 			exit.Message(reason.Usage, "'none' driver does not support 'minikube ssh' command")
 		}
 
+		if sshAllNodes || len(sshNodes) > 0 {
+			sshOnNodes(&co, args)
+			return
+		}
+		if strings.ToLower(sshOutput) != "text" {
+			exit.Message(reason.Usage, "--output is only supported with --all-nodes or --nodes")
+		}
+
 		var err error
 		var n *config.Node
 		if nodeName == "" {
@@ -66,7 +86,65 @@ var sshCmd = &cobra.Command{
 	},
 }
 
+// sshOnNodes runs the command concurrently on several nodes, and exits with an error if it failed on any of them
+func sshOnNodes(co *mustload.ClusterController, args []string) {
+	if len(args) == 0 {
+		exit.Message(reason.Usage, "Please specify the command to run on the nodes: minikube ssh --all-nodes -- <command>")
+	}
+	if nodeName != "" {
+		exit.Message(reason.Usage, "--node can not be used with --all-nodes or --nodes")
+	}
+	if sshAllNodes && len(sshNodes) > 0 {
+		exit.Message(reason.Usage, "--all-nodes and --nodes can not be used together")
+	}
+
+	nodes := co.Config.Nodes
+	if !sshAllNodes {
+		nodes = []config.Node{}
+		for _, name := range sshNodes {
+			n, _, err := node.Retrieve(*co.Config, name)
+			if err != nil {
+				exit.Message(reason.GuestNodeRetrieve, "Node {{.nodeName}} does not exist.", out.V{"nodeName": name})
+			}
+			nodes = append(nodes, *n)
+		}
+	}
+
+	output := strings.ToLower(sshOutput)
+	var results []machine.NodeCommandResult
+	switch output {
+	case "text":
+		results = machine.RunCommandOnNodes(co.API, *co.Config, nodes, args, os.Stdout, os.Stderr)
+	case "json":
+		results = machine.RunCommandOnNodes(co.API, *co.Config, nodes, args, nil, nil)
+		b, err := json.Marshal(results)
+		if err != nil {
+			exit.Error(reason.InternalJSONMarshal, "Failed to marshal results", err)
+		}
+		out.String(string(b))
+	default:
+		exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'text', 'json'", sshOutput))
+	}
+
+	failed := []string{}
+	for _, r := range results {
+		if r.Failed() {
+			failed = append(failed, r.Node)
+			if output == "text" {
+				out.ErrLn("%s: %s", r.Node, r.Error)
+			}
+		}
+	}
+	if len(failed) > 0 {
+		out.ErrLn("ssh: the command failed on %d of %d nodes: %s", len(failed), len(results), strings.Join(failed, ", "))
+		os.Exit(1)
+	}
+}
+
 func init() {
 	sshCmd.Flags().BoolVar(&nativeSSHClient, "native-ssh", true, "Use native Golang SSH client (default true). Set to 'false' to use the command line 'ssh' command when accessing the docker machine. Useful for the machine drivers when they will not start with 'Waiting for SSH'.")
 	sshCmd.Flags().StringVarP(&nodeName, "node", "n", "", "The node to ssh into. Defaults to the primary control plane.")
+	sshCmd.Flags().BoolVar(&sshAllNodes, "all-nodes", false, "Run the command concurrently on all the nodes of the cluster")
+	sshCmd.Flags().StringSliceVar(&sshNodes, "nodes", []string{}, "Run the command concurrently on the given nodes, separated by commas")
+	sshCmd.Flags().StringVarP(&sshOutput, "output", "o", "text", "Output format of the command run with --all-nodes or --nodes. Accepted values: [text, json]")
 }
@@ -26,6 +26,7 @@ import (
 	"os"
 	"os/exec"
 	"os/user"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"slices"
@@ -289,6 +290,8 @@ func runStart(cmd *cobra.Command, _ []string) {
 		exit.Error(reason.GuestStart, "failed to start node", err)
 	}
 
+	node.ConfigureConnections(*starter.Cfg)
+
 	if starter.Cfg.VerifyComponents[kverify.ExtraKey] {
 		if err := kverify.WaitExtra(ClusterFlagValue(), kverify.CorePodsLabels, kconst.DefaultControlPlaneTimeout); err != nil {
 			exit.Message(reason.GuestStart, "extra waiting: {{.error}}", out.V{"error": err})
@@ -348,6 +351,13 @@ func provisionWithDriver(cmd *cobra.Command, ds registry.DriverState, existing *
 	}
 	klog.Infof("cluster config:\n%+v", cc)
 
+	if err := validateKubeadmCustomizations(cc.KubernetesConfig); err != nil {
+		exit.Message(reason.Usage, "{{.err}}", out.V{"err": err})
+	}
+	if err := validateLocalKubernetes(cc.KubernetesConfig); err != nil {
+		exit.Message(reason.Usage, "{{.err}}", out.V{"err": err})
+	}
+
 	if firewall.IsBootpdBlocked(cc) {
 		if err := firewall.UnblockBootpd(); err != nil {
 			klog.Warningf("failed unblocking bootpd from firewall: %v", err)
@@ -596,7 +606,10 @@ func showKubectlInfo(kcs *kubeconfig.Settings, k8sVersion, rtime, machineName st
 	// To be shown at the end, regardless of exit path
 	defer func() {
 		register.Reg.SetStep(register.Done)
-		if kcs.KeepContext {
+		if kcs.Path() != kubeconfig.PathFromEnv() {
+			out.Step(style.Ready, `Done! The "{{.name}}" cluster is configured in {{.path}}`, out.V{"name": machineName, "path": kcs.Path()})
+			out.Styled(style.Kubectl, "To connect to this cluster, use:  --kubeconfig={{.path}}", out.V{"path": kcs.Path()})
+		} else if kcs.KeepContext {
 			out.Step(style.Kubectl, "To connect to this cluster, use:  --context={{.name}}", out.V{"name": kcs.ClusterName})
 		} else {
 			out.Step(style.Ready, `Done! kubectl is now configured to use "{{.name}}" cluster and "{{.ns}}" namespace by default`, out.V{"name": machineName, "ns": kcs.Namespace})
@@ -1333,6 +1346,34 @@ func validateFlags(cmd *cobra.Command, drvName string) { //nolint:gocyclo
 		}
 	}
 
+	if cmd.Flags().Changed(ipFamily) || cmd.Flags().Changed(serviceCIDRv6) || cmd.Flags().Changed(podCIDRv6) {
+		if err := validateIPFamily(viper.GetString(ipFamily), drvName, viper.GetString(serviceCIDRv6), viper.GetString(podCIDRv6)); err != nil {
+			exit.Message(reason.Usage, "{{.err}}", out.V{"err": err})
+		}
+	}
+
+	if cmd.Flags().Changed(haLoadBalancer) {
+		if err := validateHALoadBalancer(viper.GetString(haLoadBalancer), drvName); err != nil {
+			exit.Message(reason.Usage, "{{.err}}", out.V{"err": err})
+		}
+	}
+
+	if cmd.Flags().Changed(apiServerAuditPolicy) {
+		if policy := viper.GetString(apiServerAuditPolicy); policy != "" {
+			policy, err := validateAuditPolicy(policy)
+			if err != nil {
+				exit.Message(reason.Usage, "{{.err}}", out.V{"err": err})
+			}
+			viper.Set(apiServerAuditPolicy, policy)
+		}
+	}
+
+	if cmd.Flags().Changed(kubeconfigMode) {
+		if m := viper.GetString(kubeconfigMode); m != kubeconfig.MergeMode && m != kubeconfig.SeparateMode {
+			exit.Message(reason.Usage, "Sorry, the kubeconfig mode {{.mode}} is not valid, must be one of: merge, separate", out.V{"mode": m})
+		}
+	}
+
 	if cmd.Flags().Changed(gpus) {
 		if err := validateGPUs(viper.GetString(gpus), drvName, viper.GetString(containerRuntime)); err != nil {
 			exit.Message(reason.Usage, "{{.err}}", out.V{"err": err})
@@ -1503,6 +1544,106 @@ func validateGPUsArch() error {
 	return errors.Errorf("The GPUs flag is only supported on amd64, arm64 & ppc64le, currently using %s", runtime.GOARCH)
 }
 
+// validateAuditPolicy validates the --apiserver-audit-policy flag, and returns the preset or the absolute path of the policy file,
+// which is read again on every start of the cluster
+func validateAuditPolicy(policy string) (string, error) {
+	if _, ok := bsutil.AuditPolicyPresets[policy]; !ok {
+		abs, err := filepath.Abs(policy)
+		if err != nil {
+			return "", errors.Wrapf(err, "audit policy %s", policy)
+		}
+		policy = abs
+	}
+	if _, err := bsutil.AuditPolicy(policy); err != nil {
+		return "", errors.Wrap(err, "invalid --apiserver-audit-policy")
+	}
+	return policy, nil
+}
+
+// localDir returns the absolute path of the directory given to the flag, which is read again on every start of the cluster
+func localDir(flag string) string {
+	dir := viper.GetString(flag)
+	if dir == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		exit.Message(reason.Usage, "Invalid --{{.flag}} directory {{.dir}}: {{.err}}", out.V{"flag": flag, "dir": dir, "err": err})
+	}
+	return abs
+}
+
+// localBinariesDir returns the directory of the Kubernetes binaries built locally, given to the flag or else of the existing cluster
+func localBinariesDir(old *config.ClusterConfig) string {
+	if dir := localDir(kubernetesBinariesDir); dir != "" {
+		return dir
+	}
+	if old != nil {
+		return old.KubernetesConfig.KubernetesBinariesDir
+	}
+	return ""
+}
+
+// componentConfigs returns the component configs of the --component-config files, by kind
+func componentConfigs() map[string]string {
+	var docs []byte
+	for _, f := range viper.GetStringSlice(componentConfig) {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			exit.Message(reason.Usage, "Unable to read the component config {{.file}}: {{.err}}", out.V{"file": f, "err": err})
+		}
+		docs = append(docs, []byte("\n---\n")...)
+		docs = append(docs, data...)
+	}
+	configs, err := bsutil.ParseComponentConfigs(docs)
+	if err != nil {
+		exit.Message(reason.Usage, "Invalid --component-config: {{.err}}", out.V{"err": err})
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+	return configs
+}
+
+// validateLocalKubernetes validates the directories of the Kubernetes binaries and images built locally
+func validateLocalKubernetes(k8s config.KubernetesConfig) error {
+	if k8s.KubernetesBinariesDir == "" && k8s.KubernetesImagesDir == "" {
+		return nil
+	}
+	if k8s.KubernetesVersion == constants.NoKubernetesVersion {
+		return errors.New("--kubernetes-binaries-dir and --kubernetes-images-dir require Kubernetes")
+	}
+	if k8s.KubernetesImagesDir != "" {
+		if _, _, err := machine.LocalKubernetesImages(k8s.KubernetesImagesDir, nil); err != nil {
+			return errors.Wrap(err, "invalid --kubernetes-images-dir")
+		}
+	}
+	return nil
+}
+
+// validateKubeadmCustomizations validates the kubeadm patches and the component configs for the Kubernetes version of the cluster
+func validateKubeadmCustomizations(k8s config.KubernetesConfig) error {
+	if k8s.KubeadmPatches == "" && len(k8s.ComponentConfigs) == 0 {
+		return nil
+	}
+	if k8s.KubernetesVersion == constants.NoKubernetesVersion {
+		return errors.New("--kubeadm-patches and --component-config require Kubernetes")
+	}
+	version, err := util.ParseKubernetesVersion(k8s.KubernetesVersion)
+	if err != nil {
+		return errors.Wrap(err, "parsing Kubernetes version")
+	}
+	if k8s.KubeadmPatches != "" {
+		if err := bsutil.ValidateKubeadmPatches(k8s.KubeadmPatches, version); err != nil {
+			return errors.Wrap(err, "invalid --kubeadm-patches")
+		}
+	}
+	if err := bsutil.ValidateComponentConfigs(k8s.ComponentConfigs, version); err != nil {
+		return errors.Wrap(err, "invalid --component-config")
+	}
+	return nil
+}
+
 func validateAutoPauseInterval(interval time.Duration) error {
 	if interval != interval.Abs() || interval.String() == "0s" {
 		return errors.New("auto-pause-interval must be greater than 0s")
@@ -1804,14 +1945,19 @@ func validateKubernetesVersion(old *config.ClusterConfig) {
 		klog.Infof("No Kubernetes version set for minikube, setting Kubernetes version to %s", constants.NoKubernetesVersion)
 		return
 	}
-	if nvs.Major > newestVersion.Major {
+	// the binaries built locally are usually newer than the releases
+	local := localBinariesDir(old) != ""
+	if local && paramVersion != "" && paramVersion != strings.TrimPrefix(kubernetesVer, version.VersionPrefix) {
+		out.WarningT("Using Kubernetes {{.version}} of the binaries in {{.dir}}, instead of --kubernetes-version", out.V{"version": kubernetesVer, "dir": localBinariesDir(old)})
+	}
+	if nvs.Major > newestVersion.Major && !local {
 		out.WarningT("Specified Major version of Kubernetes {{.specifiedMajor}} is newer than the newest supported Major version: {{.newestMajor}}", out.V{"specifiedMajor": nvs.Major, "newestMajor": newestVersion.Major})
 		if !viper.GetBool(force) {
 			out.WarningT("You can force an unsupported Kubernetes version via the --force flag")
 		}
 		exitIfNotForced(reason.KubernetesTooNew, "Kubernetes {{.version}} is not supported by this release of minikube", out.V{"version": nvs})
 	}
-	if nvs.GT(newestVersion) {
+	if nvs.GT(newestVersion) && !local {
 		out.WarningT("Specified Kubernetes version {{.specified}} is newer than the newest supported version: {{.newest}}. Use `minikube config defaults kubernetes-version` for details.", out.V{"specified": nvs, "newest": constants.NewestKubernetesVersion})
 		if slices.Contains(constants.ValidKubernetesVersions, kubernetesVer) {
 			out.Styled(style.Check, "Kubernetes version {{.specified}} found in version list", out.V{"specified": nvs})
@@ -1902,6 +2048,19 @@ $ minikube config unset kubernetes-version`)
 		}
 	}
 
+	// the version of the binaries built locally is the version of the cluster
+	if dir := localBinariesDir(old); dir != "" && !viper.GetBool(noKubernetes) {
+		v, err := bsutil.LocalBinariesVersion(dir)
+		if err != nil {
+			exit.Message(reason.Usage, "Unable to read the Kubernetes version of the binaries in {{.dir}}: {{.err}}", out.V{"dir": dir, "err": err})
+		}
+		nvs, err := semver.Make(strings.TrimPrefix(v, version.VersionPrefix))
+		if err != nil {
+			exit.Message(reason.Usage, `Unable to parse the version "{{.kubernetes_version}}" of the binaries in {{.dir}}: {{.error}}`, out.V{"kubernetes_version": v, "dir": dir, "error": err})
+		}
+		return version.VersionPrefix + nvs.String(), nil
+	}
+
 	paramVersion := viper.GetString(kubernetesVersion)
 
 	// try to load the old version first if the user didn't specify anything
@@ -1997,6 +2156,49 @@ func validateStaticIP(staticIP, drvName, subnet string) error {
 	return nil
 }
 
+// validateIPFamily validates the --ip-family flag and the IPv6 subnets used with it
+func validateIPFamily(family, drvName, serviceCIDR, podCIDR string) error {
+	switch family {
+	case constants.IPFamilyIPv4:
+		return nil
+	case constants.IPFamilyIPv6, constants.IPFamilyDual:
+	default:
+		return errors.Errorf("Sorry, the IP family %q is not valid, must be one of: ipv4, ipv6, dual", family)
+	}
+	if drvName != driver.Docker {
+		return errors.Errorf("Sorry, the IP family %q is only supported with the docker driver", family)
+	}
+	if viper.GetBool(ha) {
+		return errors.Errorf("Sorry, the IP family %q is not supported with multi-control plane clusters", family)
+	}
+	for _, cidr := range []string{serviceCIDR, podCIDR} {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return errors.Errorf("Sorry, unable to parse IPv6 subnet: %v", err)
+		}
+		if ip.To4() != nil {
+			return errors.Errorf("Sorry, the subnet %s is not an IPv6 subnet", cidr)
+		}
+	}
+	return nil
+}
+
+// validateHALoadBalancer validates the --ha-lb flag
+func validateHALoadBalancer(lb, drvName string) error {
+	switch lb {
+	case constants.HALoadBalancerKubeVip:
+		return nil
+	case constants.HALoadBalancerHAProxy:
+	default:
+		return errors.Errorf("Sorry, the load balancer %q is not valid, must be one of: kube-vip, haproxy", lb)
+	}
+	// the load balancer runs in a container on the network of the nodes
+	if !driver.IsKIC(drvName) {
+		return errors.Errorf("Sorry, the load balancer %q is only supported with the docker and podman drivers", lb)
+	}
+	return nil
+}
+
 func validateBareMetal(drvName string) {
 	if !driver.BareMetal(drvName) {
 		return
@@ -41,6 +41,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/download"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/kubeconfig"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/proxy"
 	"k8s.io/minikube/pkg/minikube/reason"
@@ -82,6 +83,9 @@ const (
 	apiServerPort           = "apiserver-port"
 	dnsDomain               = "dns-domain"
 	serviceCIDR             = "service-cluster-ip-range"
+	serviceCIDRv6           = "service-cluster-ip-range-v6"
+	podCIDRv6               = "pod-network-cidr-v6"
+	ipFamily                = "ip-family"
 	imageRepository         = "image-repository"
 	imageMirrorCountry      = "image-mirror-country"
 	mountString             = "mount-string"
@@ -99,6 +103,12 @@ const (
 	vpnkitSock              = "hyperkit-vpnkit-sock"
 	vsockPorts              = "hyperkit-vsock-ports"
 	embedCerts              = "embed-certs"
+	kubeconfigMode          = "kubeconfig-mode"
+	apiServerAuditPolicy    = "apiserver-audit-policy"
+	kubeadmPatches          = "kubeadm-patches"
+	componentConfig         = "component-config"
+	kubernetesBinariesDir   = "kubernetes-binaries-dir"
+	kubernetesImagesDir     = "kubernetes-images-dir"
 	noVTXCheck              = "no-vtx-check"
 	downloadOnly            = "download-only"
 	dnsProxy                = "dns-proxy"
@@ -117,6 +127,7 @@ const (
 	hostOnlyNicType         = "host-only-nic-type"
 	natNicType              = "nat-nic-type"
 	ha                      = "ha"
+	haLoadBalancer          = "ha-lb"
 	nodes                   = "nodes"
 	preload                 = "preload"
 	deleteOnFailure         = "delete-on-failure"
@@ -172,6 +183,7 @@ func initMinikubeFlags() {
 	startCmd.Flags().String(kicBaseImage, kic.BaseImage, "The base image to use for docker/podman drivers. Intended for local development.")
 	startCmd.Flags().Bool(keepContext, false, "This will keep the existing kubectl context and will create a minikube context.")
 	startCmd.Flags().Bool(embedCerts, false, "if true, will embed the certs in kubeconfig.")
+	startCmd.Flags().String(kubeconfigMode, kubeconfig.MergeMode, "How to write the context of the cluster: 'merge' it into $KUBECONFIG (or ~/.kube/config), or write it into a 'separate' kubeconfig of the profile, leaving the user's kubeconfig untouched.")
 	startCmd.Flags().StringP(containerRuntime, "c", constants.DefaultContainerRuntime, fmt.Sprintf("The container runtime to be used. Valid options: %s (default: auto)", strings.Join(cruntime.ValidRuntimes(), ", ")))
 	startCmd.Flags().Bool(createMount, false, "Kept for backward compatibility, value is ignored.")
 	startCmd.Flags().String(mountString, "", "Directory to mount in the guest using format '/host-path:/guest-path'.")
@@ -194,6 +206,7 @@ func initMinikubeFlags() {
 	startCmd.Flags().Bool(autoUpdate, true, "If set, automatically updates drivers to the latest version. Defaults to true.")
 	startCmd.Flags().Bool(installAddons, true, "If set, install addons. Defaults to true.")
 	startCmd.Flags().Bool(ha, false, "Create Highly Available Multi-Control Plane Cluster with a minimum of three control-plane nodes that will also be marked for work.")
+	startCmd.Flags().String(haLoadBalancer, constants.HALoadBalancerKubeVip, "The load balancer fronting the API servers of an HA (multi-control plane) cluster: kube-vip (a virtual IP announced by the control-plane nodes) or haproxy (a dedicated container, only for the docker and podman drivers).")
 	startCmd.Flags().IntP(nodes, "n", 1, "The total number of nodes to spin up. Defaults to 1.")
 	startCmd.Flags().Bool(preload, true, "If set, download tarball of preloaded images if available to improve start time. Defaults to true.")
 	startCmd.Flags().Bool(noKubernetes, false, "If set, minikube VM/container will start without starting or configuring Kubernetes. (only works on new clusters)")
@@ -228,6 +241,11 @@ func initKubernetesFlags() {
 	startCmd.Flags().String(apiServerName, constants.APIServerName, "The authoritative apiserver hostname for apiserver certificates and connectivity. This can be used if you want to make the apiserver available from outside the machine")
 	startCmd.Flags().StringSliceVar(&apiServerNames, "apiserver-names", nil, "A set of apiserver names which are used in the generated certificate for kubernetes.  This can be used if you want to make the apiserver available from outside the machine")
 	startCmd.Flags().IPSliceVar(&apiServerIPs, "apiserver-ips", nil, "A set of apiserver IP Addresses which are used in the generated certificate for kubernetes.  This can be used if you want to make the apiserver available from outside the machine")
+	startCmd.Flags().String(apiServerAuditPolicy, "", fmt.Sprintf("Write the audit log of the apiserver with this policy, the path of an audit policy file or one of the presets: %s. Read the log with 'minikube logs --apiserver-audit'.", strings.Join(bsutil.AuditPolicyPresetNames(), ", ")))
+	startCmd.Flags().String(kubeadmPatches, "", "A directory of kubeadm patches of the static pods and of the kubelet config, named target[suffix][+patchtype].yaml, applied by kubeadm on every node")
+	startCmd.Flags().StringSlice(componentConfig, nil, "YAML files of KubeletConfiguration, KubeProxyConfiguration and KubeSchedulerConfiguration, deep-merged into the configs generated by minikube")
+	startCmd.Flags().String(kubernetesBinariesDir, "", "A directory of kubeadm, kubelet and kubectl built locally, copied to the nodes instead of the released binaries. The Kubernetes version is read from the binaries.")
+	startCmd.Flags().String(kubernetesImagesDir, "", "A directory of image tarballs of the control plane built locally (e.g. kube-apiserver.tar), loaded into the container runtime of the nodes")
 }
 
 // initDriverFlags inits the commandline flags for vm drivers
@@ -294,6 +312,9 @@ func initNetworkingFlags() {
 	startCmd.Flags().String(imageRepository, "", "Alternative image repository to pull docker images from. This can be used when you have limited access to gcr.io. Set it to \"auto\" to let minikube decide one for you. For Chinese mainland users, you may use local gcr.io mirrors such as registry.cn-hangzhou.aliyuncs.com/google_containers")
 	startCmd.Flags().String(imageMirrorCountry, "", "Country code of the image mirror to be used. Leave empty to use the global one. For Chinese mainland users, set it to cn.")
 	startCmd.Flags().String(serviceCIDR, constants.DefaultServiceCIDR, "The CIDR to be used for service cluster IPs.")
+	startCmd.Flags().String(ipFamily, constants.IPFamilyIPv4, "The IP family of the cluster: ipv4, ipv6 or dual (dual-stack). ipv6 and dual are only supported with the docker driver")
+	startCmd.Flags().String(serviceCIDRv6, constants.DefaultServiceCIDRv6, "The IPv6 CIDR to be used for service cluster IPs, when --ip-family is ipv6 or dual.")
+	startCmd.Flags().String(podCIDRv6, cni.DefaultPodCIDRv6, "The IPv6 CIDR to be used for pod IPs, when --ip-family is ipv6 or dual.")
 	startCmd.Flags().StringArrayVar(&config.DockerEnv, "docker-env", nil, "Environment variables to pass to the Docker daemon. (format: key=value)")
 	startCmd.Flags().StringArrayVar(&config.DockerOpt, "docker-opt", nil, "Specify arbitrary flags to pass to the Docker daemon. (format: key=value)")
 
@@ -572,6 +593,7 @@ func generateNewConfigFromFlags(cmd *cobra.Command, k8sVersion string, rtime str
 		Name:                    ClusterFlagValue(),
 		KeepContext:             viper.GetBool(keepContext),
 		EmbedCerts:              viper.GetBool(embedCerts),
+		KubeconfigMode:          viper.GetString(kubeconfigMode),
 		MinikubeISO:             viper.GetString(isoURL),
 		KicBaseImage:            viper.GetString(kicBaseImage),
 		Network:                 getNetwork(drvName),
@@ -636,14 +658,23 @@ func generateNewConfigFromFlags(cmd *cobra.Command, k8sVersion string, rtime str
 			ClusterName:            ClusterFlagValue(),
 			Namespace:              viper.GetString(startNamespace),
 			APIServerName:          viper.GetString(apiServerName),
+			HALoadBalancer:         viper.GetString(haLoadBalancer),
 			APIServerNames:         apiServerNames,
 			APIServerIPs:           apiServerIPs,
+			APIServerAuditPolicy:   viper.GetString(apiServerAuditPolicy),
+			KubeadmPatches:         localDir(kubeadmPatches),
+			ComponentConfigs:       componentConfigs(),
+			KubernetesBinariesDir:  localDir(kubernetesBinariesDir),
+			KubernetesImagesDir:    localDir(kubernetesImagesDir),
 			DNSDomain:              viper.GetString(dnsDomain),
 			FeatureGates:           viper.GetString(featureGates),
 			ContainerRuntime:       rtime,
 			CRISocket:              viper.GetString(criSocket),
 			NetworkPlugin:          chosenNetworkPlugin,
 			ServiceCIDR:            viper.GetString(serviceCIDR),
+			ServiceCIDRv6:          viper.GetString(serviceCIDRv6),
+			PodCIDRv6:              viper.GetString(podCIDRv6),
+			IPFamily:               viper.GetString(ipFamily),
 			ImageRepository:        getRepository(cmd, k8sVersion),
 			ExtraOptions:           getExtraOptions(),
 			ShouldLoadCachedImages: viper.GetBool(cacheImages),
@@ -796,25 +827,58 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 		out.WarningT("Changing the HA (multi-control plane) mode of an existing minikube cluster is not currently supported. Please first delete the cluster and use 'minikube start --ha' to create new one.")
 	}
 
+	if cmd.Flags().Changed(haLoadBalancer) && viper.GetString(haLoadBalancer) != cc.KubernetesConfig.HALoadBalancer {
+		out.WarningT("Changing the load balancer of an existing minikube HA (multi-control plane) cluster is not currently supported. Please first delete the cluster.")
+	}
+
 	if cmd.Flags().Changed(apiServerPort) && config.IsHA(*existing) {
 		out.WarningT("Changing the API server port of an existing minikube HA (multi-control plane) cluster is not currently supported. Please first delete the cluster.")
 	} else {
 		updateIntFromFlag(cmd, &cc.APIServerPort, apiServerPort)
 	}
 
-	if cmd.Flags().Changed(memory) && getMemorySize(cmd, cc.Driver) != cc.Memory {
-		out.WarningT("You cannot change the memory size for an existing minikube cluster. Please first delete the cluster.")
+	if cmd.Flags().Changed(memory) {
+		if mem := getMemorySize(cmd, cc.Driver); mem != cc.Memory {
+			switch {
+			case !driver.SupportsResize(cc.Driver):
+				out.WarningT("You cannot change the memory size for an existing minikube cluster. Please first delete the cluster.")
+			case mem == 0:
+				out.WarningT("You cannot remove the memory limit of an existing minikube cluster. Please first delete the cluster.")
+			default:
+				cc.Memory = mem
+			}
+		}
 	}
 
-	if cmd.Flags().Changed(cpus) && viper.GetInt(cpus) != cc.CPUs {
-		out.WarningT("You cannot change the CPUs for an existing minikube cluster. Please first delete the cluster.")
+	if cmd.Flags().Changed(cpus) {
+		if cpuCount := getCPUCount(cc.Driver); cpuCount != cc.CPUs {
+			switch {
+			case !driver.SupportsResize(cc.Driver):
+				out.WarningT("You cannot change the CPUs for an existing minikube cluster. Please first delete the cluster.")
+			case cpuCount == 0:
+				out.WarningT("You cannot remove the CPU limit of an existing minikube cluster. Please first delete the cluster.")
+			default:
+				cc.CPUs = cpuCount
+			}
+		}
 	}
 
 	// validate the memory size in case user changed their system memory limits (example change docker desktop or upgraded memory.)
 	validateRequestedMemorySize(cc.Memory, cc.Driver)
 
-	if cmd.Flags().Changed(humanReadableDiskSize) && getDiskSize() != existing.DiskSize {
-		out.WarningT("You cannot change the disk size for an existing minikube cluster. Please first delete the cluster.")
+	if cmd.Flags().Changed(humanReadableDiskSize) {
+		if diskSize := getDiskSize(); diskSize != existing.DiskSize {
+			switch {
+			case !driver.SupportsResize(cc.Driver):
+				out.WarningT("You cannot change the disk size for an existing minikube cluster. Please first delete the cluster.")
+			case driver.IsKIC(cc.Driver):
+				out.WarningT("You cannot change the disk size for an existing minikube cluster using the {{.driver}} driver, as its volume is not limited in size.", out.V{"driver": cc.Driver})
+			case diskSize < existing.DiskSize:
+				out.WarningT("You cannot shrink the disk of an existing minikube cluster. Please first delete the cluster.")
+			default:
+				cc.DiskSize = diskSize
+			}
+		}
 	}
 
 	checkExtraDiskOptions(cmd, cc.Driver)
@@ -826,8 +890,19 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 		out.WarningT("You cannot change the static IP of an existing minikube cluster. Please first delete the cluster.")
 	}
 
+	existingIPFamily := existing.KubernetesConfig.IPFamily
+	if existingIPFamily == "" {
+		existingIPFamily = constants.IPFamilyIPv4
+	}
+	if (cmd.Flags().Changed(ipFamily) && viper.GetString(ipFamily) != existingIPFamily) ||
+		(cmd.Flags().Changed(serviceCIDRv6) && viper.GetString(serviceCIDRv6) != existing.KubernetesConfig.ServiceCIDRv6) ||
+		(cmd.Flags().Changed(podCIDRv6) && viper.GetString(podCIDRv6) != existing.KubernetesConfig.PodCIDRv6) {
+		out.WarningT("You cannot change the IP family or the IPv6 subnets of an existing minikube cluster. Please first delete the cluster.")
+	}
+
 	updateBoolFromFlag(cmd, &cc.KeepContext, keepContext)
 	updateBoolFromFlag(cmd, &cc.EmbedCerts, embedCerts)
+	updateStringFromFlag(cmd, &cc.KubeconfigMode, kubeconfigMode)
 	updateStringFromFlag(cmd, &cc.MinikubeISO, isoURL)
 	updateStringFromFlag(cmd, &cc.KicBaseImage, kicBaseImage)
 	updateStringFromFlag(cmd, &cc.Network, network)
@@ -859,6 +934,19 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.Namespace, startNamespace)
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.APIServerName, apiServerName)
 	updateStringSliceFromFlag(cmd, &cc.KubernetesConfig.APIServerNames, "apiserver-names")
+	updateStringFromFlag(cmd, &cc.KubernetesConfig.APIServerAuditPolicy, apiServerAuditPolicy)
+	if cmd.Flags().Changed(kubeadmPatches) {
+		cc.KubernetesConfig.KubeadmPatches = localDir(kubeadmPatches)
+	}
+	if cmd.Flags().Changed(componentConfig) {
+		cc.KubernetesConfig.ComponentConfigs = componentConfigs()
+	}
+	if cmd.Flags().Changed(kubernetesBinariesDir) {
+		cc.KubernetesConfig.KubernetesBinariesDir = localDir(kubernetesBinariesDir)
+	}
+	if cmd.Flags().Changed(kubernetesImagesDir) {
+		cc.KubernetesConfig.KubernetesImagesDir = localDir(kubernetesImagesDir)
+	}
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.DNSDomain, dnsDomain)
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.FeatureGates, featureGates)
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.ContainerRuntime, containerRuntime)
@@ -883,8 +971,9 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 	updateStringFromFlag(cmd, &cc.SocketVMnetPath, socketVMnetPath)
 	updateDurationFromFlag(cmd, &cc.AutoPauseInterval, autoPauseInterval)
 
-	if cmd.Flags().Changed(kubernetesVersion) {
-		kubeVer, err := getKubernetesVersion(existing)
+	// the version of the binaries built locally is read again, as they may have been rebuilt
+	if cmd.Flags().Changed(kubernetesVersion) || cc.KubernetesConfig.KubernetesBinariesDir != "" {
+		kubeVer, err := getKubernetesVersion(&cc)
 		if err != nil {
 			klog.Warningf("failed getting Kubernetes version: %v", err)
 		}
@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -721,6 +723,94 @@ func TestValidateSubnet(t *testing.T) {
 	}
 }
 
+func TestValidateIPFamily(t *testing.T) {
+	tests := []struct {
+		family      string
+		drvName     string
+		serviceCIDR string
+		podCIDR     string
+		errorMsg    string
+	}{
+		{
+			family:      "ipv4",
+			drvName:     "kvm2",
+			serviceCIDR: "fd00:10:96::/112",
+			podCIDR:     "fd00:10:244::/56",
+		},
+		{
+			family:      "dual",
+			drvName:     "docker",
+			serviceCIDR: "fd00:10:96::/112",
+			podCIDR:     "fd00:10:244::/56",
+		},
+		{
+			family:      "ipv6",
+			drvName:     "docker",
+			serviceCIDR: "fd00:10:96::/112",
+			podCIDR:     "fd00:10:244::/56",
+		},
+		{
+			family:      "ipv5",
+			drvName:     "docker",
+			serviceCIDR: "fd00:10:96::/112",
+			podCIDR:     "fd00:10:244::/56",
+			errorMsg:    `Sorry, the IP family "ipv5" is not valid, must be one of: ipv4, ipv6, dual`,
+		},
+		{
+			family:      "dual",
+			drvName:     "kvm2",
+			serviceCIDR: "fd00:10:96::/112",
+			podCIDR:     "fd00:10:244::/56",
+			errorMsg:    `Sorry, the IP family "dual" is only supported with the docker driver`,
+		},
+		{
+			family:      "dual",
+			drvName:     "docker",
+			serviceCIDR: "10.96.0.0/12",
+			podCIDR:     "fd00:10:244::/56",
+			errorMsg:    "Sorry, the subnet 10.96.0.0/12 is not an IPv6 subnet",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%s/%s", tt.family, tt.drvName, tt.serviceCIDR), func(t *testing.T) {
+			gotError := ""
+			got := validateIPFamily(tt.family, tt.drvName, tt.serviceCIDR, tt.podCIDR)
+			if got != nil {
+				gotError = got.Error()
+			}
+			if gotError != tt.errorMsg {
+				t.Errorf("validateIPFamily(family=%v, drvName=%v, serviceCIDR=%v): got %v, expected %v", tt.family, tt.drvName, tt.serviceCIDR, got, tt.errorMsg)
+			}
+		})
+	}
+}
+
+func TestValidateHALoadBalancer(t *testing.T) {
+	tests := []struct {
+		lb       string
+		drvName  string
+		errorMsg string
+	}{
+		{lb: "kube-vip", drvName: "kvm2"},
+		{lb: "haproxy", drvName: "docker"},
+		{lb: "haproxy", drvName: "podman"},
+		{lb: "envoy", drvName: "docker", errorMsg: `Sorry, the load balancer "envoy" is not valid, must be one of: kube-vip, haproxy`},
+		{lb: "haproxy", drvName: "kvm2", errorMsg: `Sorry, the load balancer "haproxy" is only supported with the docker and podman drivers`},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%s", tt.lb, tt.drvName), func(t *testing.T) {
+			gotError := ""
+			got := validateHALoadBalancer(tt.lb, tt.drvName)
+			if got != nil {
+				gotError = got.Error()
+			}
+			if gotError != tt.errorMsg {
+				t.Errorf("validateHALoadBalancer(lb=%v, drvName=%v): got %v, expected %v", tt.lb, tt.drvName, got, tt.errorMsg)
+			}
+		})
+	}
+}
+
 func TestValidateStaticIP(t *testing.T) {
 	tests := []struct {
 		staticIP string
@@ -857,3 +947,71 @@ func TestValidateAutoPause(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateAuditPolicy(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("policy.yaml", []byte("apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n- level: Metadata\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	abs, err := filepath.Abs("policy.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		policy      string
+		want        string
+		shouldError bool
+	}{
+		{"request", "request", false},
+		{"policy.yaml", abs, false},
+		{"missing.yaml", "", true},
+	}
+	for _, tc := range tests {
+		got, err := validateAuditPolicy(tc.policy)
+		if (err != nil) != tc.shouldError {
+			t.Errorf("validateAuditPolicy(%q) error = %v, expected error: %v", tc.policy, err, tc.shouldError)
+		}
+		if got != tc.want {
+			t.Errorf("validateAuditPolicy(%q) = %q, want %q", tc.policy, got, tc.want)
+		}
+	}
+}
+
+func TestValidateKubeadmCustomizations(t *testing.T) {
+	scheduler := map[string]string{"KubeSchedulerConfiguration": "apiVersion: kubescheduler.config.k8s.io/v1beta3\nkind: KubeSchedulerConfiguration\n"}
+	tests := []struct {
+		k8s         cfg.KubernetesConfig
+		shouldError bool
+	}{
+		{cfg.KubernetesConfig{KubernetesVersion: "v1.28.0"}, false},
+		{cfg.KubernetesConfig{KubernetesVersion: "v1.28.0", ComponentConfigs: scheduler}, false},
+		{cfg.KubernetesConfig{KubernetesVersion: "v1.30.0", ComponentConfigs: scheduler}, true},
+		{cfg.KubernetesConfig{KubernetesVersion: constants.NoKubernetesVersion, ComponentConfigs: scheduler}, true},
+		{cfg.KubernetesConfig{KubernetesVersion: "v1.30.0", KubeadmPatches: filepath.Join(t.TempDir(), "missing")}, true},
+	}
+	for _, tc := range tests {
+		err := validateKubeadmCustomizations(tc.k8s)
+		if (err != nil) != tc.shouldError {
+			t.Errorf("validateKubeadmCustomizations(%+v) = %v, expected error: %v", tc.k8s, err, tc.shouldError)
+		}
+	}
+}
+
+func TestValidateLocalKubernetes(t *testing.T) {
+	tests := []struct {
+		k8s         cfg.KubernetesConfig
+		shouldError bool
+	}{
+		{cfg.KubernetesConfig{KubernetesVersion: "v1.34.0"}, false},
+		{cfg.KubernetesConfig{KubernetesVersion: "v1.35.0-alpha.1", KubernetesBinariesDir: t.TempDir()}, false},
+		{cfg.KubernetesConfig{KubernetesVersion: constants.NoKubernetesVersion, KubernetesBinariesDir: t.TempDir()}, true},
+		{cfg.KubernetesConfig{KubernetesVersion: "v1.34.0", KubernetesImagesDir: t.TempDir()}, true},
+	}
+	for _, tc := range tests {
+		err := validateLocalKubernetes(tc.k8s)
+		if (err != nil) != tc.shouldError {
+			t.Errorf("validateLocalKubernetes(%+v) = %v, expected error: %v", tc.k8s, err, tc.shouldError)
+		}
+	}
+}
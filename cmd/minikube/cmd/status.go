@@ -30,6 +30,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/exit"
@@ -73,6 +74,11 @@ type: Worker
 host: {{.Host}}
 kubelet: {{.Kubelet}}
 
+`
+	loadBalancerStatusFormat = `{{.Name}}
+type: Load Balancer
+host: {{.Host}}
+
 `
 )
 
@@ -134,6 +140,11 @@ func writeStatusesAtInterval(duration time.Duration, api libmachine.API, cc *con
 					exit.Error(reason.InternalStatusText, "status text failure", err)
 				}
 			}
+			if nodeName == "" && statusFormat == defaultStatusFormat && config.IsHAProxy(*cc) {
+				if err := loadBalancerStatusText(*cc, os.Stdout); err != nil {
+					exit.Error(reason.InternalStatusText, "status text failure", err)
+				}
+			}
 		case "json":
 			// Layout is currently only supported for JSON mode
 			if layout == "cluster" {
@@ -204,6 +215,15 @@ func statusText(st *cluster.Status, w io.Writer) error {
 	return nil
 }
 
+// loadBalancerStatusText writes the status of the haproxy load balancer of an HA cluster created with --ha-lb=haproxy
+func loadBalancerStatusText(cc config.ClusterConfig, w io.Writer) error {
+	tmpl, err := template.New("load-balancer-status").Parse(loadBalancerStatusFormat)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, struct{ Name, Host string }{Name: oci.LoadBalancerName(cc.Name), Host: cluster.LoadBalancerStatus(cc)})
+}
+
 func statusJSON(st []*cluster.Status, w io.Writer) error {
 	var js []byte
 	var err error
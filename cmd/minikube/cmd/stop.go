@@ -33,6 +33,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/node"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/minikube/reason"
@@ -133,6 +134,9 @@ func stopProfile(profile string) int {
 	if err := killMountProcess(); err != nil {
 		out.WarningT("Unable to kill mount process: {{.error}}", out.V{"error": err})
 	}
+	stopManagedMounts(cc)
+
+	node.UnpublishPorts(*cc)
 
 	// stop nodes in reverse order, so last one being primary control-plane node, that will start first next time
 	for i := len(cc.Nodes) - 1; i >= 0; i-- {
@@ -145,8 +149,10 @@ func stopProfile(profile string) int {
 		}
 	}
 
+	node.StopLoadBalancer(*cc)
+
 	if !keepActive {
-		if err := kubeconfig.DeleteContext(profile, kubeconfig.PathFromEnv()); err != nil {
+		if err := kubeconfig.DeleteContext(profile, config.KubeconfigPath(cc)); err != nil {
 			exit.Error(reason.HostKubeconfigDeleteCtx, "delete ctx", err)
 		}
 	}
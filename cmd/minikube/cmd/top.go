@@ -0,0 +1,277 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	topAllProfiles bool
+	topWatch       time.Duration
+	topOutput      string
+)
+
+// nodeTop is the usage of the resources of a node, with its Kubernetes metrics when metrics-server is enabled
+type nodeTop struct {
+	Profile string
+	*machine.NodeUsage
+	KubernetesCPU    string `json:",omitempty"`
+	KubernetesMemory string `json:",omitempty"`
+	Pressure         []string
+}
+
+// nodeMetrics is the usage of a node reported by metrics-server
+type nodeMetrics struct {
+	CPU    resource.Quantity `json:"cpu"`
+	Memory resource.Quantity `json:"memory"`
+}
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Display the usage of the resources of the nodes",
+	Long: `Displays the usage of the CPU, memory, disk and PIDs of the nodes of the cluster.
+The CPU and memory are measured from the host for the docker, podman, kvm2 and qemu2 drivers, including the overhead of the machine,
+and in the node otherwise. The usage reported by Kubernetes is displayed too when the metrics-server addon is enabled.
+The nodes near the thresholds at which the kubelet reports memory, disk or PID pressure are flagged.`,
+	Example: "minikube top --all-profiles --watch",
+	Run: func(cmd *cobra.Command, _ []string) {
+		if topOutput != "table" && topOutput != "json" {
+			exit.Message(reason.Usage, "Invalid output format '{{.output}}', use 'table' or 'json'", out.V{"output": topOutput})
+		}
+
+		var ccs []*config.ClusterConfig
+		if topAllProfiles {
+			profiles, err := config.ListValidProfiles()
+			if err != nil {
+				exit.Error(reason.InternalListConfig, "Unable to list profiles", err)
+			}
+			for _, p := range profiles {
+				ccs = append(ccs, p.Config)
+			}
+		} else {
+			ccs = append(ccs, mustload.Running(ClusterFlagValue()).Config)
+		}
+
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.Error(reason.NewAPIClient, "Unable to get machine client", err)
+		}
+		defer api.Close()
+
+		duration := topWatch
+		if !cmd.Flags().Changed("watch") || topWatch < 0 {
+			duration = 0
+		}
+		for {
+			tops := readTops(api, ccs)
+			if topOutput == "json" {
+				printTopJSON(tops)
+			} else {
+				printTopTable(tops)
+			}
+			if duration == 0 {
+				return
+			}
+			time.Sleep(duration)
+		}
+	},
+}
+
+// readTops reads the usage of the running nodes of the clusters, in parallel as it is measured over time
+func readTops(api libmachine.API, ccs []*config.ClusterConfig) []nodeTop {
+	var tops []*nodeTop
+	var wg sync.WaitGroup
+	for _, cc := range ccs {
+		metrics := readNodeMetrics(*cc)
+		for _, n := range cc.Nodes {
+			name := config.MachineName(*cc, n)
+			if st, err := machine.Status(api, name); err != nil || st != state.Running.String() {
+				klog.Infof("skipping node %s, which is not running (%s): %v", name, st, err)
+				continue
+			}
+			t := &nodeTop{Profile: cc.Name}
+			if m, ok := metrics[name]; ok {
+				t.KubernetesCPU = m.CPU.String()
+				t.KubernetesMemory = units.BytesSize(float64(m.Memory.Value()))
+			}
+			tops = append(tops, t)
+
+			wg.Add(1)
+			go func(cc config.ClusterConfig, n config.Node) {
+				defer wg.Done()
+				u, err := machine.ReadNodeUsage(api, cc, n)
+				if err != nil {
+					out.WarningT("Unable to read the usage of node {{.name}}: {{.error}}", out.V{"name": name, "error": err})
+					return
+				}
+				t.NodeUsage = u
+				t.Pressure = u.Pressure()
+			}(*cc, n)
+		}
+	}
+	wg.Wait()
+
+	var read []nodeTop
+	for _, t := range tops {
+		if t.NodeUsage != nil {
+			read = append(read, *t)
+		}
+	}
+	return read
+}
+
+// readNodeMetrics returns the usage of the nodes of the cluster reported by metrics-server, by node, if it is enabled
+func readNodeMetrics(cc config.ClusterConfig) map[string]nodeMetrics {
+	if !assets.Addons["metrics-server"].IsEnabled(&cc) {
+		return nil
+	}
+	client, err := kapi.Client(cc.Name)
+	if err != nil {
+		klog.Warningf("unable to get client for %s: %v", cc.Name, err)
+		return nil
+	}
+	body, err := client.CoreV1().RESTClient().Get().AbsPath("/apis/metrics.k8s.io/v1beta1/nodes").DoRaw(context.Background())
+	if err != nil {
+		klog.Warningf("unable to get the metrics of the nodes of %s: %v", cc.Name, err)
+		return nil
+	}
+	metrics, err := parseNodeMetrics(body)
+	if err != nil {
+		klog.Warningf("unable to parse the metrics of the nodes of %s: %v", cc.Name, err)
+		return nil
+	}
+	return metrics
+}
+
+// parseNodeMetrics parses a NodeMetricsList of the metrics.k8s.io API
+func parseNodeMetrics(body []byte) (map[string]nodeMetrics, error) {
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Usage nodeMetrics `json:"usage"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, err
+	}
+	metrics := map[string]nodeMetrics{}
+	for _, item := range list.Items {
+		metrics[item.Metadata.Name] = item.Usage
+	}
+	return metrics, nil
+}
+
+// usageCell formats the bytes used of a resource, with their percentage of its total
+func usageCell(used, total int64) string {
+	if total <= 0 {
+		return units.BytesSize(float64(used))
+	}
+	return fmt.Sprintf("%s / %s (%d%%)", units.BytesSize(float64(used)), units.BytesSize(float64(total)), used*100/total)
+}
+
+func printTopTable(tops []nodeTop) {
+	if len(tops) == 0 {
+		out.Styled(style.Empty, "No running nodes")
+		return
+	}
+	metrics := false
+	for _, t := range tops {
+		metrics = metrics || t.KubernetesCPU != ""
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	header := []any{"Profile", "Node", "Source", "CPU", "Memory", "Disk", "PIDs"}
+	if metrics {
+		header = append(header, "K8s CPU", "K8s Memory")
+	}
+	table.Header(append(header, "Pressure")...)
+	table.Options(
+		tablewriter.WithHeaderAutoFormat(tw.On),
+	)
+	var data [][]string
+	for _, t := range tops {
+		row := []string{
+			t.Profile,
+			t.Name,
+			t.Source,
+			fmt.Sprintf("%.1f%% of %d", t.CPUPercent, t.CPUs),
+			usageCell(t.MemoryUsed, t.MemoryTotal),
+			usageCell(t.DiskUsed, t.DiskTotal),
+			strconv.Itoa(t.PIDs),
+		}
+		if metrics {
+			row = append(row, t.KubernetesCPU, t.KubernetesMemory)
+		}
+		data = append(data, append(row, strings.Join(t.Pressure, ",")))
+	}
+	if err := table.Bulk(data); err != nil {
+		klog.Error("Error rendering table (bulk)", err)
+	}
+	if err := table.Render(); err != nil {
+		klog.Error("Error rendering table", err)
+	}
+
+	for _, t := range tops {
+		if len(t.Pressure) > 0 {
+			out.WarningT("Node {{.name}} is near {{.resources}} pressure, at which the kubelet starts evicting pods", out.V{"name": t.Name, "resources": strings.Join(t.Pressure, ", ")})
+		}
+	}
+}
+
+func printTopJSON(tops []nodeTop) {
+	b, err := json.Marshal(tops)
+	if err != nil {
+		exit.Error(reason.InternalJSONMarshal, "Failed to marshal usage", err)
+	}
+	out.String(string(b) + "\n")
+}
+
+func init() {
+	topCmd.Flags().BoolVar(&topAllProfiles, "all-profiles", false, "Display the nodes of all the running profiles")
+	topCmd.Flags().DurationVarP(&topWatch, "watch", "w", 2*time.Second, "Continuously display the usage with optional interval duration.")
+	topCmd.Flags().Lookup("watch").NoOptDefVal = "2s"
+	topCmd.Flags().StringVarP(&topOutput, "output", "o", "table", "The output format. One of 'json', 'table'")
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestParseNodeMetrics(t *testing.T) {
+	body := []byte(`{
+  "kind": "NodeMetricsList",
+  "apiVersion": "metrics.k8s.io/v1beta1",
+  "items": [
+    {"metadata": {"name": "minikube"}, "timestamp": "2025-01-01T00:00:00Z", "window": "10s", "usage": {"cpu": "250m", "memory": "1Gi"}},
+    {"metadata": {"name": "minikube-m02"}, "timestamp": "2025-01-01T00:00:00Z", "window": "10s", "usage": {"cpu": "1500m", "memory": "524288Ki"}}
+  ]
+}`)
+	metrics, err := parseNodeMetrics(body)
+	if err != nil {
+		t.Fatalf("parseNodeMetrics returned error: %v", err)
+	}
+	if len(metrics) != 2 {
+		t.Fatalf("got metrics of %d nodes, want 2", len(metrics))
+	}
+	if m := metrics["minikube"]; m.CPU.MilliValue() != 250 || m.Memory.Value() != 1<<30 {
+		t.Errorf("got cpu %s and memory %s for minikube, want 250m and 1Gi", m.CPU.String(), m.Memory.String())
+	}
+	if m := metrics["minikube-m02"]; m.CPU.MilliValue() != 1500 || m.Memory.Value() != 512<<20 {
+		t.Errorf("got cpu %s and memory %s for minikube-m02, want 1500m and 512Mi", m.CPU.String(), m.Memory.String())
+	}
+
+	if _, err := parseNodeMetrics([]byte("not json")); err == nil {
+		t.Error("expected an error for invalid metrics")
+	}
+}
+
+func TestUsageCell(t *testing.T) {
+	tests := []struct {
+		used, total int64
+		want        string
+	}{
+		{1 << 30, 4 << 30, "1GiB / 4GiB (25%)"},
+		{512 << 20, 0, "512MiB"},
+	}
+	for _, tc := range tests {
+		if got := usageCell(tc.used, tc.total); got != tc.want {
+			t.Errorf("usageCell(%d, %d) = %q, want %q", tc.used, tc.total, got, tc.want)
+		}
+	}
+}
@@ -18,12 +18,18 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"runtime"
 	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/docker/machine/libmachine/state"
 
 	"github.com/juju/fslock"
 	"github.com/spf13/cobra"
@@ -35,6 +41,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
@@ -46,8 +53,13 @@ import (
 
 var cleanup bool
 var bindAddress string
+var tunnelBackground bool
+var tunnelStatusOutput string
 var lockHandle *fslock.Lock
 
+// tunnelStopTimeout is how long a tunnel running in the background is given to clean up before being killed
+const tunnelStopTimeout = 30 * time.Second
+
 // tunnelCmd represents the tunnel command
 var tunnelCmd = &cobra.Command{
 	Use:   "tunnel",
@@ -69,6 +81,21 @@ var tunnelCmd = &cobra.Command{
 			exit.Message(reason.Unimplemented, msg)
 		}
 
+		sshTunnel := driver.NeedsPortForward(co.Config.Driver) || bindAddress != ""
+		var daemonState *tunnel.DaemonState
+		if tunnelBackground {
+			daemonizeTunnel(cname)
+			defer tunnel.RemoveDaemonFiles(cname)
+			mode := tunnel.RouteMode
+			if sshTunnel {
+				mode = tunnel.SSHMode
+			}
+			daemonState = tunnel.NewDaemonState(cname, mode)
+			if err := tunnel.WriteDaemonState(daemonState); err != nil {
+				klog.Warningf("unable to save tunnel state: %v", err)
+			}
+		}
+
 		if cleanup {
 			klog.Info("Checking for tunnels to cleanup...")
 			if err := manager.CleanupNotRunningTunnels(); err != nil {
@@ -85,43 +112,191 @@ var tunnelCmd = &cobra.Command{
 		// doesn't hang on the API server call during startup and shutdown time or if there is a temporary error.
 		clientset, err := kapi.Client(cname)
 		if err != nil {
-			exit.Error(reason.InternalKubernetesClient, "error creating clientset", err)
+			exitTunnel(cname, reason.InternalKubernetesClient, "error creating clientset", err)
 		}
 
 		ctrlC := make(chan os.Signal, 1)
-		signal.Notify(ctrlC, os.Interrupt)
+		signal.Notify(ctrlC, os.Interrupt, syscall.SIGTERM)
 		ctx, cancel := context.WithCancel(context.Background())
 		go func() {
 			<-ctrlC
 			cancel()
 		}()
 
-		if driver.NeedsPortForward(co.Config.Driver) || bindAddress != "" {
+		if sshTunnel {
 			port, err := oci.ForwardedPort(co.Config.Driver, cname, 22)
 			if err != nil {
-				exit.Error(reason.DrvPortForward, "error getting ssh port", err)
+				exitTunnel(cname, reason.DrvPortForward, "error getting ssh port", err)
 			}
 			sshPort := strconv.Itoa(port)
 			sshKey := filepath.Join(localpath.MiniPath(), "machines", cname, "id_rsa")
 
-			outputTunnelStarted()
 			kicSSHTunnel := kic.NewSSHTunnel(ctx, sshPort, sshKey, bindAddress, clientset.CoreV1(), clientset.NetworkingV1())
+			if tunnelBackground {
+				kicSSHTunnel.RecordState(daemonState)
+			} else {
+				outputTunnelStarted()
+			}
 			err = kicSSHTunnel.Start()
 			if err != nil {
-				exit.Error(reason.SvcTunnelStart, "error starting tunnel", err)
+				exitTunnel(cname, reason.SvcTunnelStart, "error starting tunnel", err)
 			}
 
 			return
 		}
 
+		if tunnelBackground {
+			manager.RecordState(daemonState)
+		}
 		done, err := manager.StartTunnel(ctx, cname, co.API, config.DefaultLoader, clientset.CoreV1())
 		if err != nil {
-			exit.Error(reason.SvcTunnelStart, "error starting tunnel", err)
+			exitTunnel(cname, reason.SvcTunnelStart, "error starting tunnel", err)
 		}
 		<-done
 	},
 }
 
+var tunnelStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Shows the status of the tunnel running in the background",
+	Long:  "Shows the status of the tunnel started with 'minikube tunnel --background': its process, route and the services it manages",
+	Run: func(_ *cobra.Command, _ []string) {
+		cname := ClusterFlagValue()
+		st, err := tunnel.ReadDaemonState(cname)
+		if err != nil {
+			exit.Error(reason.SvcTunnelStatus, "Failed to get the tunnel status", err)
+		}
+		if st == nil {
+			st = &tunnel.DaemonState{Profile: cname, LogFile: localpath.TunnelLog(cname), PatchedServices: []string{}}
+		}
+
+		switch strings.ToLower(tunnelStatusOutput) {
+		case "text":
+			printTunnelStatus(st)
+		case "json":
+			b, err := json.Marshal(st)
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "Failed to marshal tunnel status", err)
+			}
+			out.String(string(b))
+		default:
+			exit.Message(reason.Usage, fmt.Sprintf("invalid output format: %s. Valid values: 'text', 'json'", tunnelStatusOutput))
+		}
+	},
+}
+
+var tunnelStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stops the tunnel running in the background",
+	Long:  "Stops the tunnel started with 'minikube tunnel --background', removing its routes and the load balancer ingress of the services it manages",
+	Run: func(_ *cobra.Command, _ []string) {
+		cname := ClusterFlagValue()
+		st, err := tunnel.StopDaemon(cname, tunnelStopTimeout)
+		if err != nil {
+			exit.Error(reason.SvcTunnelStop, "Failed to stop the tunnel", err)
+		}
+		if st == nil {
+			out.Styled(style.Meh, "No tunnel is running in the background for profile {{.profile}}", out.V{"profile": cname})
+			return
+		}
+		cleanupStoppedTunnel(cname)
+		out.Step(style.Stopped, "Stopped the tunnel of profile {{.profile}}", out.V{"profile": cname})
+	},
+}
+
+// daemonizeTunnel runs the rest of the tunnel command in the background, only the daemon returns
+func daemonizeTunnel(cname string) {
+	st, err := tunnel.ReadDaemonState(cname)
+	if err != nil {
+		klog.Warningf("unable to read the state of the background tunnel: %v", err)
+	}
+	if st != nil && st.Running && st.Pid != os.Getpid() {
+		exit.Message(reason.SvcTunnelAlreadyRunning, "A tunnel is already running in the background for profile {{.profile}} (pid {{.pid}}), run 'minikube tunnel stop' to stop it", out.V{"profile": cname, "pid": st.Pid})
+	}
+	// fail early if a tunnel is running in the foreground, the daemon takes the lock for itself
+	mustLockOrExit(cname)
+	cleanupLock()
+
+	if runtime.GOOS != "windows" && os.Geteuid() != 0 {
+		out.WarningT("The tunnel might need sudo for routes or privileged ports: in the background it only works if sudo does not ask for a password")
+	}
+	out.Step(style.Running, "Starting the tunnel of profile {{.profile}} in the background, its logs are in {{.log}}", out.V{"profile": cname, "log": localpath.TunnelLog(cname)})
+	out.Styled(style.Tip, "Run 'minikube tunnel status' to check it and 'minikube tunnel stop' to stop it")
+	if err := tunnel.Daemonize(cname); err != nil {
+		tunnel.RemoveOwnDaemonFiles(cname)
+		exit.Message(reason.DaemonizeError, "unable to daemonize: {{.err}}", out.V{"err": err.Error()})
+	}
+}
+
+// cleanupStoppedTunnel removes the routes and load balancer ingresses left over by a tunnel that could not clean up
+// after itself, so that the services are consistent when a tunnel is started again
+func cleanupStoppedTunnel(cname string) {
+	if err := tunnel.NewManager().CleanupNotRunningTunnels(); err != nil {
+		klog.Warningf("unable to clean up routes: %v", err)
+	}
+	// a tunnel running in the foreground holds the lock, its services must be left alone
+	l := fslock.New(tunnelLockPath(cname))
+	if err := l.TryLock(); err != nil {
+		klog.Infof("not cleaning up services, another tunnel is running: %v", err)
+		return
+	}
+	defer func() {
+		if err := l.Unlock(); err != nil {
+			klog.Warningf("failed to release tunnel lock: %v", err)
+		}
+	}()
+
+	api, cc := mustload.Partial(cname)
+	cp, err := config.ControlPlane(*cc)
+	if err != nil {
+		klog.Warningf("unable to get control-plane node: %v", err)
+		return
+	}
+	if hs, err := machine.Status(api, config.MachineName(*cc, cp)); err != nil || hs != state.Running.String() {
+		klog.Infof("not cleaning up services, %s is not running: %v", cname, err)
+		return
+	}
+	clientset, err := kapi.Client(cname)
+	if err != nil {
+		klog.Warningf("unable to create kubernetes client: %v", err)
+		return
+	}
+	lb := tunnel.NewLoadBalancerEmulator(clientset.CoreV1())
+	if _, err := lb.Cleanup(); err != nil {
+		klog.Warningf("unable to clean up load balancer services: %v", err)
+	}
+}
+
+func printTunnelStatus(st *tunnel.DaemonState) {
+	if st.Pid == 0 {
+		out.Styled(style.Meh, "No tunnel is running in the background for profile {{.profile}}", out.V{"profile": st.Profile})
+		return
+	}
+	if !st.Running {
+		out.Styled(style.Stopped, "The tunnel of profile {{.profile}} (pid {{.pid}}) is not running anymore, see {{.log}}", out.V{"profile": st.Profile, "pid": st.Pid, "log": st.LogFile})
+		return
+	}
+	out.Styled(style.Running, "The tunnel of profile {{.profile}} is running in the background (pid {{.pid}})", out.V{"profile": st.Profile, "pid": st.Pid})
+	if st.Route != "" {
+		out.Styled(style.Connectivity, "Route: {{.route}}", out.V{"route": st.Route})
+	}
+	services := "none"
+	if len(st.PatchedServices) > 0 {
+		services = strings.Join(st.PatchedServices, ", ")
+	}
+	out.Styled(style.Indent, "Services: {{.services}}", out.V{"services": services})
+	for _, e := range []string{st.MinikubeError, st.RouteError, st.LoadBalancerEmulatorError} {
+		if e != "" {
+			out.Styled(style.Warning, "{{.error}}", out.V{"error": e})
+		}
+	}
+	out.Styled(style.Documentation, "Logs: {{.log}}", out.V{"log": st.LogFile})
+}
+
+func tunnelLockPath(profile string) string {
+	return filepath.Join(localpath.Profile(profile), ".tunnel_lock")
+}
+
 func cleanupLock() {
 	if lockHandle != nil {
 		err := lockHandle.Unlock()
@@ -132,18 +307,24 @@ func cleanupLock() {
 }
 
 func mustLockOrExit(profile string) {
-	tunnelLockPath := filepath.Join(localpath.Profile(profile), ".tunnel_lock")
-
-	lockHandle = fslock.New(tunnelLockPath)
+	lockHandle = fslock.New(tunnelLockPath(profile))
 	err := lockHandle.TryLock()
 	if err == fslock.ErrLocked {
+		tunnel.RemoveOwnDaemonFiles(profile)
 		exit.Message(reason.SvcTunnelAlreadyRunning, "Another tunnel process is already running, terminate the existing instance to start a new one")
 	}
 	if err != nil {
-		exit.Error(reason.SvcTunnelStart, "failed to acquire lock due to unexpected error", err)
+		exitTunnel(profile, reason.SvcTunnelStart, "failed to acquire lock due to unexpected error", err)
 	}
 }
 
+// exitTunnel exits on err, first removing the pid and state files if this process is the tunnel running in the background,
+// as exiting skips the deferred cleanup and the files would block the next background tunnel
+func exitTunnel(profile string, r reason.Kind, msg string, err error) {
+	tunnel.RemoveOwnDaemonFiles(profile)
+	exit.Error(r, msg, err)
+}
+
 func outputTunnelStarted() {
 	out.Styled(style.Success, "Tunnel successfully started")
 	out.Ln("")
@@ -154,4 +335,8 @@ func outputTunnelStarted() {
 func init() {
 	tunnelCmd.Flags().BoolVarP(&cleanup, "cleanup", "c", true, "call with cleanup=true to remove old tunnels")
 	tunnelCmd.Flags().StringVar(&bindAddress, "bind-address", "", "set tunnel bind address, empty or '*' indicates the tunnel should be available for all interfaces")
+	tunnelCmd.Flags().BoolVar(&tunnelBackground, "background", false, "Run the tunnel in the background, see 'minikube tunnel status' and 'minikube tunnel stop'")
+	tunnelStatusCmd.Flags().StringVarP(&tunnelStatusOutput, "output", "o", "text", "Format to print the status in. One of 'text', 'json'")
+	tunnelCmd.AddCommand(tunnelStatusCmd)
+	tunnelCmd.AddCommand(tunnelStopCmd)
 }
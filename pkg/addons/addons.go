@@ -449,6 +449,22 @@ func enableOrDisableAddonInternal(cc *config.ClusterConfig, addon *assets.Addon,
 		}
 	}
 
+	if addon.Chart != nil {
+		fPaths, err := installChart(cc, addon.Chart, runner, data, enable)
+		if err != nil {
+			return err
+		}
+		if !enable {
+			defer func() {
+				args := append([]string{"rm", "-f", addon.Chart.NamespaceTargetPath()}, fPaths...)
+				if _, err := runner.RunCmd(exec.Command("sudo", args...)); err != nil {
+					klog.Warningf("error removing %v; addon should still be disabled as expected", fPaths)
+				}
+			}()
+		}
+		deployFiles = append(deployFiles, fPaths...)
+	}
+
 	// on the first attempt try without force, but on subsequent attempts use force
 	force := false
 
@@ -467,6 +483,37 @@ func enableOrDisableAddonInternal(cc *config.ClusterConfig, addon *assets.Addon,
 	return retry.Expo(apply, 250*time.Millisecond, 2*time.Minute)
 }
 
+// installChart renders a chart-backed addon onto the node and returns the paths of the manifests to apply or delete.
+// On enable the release namespace is created by a manifest of its own, which is not deleted on disable as it may be shared.
+// On disable the manifest rendered at enable time is reused, so every object that was applied gets deleted
+// even if the profile values changed in the meantime.
+func installChart(cc *config.ClusterConfig, chart *assets.HelmChart, runner command.Runner, data interface{}, enable bool) ([]string, error) {
+	fPath := chart.GetTargetPath()
+	if !enable {
+		if _, err := runner.RunCmd(exec.Command("sudo", "test", "-f", fPath)); err == nil {
+			return []string{fPath}, nil
+		}
+		klog.Infof("%s is missing, rendering the chart again to disable it", fPath)
+	}
+
+	var fPaths []string
+	if ns := chart.NamespaceAsset(); ns != nil && enable {
+		if err := runner.Copy(ns); err != nil {
+			return nil, err
+		}
+		fPaths = append(fPaths, chart.NamespaceTargetPath())
+	}
+	f, err := chart.Render(cc.KubernetesConfig.KubernetesVersion, data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "render chart %s", chart.Dir)
+	}
+	klog.Infof("installing %s", fPath)
+	if err := runner.Copy(f); err != nil {
+		return nil, err
+	}
+	return append(fPaths, fPath), nil
+}
+
 func verifyAddonStatus(cc *config.ClusterConfig, name string, val string) error {
 	ns := "kube-system"
 	if name == "ingress" {
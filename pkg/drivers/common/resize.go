@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/docker/machine/libmachine/log"
+	"github.com/pkg/errors"
+
+	"k8s.io/minikube/pkg/util"
+)
+
+// Resources are the resources of a machine
+type Resources struct {
+	CPUs     int // 0 if not limited
+	Memory   int // MB, 0 if not limited
+	DiskSize int // MB, 0 if not limited
+}
+
+// Resizer is implemented by the drivers which can change the resources of an existing machine
+type Resizer interface {
+	// Resources returns the resources the machine was given
+	Resources() Resources
+	// Resize changes the resources of the machine, which must be stopped if it is a VM. Its disk can only grow.
+	Resize(Resources) error
+}
+
+// GrowRawDisk grows a raw disk image to sizeMB, leaving the partitions and filesystems on it to be grown by the guest
+func GrowRawDisk(diskPath string, sizeMB int) error {
+	fi, err := os.Stat(diskPath)
+	if err != nil {
+		return errors.Wrap(err, "stat")
+	}
+	size := util.ConvertMBToBytes(sizeMB)
+	if size < fi.Size() {
+		return fmt.Errorf("cannot shrink disk %s from %dMB to %dMB", diskPath, fi.Size()>>20, sizeMB)
+	}
+	log.Infof("Growing raw disk image: %s to %vMB", diskPath, sizeMB)
+	return os.Truncate(diskPath, size)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGrowRawDisk(t *testing.T) {
+	diskPath := filepath.Join(t.TempDir(), "disk")
+	if err := CreateRawDisk(diskPath, 10); err != nil {
+		t.Fatalf("CreateRawDisk: %v", err)
+	}
+
+	if err := GrowRawDisk(diskPath, 20); err != nil {
+		t.Fatalf("GrowRawDisk: %v", err)
+	}
+	fi, err := os.Stat(diskPath)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if fi.Size() != 20<<20 {
+		t.Errorf("got disk size %d, want %d", fi.Size(), 20<<20)
+	}
+
+	if err := GrowRawDisk(diskPath, 10); err == nil {
+		t.Error("expected an error shrinking the disk")
+	}
+}
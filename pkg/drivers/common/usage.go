@@ -0,0 +1,44 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import "time"
+
+// UsageInterval is how long the CPU usage of a machine is measured over
+const UsageInterval = time.Second
+
+// Usage is the usage of the resources of a machine, as seen from the host
+type Usage struct {
+	CPUPercent  float64 // of one CPU, so up to 100 times the number of CPUs of the machine
+	MemoryUsed  int64   // bytes
+	MemoryLimit int64   // bytes
+	CPUs        int     // 0 if the CPUs of the machine are not limited
+	PIDs        int     // 0 if the host cannot see the processes of the machine
+}
+
+// UsageReporter is implemented by the drivers which measure the usage of the resources of their machine from the host
+type UsageReporter interface {
+	Usage() (*Usage, error)
+}
+
+// CPUPercent returns the percentage of one CPU used by cpuTime over elapsed
+func CPUPercent(cpuTime, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return 100 * float64(cpuTime) / float64(elapsed)
+}
@@ -93,13 +93,17 @@ func (d *Driver) Create() error {
 	if params.Memory != "0" {
 		params.Memory += "mb"
 	}
+	if d.NodeConfig.IPv6 {
+		// docker disables ipv6 inside containers unless asked otherwise, and nodes route pod traffic
+		params.ExtraArgs = append(params.ExtraArgs, "--sysctl", "net.ipv6.conf.all.disable_ipv6=0", "--sysctl", "net.ipv6.conf.all.forwarding=1")
+	}
 
 	networkName := d.NodeConfig.Network
 	if networkName == "" {
 		networkName = d.NodeConfig.ClusterName
 	}
 	staticIP := d.NodeConfig.StaticIP
-	if gateway, err := oci.CreateNetwork(d.OCIBinary, networkName, d.NodeConfig.Subnet, staticIP); err != nil {
+	if gateway, err := oci.CreateNetwork(d.OCIBinary, networkName, d.NodeConfig.Subnet, staticIP, d.NodeConfig.IPv6); err != nil {
 		msg := "Unable to create dedicated network, this might result in cluster IP change after restart: {{.error}}"
 		args := out.V{"error": err}
 		if staticIP != "" {
@@ -346,6 +350,33 @@ func (d *Driver) GetState() (state.State, error) {
 	return oci.ContainerStatus(d.OCIBinary, d.MachineName, true)
 }
 
+// Usage returns the usage of the resources of the container, read from its cgroup
+func (d *Driver) Usage() (*common.Usage, error) {
+	u, err := oci.ContainerStats(d.OCIBinary, d.MachineName)
+	if err != nil {
+		return nil, err
+	}
+	return &common.Usage{CPUPercent: u.CPUPercent, MemoryUsed: u.MemoryUsed, MemoryLimit: u.MemoryLimit, CPUs: d.NodeConfig.CPU, PIDs: u.PIDs}, nil
+}
+
+// Resources returns the CPUs and memory the container is limited to. Its volume is not limited in size.
+func (d *Driver) Resources() common.Resources {
+	return common.Resources{CPUs: d.NodeConfig.CPU, Memory: d.NodeConfig.Memory}
+}
+
+// Resize changes the CPUs and memory the container is limited to, which docker and podman can do while it runs
+func (d *Driver) Resize(r common.Resources) error {
+	if (r.CPUs == 0 && d.NodeConfig.CPU != 0) || (r.Memory == 0 && d.NodeConfig.Memory != 0) {
+		return errors.New("the limits of an existing container cannot be removed")
+	}
+	if err := oci.UpdateContainerResources(d.OCIBinary, d.MachineName, r.CPUs, r.Memory); err != nil {
+		return err
+	}
+	d.NodeConfig.CPU = r.CPUs
+	d.NodeConfig.Memory = r.Memory
+	return nil
+}
+
 // Kill stops a host forcefully, including any containers that we are managing.
 func (d *Driver) Kill() error {
 	// on init this doesn't get filled when called from cmd
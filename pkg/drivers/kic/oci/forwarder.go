@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// PortForwarderLabelKey is applied to the containers publishing ports of an existing kic node
+const PortForwarderLabelKey = "port-forwarder.minikube.sigs.k8s.io"
+
+// PortForwarder is a node port published on the host after the node container was created
+type PortForwarder struct {
+	ListenAddress string
+	HostPort      int
+	ContainerPort int
+	Protocol      string // tcp or udp
+}
+
+// ParsePortForwarder parses a [listen address:]host port:container port[/protocol] spec, the same format as --ports
+func ParsePortForwarder(spec string) (PortForwarder, error) {
+	mappings, err := nat.ParsePortSpec(spec)
+	if err != nil {
+		return PortForwarder{}, errors.Wrapf(err, "parse port %q", spec)
+	}
+	if len(mappings) != 1 {
+		return PortForwarder{}, fmt.Errorf("port %q must map a single port", spec)
+	}
+	m := mappings[0]
+	if m.Binding.HostPort == "" {
+		return PortForwarder{}, fmt.Errorf("port %q must have a host port, eg 8080:%s", spec, m.Port.Port())
+	}
+	p := PortForwarder{
+		ListenAddress: m.Binding.HostIP,
+		ContainerPort: m.Port.Int(),
+		Protocol:      m.Port.Proto(),
+	}
+	if p.HostPort, err = strconv.Atoi(m.Binding.HostPort); err != nil {
+		return PortForwarder{}, errors.Wrapf(err, "parse host port %q", m.Binding.HostPort)
+	}
+	if p.ListenAddress == "" {
+		p.ListenAddress = DefaultBindIPV4
+	}
+	if p.Protocol != "tcp" && p.Protocol != "udp" {
+		return PortForwarder{}, fmt.Errorf("port %q has unsupported protocol %q, must be tcp or udp", spec, p.Protocol)
+	}
+	return p, nil
+}
+
+// String returns the spec of the port forwarder
+func (p PortForwarder) String() string {
+	return fmt.Sprintf("%s:%d:%d/%s", p.ListenAddress, p.HostPort, p.ContainerPort, p.Protocol)
+}
+
+// PortForwarderName returns the name of the container forwarding a port of a profile
+func PortForwarderName(profile string, p PortForwarder) string {
+	return fmt.Sprintf("%s-port-%d-%s", profile, p.HostPort, p.Protocol)
+}
+
+// portForwarderArgs returns the arguments to run a forwarder container relaying the host port to the node with socat
+func portForwarderArgs(profile, network, image, nodeIP string, p PortForwarder) []string {
+	proto := strings.ToUpper(p.Protocol)
+	return []string{
+		"run", "-d",
+		"--name", PortForwarderName(profile, p),
+		"--network", network,
+		"--label", fmt.Sprintf("%s=%s", CreatedByLabelKey, "true"),
+		"--label", fmt.Sprintf("%s=%s", ProfileLabelKey, profile),
+		"--label", fmt.Sprintf("%s=%s", PortForwarderLabelKey, p),
+		"--publish", fmt.Sprintf("%s:%d/%s", net.JoinHostPort(p.ListenAddress, strconv.Itoa(p.HostPort)), p.ContainerPort, p.Protocol),
+		"--entrypoint", "socat",
+		image,
+		fmt.Sprintf("%s-LISTEN:%d,fork,reuseaddr", proto, p.ContainerPort),
+		fmt.Sprintf("%s:%s", proto, net.JoinHostPort(nodeIP, strconv.Itoa(p.ContainerPort))),
+	}
+}
+
+// CreatePortForwarder publishes a port of a running node: it runs a container on the node network that listens on the
+// host port and relays the traffic to the node IP. An existing forwarder for the same host port is replaced.
+func CreatePortForwarder(ociBin, profile, network, image, nodeIP string, p PortForwarder) error {
+	if err := DeletePortForwarder(ociBin, profile, p); err != nil {
+		return err
+	}
+	klog.Infof("creating port forwarder %s for %s -> %s", PortForwarderName(profile, p), p, nodeIP)
+	if rr, err := runCmd(exec.Command(ociBin, portForwarderArgs(profile, network, image, nodeIP, p)...)); err != nil {
+		return errors.Wrapf(err, "create port forwarder: %s", rr.Output())
+	}
+	return nil
+}
+
+// DeletePortForwarder removes the container forwarding a port, if any
+func DeletePortForwarder(ociBin, profile string, p PortForwarder) error {
+	name := PortForwarderName(profile, p)
+	if _, err := ContainerStatus(ociBin, name); err != nil {
+		klog.Infof("no port forwarder %s to delete: %v", name, err)
+		return nil
+	}
+	if rr, err := runCmd(exec.Command(ociBin, "rm", "-f", name)); err != nil {
+		return errors.Wrapf(err, "delete port forwarder %s: %s", name, rr.Output())
+	}
+	return nil
+}
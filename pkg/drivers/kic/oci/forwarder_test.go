@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePortForwarder(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+		err  bool
+	}{
+		{spec: "8443:30443/tcp", want: "127.0.0.1:8443:30443/tcp"},
+		{spec: "8080:80", want: "127.0.0.1:8080:80/tcp"},
+		{spec: "0.0.0.0:5353:53/udp", want: "0.0.0.0:5353:53/udp"},
+		{spec: "80", err: true},
+		{spec: "8080-8081:80-81", err: true},
+		{spec: "8080:80/sctp", err: true},
+		{spec: "abc:80", err: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			p, err := ParsePortForwarder(tc.spec)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("ParsePortForwarder(%q) = %s, expected an error", tc.spec, p)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePortForwarder(%q): %v", tc.spec, err)
+			}
+			if p.String() != tc.want {
+				t.Errorf("ParsePortForwarder(%q) = %s, want %s", tc.spec, p, tc.want)
+			}
+		})
+	}
+}
+
+func TestPortForwarderArgs(t *testing.T) {
+	p, err := ParsePortForwarder("5353:53/udp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(portForwarderArgs("p1", "p1", "kicbase", "192.168.49.2", p), " ")
+	want := "run -d --name p1-port-5353-udp --network p1 --label created_by.minikube.sigs.k8s.io=true --label name.minikube.sigs.k8s.io=p1 " +
+		"--label port-forwarder.minikube.sigs.k8s.io=127.0.0.1:5353:53/udp --publish 127.0.0.1:5353:53/udp --entrypoint socat kicbase " +
+		"UDP-LISTEN:53,fork,reuseaddr UDP:192.168.49.2:53"
+	if got != want {
+		t.Errorf("portForwarderArgs() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// LoadBalancerImage is the image of the load balancer fronting the API servers of HA (multi-control plane) clusters with --ha-lb=haproxy
+	LoadBalancerImage = "docker.io/library/haproxy:2.8-alpine"
+	// loadBalancerConfig is the config of haproxy in the load balancer container
+	loadBalancerConfig = "/usr/local/etc/haproxy/haproxy.cfg"
+)
+
+// LoadBalancerName returns the name of the load balancer container of a profile
+func LoadBalancerName(profile string) string {
+	return profile + "-lb"
+}
+
+// loadBalancerArgs returns the arguments to create the load balancer container from the image, at the IP of the API servers on the node network,
+// with the port of the API servers published on the host
+func loadBalancerArgs(profile, network, ip, image string, port int) []string {
+	return []string{
+		"create",
+		"--name", LoadBalancerName(profile),
+		"--network", network,
+		"--ip", ip,
+		"--label", fmt.Sprintf("%s=%s", CreatedByLabelKey, "true"),
+		"--label", fmt.Sprintf("%s=%s", ProfileLabelKey, profile),
+		"--publish", fmt.Sprintf("%s::%d", DefaultBindIPV4, port),
+		image,
+	}
+}
+
+// ConfigureLoadBalancer creates the load balancer container of a profile from the image if it does not exist, copies the haproxy config into it,
+// then starts it, or reloads haproxy if it is already running
+func ConfigureLoadBalancer(ociBin, profile, network, ip, image string, port int, cfg []byte) error {
+	name := LoadBalancerName(profile)
+	exists, err := ContainerExists(ociBin, name)
+	if err != nil {
+		return errors.Wrapf(err, "check load balancer %s", name)
+	}
+	if !exists {
+		klog.Infof("creating load balancer %s at %s:%d", name, ip, port)
+		if rr, err := runCmd(exec.Command(ociBin, loadBalancerArgs(profile, network, ip, image, port)...)); err != nil {
+			return errors.Wrapf(err, "create load balancer: %s", rr.Output())
+		}
+	}
+
+	f, err := os.CreateTemp("", "haproxy-*.cfg")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(cfg); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// haproxy does not run as root in its container
+	if err := os.Chmod(f.Name(), 0o644); err != nil {
+		return err
+	}
+	if rr, err := runCmd(exec.Command(ociBin, "cp", f.Name(), name+":"+loadBalancerConfig)); err != nil {
+		return errors.Wrapf(err, "copy load balancer config: %s", rr.Output())
+	}
+
+	running, err := ContainerRunning(ociBin, name)
+	if err != nil {
+		return errors.Wrapf(err, "check load balancer %s", name)
+	}
+	if running {
+		// the master process of haproxy reloads its config, without dropping the established connections
+		if rr, err := runCmd(exec.Command(ociBin, "kill", "--signal", "HUP", name)); err != nil {
+			return errors.Wrapf(err, "reload load balancer: %s", rr.Output())
+		}
+		return nil
+	}
+	if rr, err := runCmd(exec.Command(ociBin, "start", name)); err != nil {
+		return errors.Wrapf(err, "start load balancer: %s", rr.Output())
+	}
+	return nil
+}
+
+// StopLoadBalancer stops the load balancer container of a profile, if any
+func StopLoadBalancer(ociBin, profile string) error {
+	name := LoadBalancerName(profile)
+	if running, err := ContainerRunning(ociBin, name); err != nil || !running {
+		klog.Infof("no running load balancer %s to stop: %v", name, err)
+		return nil
+	}
+	if rr, err := runCmd(exec.Command(ociBin, "stop", name)); err != nil {
+		return errors.Wrapf(err, "stop load balancer %s: %s", name, rr.Output())
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadBalancerArgs(t *testing.T) {
+	got := strings.Join(loadBalancerArgs("p1", "p1", "192.168.49.254", LoadBalancerImage, 8443), " ")
+	want := "create --name p1-lb --network p1 --ip 192.168.49.254 --label created_by.minikube.sigs.k8s.io=true --label name.minikube.sigs.k8s.io=p1 " +
+		"--publish 127.0.0.1::8443 " + LoadBalancerImage
+	if got != want {
+		t.Errorf("loadBalancerArgs() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// SharedNetworkName returns the name of the network shared by the nodes of two profiles connected by 'minikube network connect'
+func SharedNetworkName(profileA, profileB string) string {
+	profiles := []string{profileA, profileB}
+	sort.Strings(profiles)
+	return fmt.Sprintf("%s-to-%s", profiles[0], profiles[1])
+}
+
+// ConnectNetwork attaches a container to a network, if it is not attached yet
+func ConnectNetwork(ociBin, network, container string) error {
+	if ip, err := ContainerNetworkIP(ociBin, container, network); err == nil && ip != "" {
+		klog.Infof("container %s is already attached to network %s with IP %s", container, network, ip)
+		return nil
+	}
+	if rr, err := runCmd(exec.Command(ociBin, "network", "connect", network, container)); err != nil {
+		return errors.Wrapf(err, "connect %s to network %s: %s", container, network, rr.Output())
+	}
+	return nil
+}
+
+// DisconnectNetwork detaches a container from a network, if it is attached
+func DisconnectNetwork(ociBin, network, container string) error {
+	if ip, err := ContainerNetworkIP(ociBin, container, network); err != nil || ip == "" {
+		klog.Infof("container %s is not attached to network %s: %v", container, network, err)
+		return nil
+	}
+	if rr, err := runCmd(exec.Command(ociBin, "network", "disconnect", network, container)); err != nil {
+		return errors.Wrapf(err, "disconnect %s from network %s: %s", container, network, rr.Output())
+	}
+	return nil
+}
+
+// ContainerNetworkIP returns the IPv4 address of a container on a network, empty if it is not attached to the network
+func ContainerNetworkIP(ociBin, container, network string) (string, error) {
+	format := fmt.Sprintf("{{with index .NetworkSettings.Networks %q}}{{.IPAddress}}{{end}}", network)
+	rr, err := runCmd(exec.Command(ociBin, "container", "inspect", "-f", format, container))
+	if err != nil {
+		return "", errors.Wrapf(err, "inspect IP of %s on network %s", container, network)
+	}
+	return strings.TrimSpace(rr.Stdout.String()), nil
+}
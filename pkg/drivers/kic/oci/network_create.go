@@ -64,7 +64,8 @@ func firstSubnetAddr(subnet string) string {
 }
 
 // CreateNetwork creates a network returns gateway and error, minikube creates one network per cluster
-func CreateNetwork(ociBin, networkName, subnet, staticIP string) (net.IP, error) {
+// if ipv6 is set, the network also gets an IPv6 unique local subnet paired with its IPv4 one
+func CreateNetwork(ociBin, networkName, subnet, staticIP string, ipv6 bool) (net.IP, error) {
 	defaultBridgeName := defaultBridgeName(ociBin)
 	if networkName == defaultBridgeName {
 		klog.Infof("skipping creating network since default network %s was specified", networkName)
@@ -103,7 +104,7 @@ func CreateNetwork(ociBin, networkName, subnet, staticIP string) (net.IP, error)
 			klog.Errorf("failed to find free subnet for %s network %s after %d attempts: %v", ociBin, networkName, 20, err)
 			return nil, fmt.Errorf("un-retryable: %w", err)
 		}
-		info.gateway, err = tryCreateDockerNetwork(ociBin, subnet, info.mtu, networkName)
+		info.gateway, err = tryCreateDockerNetwork(ociBin, subnet, info.mtu, networkName, ipv6)
 		if err == nil {
 			klog.Infof("%s network %s %s created", ociBin, networkName, subnet.CIDR)
 			return info.gateway, nil
@@ -119,7 +120,7 @@ func CreateNetwork(ociBin, networkName, subnet, staticIP string) (net.IP, error)
 	return info.gateway, fmt.Errorf("failed to create %s network %s: %w", ociBin, networkName, err)
 }
 
-func tryCreateDockerNetwork(ociBin string, subnet *network.Parameters, mtu int, name string) (net.IP, error) {
+func tryCreateDockerNetwork(ociBin string, subnet *network.Parameters, mtu int, name string, ipv6 bool) (net.IP, error) {
 	gateway := net.ParseIP(subnet.Gateway)
 	klog.Infof("attempt to create %s network %s %s with gateway %s and MTU of %d ...", ociBin, name, subnet.CIDR, subnet.Gateway, mtu)
 	args := []string{
@@ -129,6 +130,14 @@ func tryCreateDockerNetwork(ociBin string, subnet *network.Parameters, mtu int,
 		fmt.Sprintf("--subnet=%s", subnet.CIDR),
 		fmt.Sprintf("--gateway=%s", subnet.Gateway),
 	}
+	if ipv6 {
+		subnetv6, gatewayv6, err := network.IPv6Subnet(subnet.IP)
+		if err != nil {
+			return nil, errors.Wrap(err, "ipv6 subnet")
+		}
+		klog.Infof("enabling ipv6 on %s network %s with subnet %s and gateway %s", ociBin, name, subnetv6, gatewayv6)
+		args = append(args, "--ipv6", fmt.Sprintf("--subnet=%s", subnetv6), fmt.Sprintf("--gateway=%s", gatewayv6))
+	}
 	if ociBin == Docker {
 		// options documentation https://docs.docker.com/engine/reference/commandline/network_create/#bridge-driver-options
 		args = append(args, "-o")
@@ -196,7 +205,7 @@ type networkInspect struct {
 var dockerInspectGetter = func(name string) (*RunResult, error) {
 	// hack -- 'support ancient versions of docker again (template parsing issue) #10362' and resolve 'Template parsing error: template: :1: unexpected "=" in operand' / 'exit status 64'
 	// note: docker v18.09.7 and older use go v1.10.8 and older, whereas support for '=' operator in go templates came in go v1.11
-	cmd := exec.Command(Docker, "network", "inspect", name, "--format", `{"Name": "{{.Name}}","Driver": "{{.Driver}}","Subnet": "{{range .IPAM.Config}}{{.Subnet}}{{end}}","Gateway": "{{range .IPAM.Config}}{{.Gateway}}{{end}}","MTU": {{if (index .Options "com.docker.network.driver.mtu")}}{{(index .Options "com.docker.network.driver.mtu")}}{{else}}0{{end}}, "ContainerIPs": [{{range $k,$v := .Containers }}"{{$v.IPv4Address}}",{{end}}]}`)
+	cmd := exec.Command(Docker, "network", "inspect", name, "--format", `{"Name": "{{.Name}}","Driver": "{{.Driver}}","Subnet": "{{range $i, $c := .IPAM.Config}}{{if $i}},{{end}}{{$c.Subnet}}{{end}}","Gateway": "{{range $i, $c := .IPAM.Config}}{{if $i}},{{end}}{{$c.Gateway}}{{end}}","MTU": {{if (index .Options "com.docker.network.driver.mtu")}}{{(index .Options "com.docker.network.driver.mtu")}}{{else}}0{{end}}, "ContainerIPs": [{{range $k,$v := .Containers }}"{{$v.IPv4Address}}",{{end}}]}`)
 	rr, err := runCmd(cmd)
 	// remove extra ',' after the last element in the ContainerIPs slice
 	rr.Stdout = *bytes.NewBuffer(bytes.ReplaceAll(rr.Stdout.Bytes(), []byte(",]"), []byte("]")))
@@ -222,17 +231,41 @@ func dockerNetworkInspect(name string) (netInfo, error) {
 		return info, fmt.Errorf("error parsing network inspect output: %q", rr.Stdout.String())
 	}
 
-	info.gateway = net.ParseIP(vals.Gateway)
+	info.gateway = firstIPv4(strings.Split(vals.Gateway, ","))
 	info.mtu = vals.MTU
 
-	_, info.subnet, err = net.ParseCIDR(vals.Subnet)
-	if err != nil {
-		return info, errors.Wrapf(err, "parse subnet for %s", name)
+	// dual-stack networks have one subnet per family, kic nodes are addressed by the IPv4 one
+	for _, s := range strings.Split(vals.Subnet, ",") {
+		ip, subnet, err := net.ParseCIDR(s)
+		if err != nil {
+			return info, errors.Wrapf(err, "parse subnet for %s", name)
+		}
+		if info.subnet == nil || (ip.To4() != nil && info.subnet.IP.To4() == nil) {
+			info.subnet = subnet
+		}
 	}
 
 	return info, nil
 }
 
+// firstIPv4 returns the first IPv4 address of the list, or the first address if there is none
+func firstIPv4(addrs []string) net.IP {
+	var first net.IP
+	for _, a := range addrs {
+		ip := net.ParseIP(a)
+		if ip == nil {
+			continue
+		}
+		if ip.To4() != nil {
+			return ip
+		}
+		if first == nil {
+			first = ip
+		}
+	}
+	return first
+}
+
 var podmanInspectGetter = func(name string) (*RunResult, error) {
 	v, err := podmanVersion()
 	if err != nil {
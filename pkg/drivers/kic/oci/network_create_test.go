@@ -53,6 +53,13 @@ func TestDockerInspect(t *testing.T) {
 			subnetIP:              "172.19.0.0",
 			mtu:                   0,
 		},
+		{
+			name:                  "dualStack",
+			dockerInspectResponse: `{"Name": "m2","Driver": "bridge","Subnet": "fd00:c0a8:3100::/64,192.168.49.0/24","Gateway": "fd00:c0a8:3100::1,192.168.49.1","MTU": 1500, "ContainerIPs": []}`,
+			gateway:               "192.168.49.1",
+			subnetIP:              "192.168.49.0",
+			mtu:                   1500,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+)
+
+// ContainerUsage is the usage of the resources of a container, as reported by docker or podman stats
+type ContainerUsage struct {
+	CPUPercent  float64 // of one CPU
+	MemoryUsed  int64   // bytes
+	MemoryLimit int64   // bytes
+	PIDs        int
+}
+
+// ContainerStats returns the usage of the resources of a container, read from its cgroup by docker or podman
+func ContainerStats(ociBin, name string) (*ContainerUsage, error) {
+	rr, err := runCmd(exec.Command(ociBin, "stats", "--no-stream", "--format", "{{.CPUPerc}}|{{.MemUsage}}|{{.PIDs}}", name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "stats of %s", name)
+	}
+	return parseContainerStats(rr.Stdout.String())
+}
+
+// parseContainerStats parses the stats of a container, such as "12.50%|1.2GiB / 7.6GiB|345"
+func parseContainerStats(s string) (*ContainerUsage, error) {
+	fields := strings.Split(strings.TrimSpace(s), "|")
+	if len(fields) != 3 {
+		return nil, errors.Errorf("unexpected stats %q", s)
+	}
+	cpu, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(fields[0]), "%"), 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse CPU %q", fields[0])
+	}
+	used, limit, ok := strings.Cut(fields[1], "/")
+	if !ok {
+		return nil, errors.Errorf("unexpected memory usage %q", fields[1])
+	}
+	u := &ContainerUsage{CPUPercent: cpu}
+	if u.MemoryUsed, err = units.RAMInBytes(strings.TrimSpace(used)); err != nil {
+		return nil, errors.Wrapf(err, "parse memory %q", used)
+	}
+	if u.MemoryLimit, err = units.RAMInBytes(strings.TrimSpace(limit)); err != nil {
+		return nil, errors.Wrapf(err, "parse memory limit %q", limit)
+	}
+	if u.PIDs, err = strconv.Atoi(strings.TrimSpace(fields[2])); err != nil {
+		return nil, errors.Wrapf(err, "parse PIDs %q", fields[2])
+	}
+	return u, nil
+}
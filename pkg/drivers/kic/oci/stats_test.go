@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseContainerStats(t *testing.T) {
+	tests := []struct {
+		description string
+		stats       string
+		want        *ContainerUsage
+		wantErr     bool
+	}{
+		{"docker", "12.50%|1.5GiB / 4GiB|345\n", &ContainerUsage{CPUPercent: 12.5, MemoryUsed: 1536 << 20, MemoryLimit: 4 << 30, PIDs: 345}, false},
+		{"podman", "210.02%|512MB / 2GB|87", &ContainerUsage{CPUPercent: 210.02, MemoryUsed: 512 << 20, MemoryLimit: 2 << 30, PIDs: 87}, false},
+		{"missing fields", "12.50%|1.5GiB / 4GiB", nil, true},
+		{"invalid memory", "12.50%|--|345", nil, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := parseContainerStats(tc.stats)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, want error: %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("parseContainerStats mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/pkg/errors"
+)
+
+// UpdateContainerResources changes the CPUs and the memory in MB of a container, running or not, 0 keeping the current limit
+func UpdateContainerResources(ociBin, name string, cpus, memory int) error {
+	args := updateArgs(cpus, memory, HasMemoryCgroup(), hasMemorySwapCgroup())
+	if len(args) == 0 {
+		return nil
+	}
+	if _, err := runCmd(exec.Command(ociBin, append(append([]string{"update"}, args...), name)...)); err != nil {
+		return errors.Wrapf(err, "update %s", name)
+	}
+	return nil
+}
+
+// updateArgs returns the arguments to update the resources of a container, disabling swap as CreateContainerNode does
+func updateArgs(cpus, memory int, memcg, memcgSwap bool) []string {
+	var args []string
+	if cpus > 0 {
+		args = append(args, fmt.Sprintf("--cpus=%d", cpus))
+	}
+	if memory > 0 && memcg {
+		args = append(args, fmt.Sprintf("--memory=%dmb", memory))
+		if memcgSwap {
+			args = append(args, fmt.Sprintf("--memory-swap=%dmb", memory))
+		}
+	}
+	return args
+}
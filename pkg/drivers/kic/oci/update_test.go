@@ -0,0 +1,44 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUpdateArgs(t *testing.T) {
+	tests := []struct {
+		description      string
+		cpus, memory     int
+		memcg, memcgSwap bool
+		want             []string
+	}{
+		{"cpus and memory", 4, 4096, true, true, []string{"--cpus=4", "--memory=4096mb", "--memory-swap=4096mb"}},
+		{"no swap cgroup", 4, 4096, true, false, []string{"--cpus=4", "--memory=4096mb"}},
+		{"no memory cgroup", 4, 4096, false, false, []string{"--cpus=4"}},
+		{"unchanged", 0, 0, true, true, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, updateArgs(tc.cpus, tc.memory, tc.memcg, tc.memcgSwap)); diff != "" {
+				t.Errorf("updateArgs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
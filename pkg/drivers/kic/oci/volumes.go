@@ -147,6 +147,38 @@ func ExtractTarballToVolume(ociBin string, tarballPath, volumeName, imageName st
 	return nil
 }
 
+// CopyVolume copies the content of the volume of a node into the volume of another node, which is created if it does not exist,
+// by running a temporary container of the image of the nodes
+func CopyVolume(ociBin, src, dst, imageName string) error {
+	if err := createVolume(ociBin, dst, dst); err != nil {
+		return errors.Wrapf(err, "create volume %s", dst)
+	}
+	cmdArgs := []string{"run", "--rm", "--entrypoint", "/bin/cp"}
+	if ociBin == Podman && runtime.GOOS == "linux" {
+		cmdArgs = append(cmdArgs, "--security-opt", "label=disable")
+	}
+	cmdArgs = append(cmdArgs, "-v", fmt.Sprintf("%s:/from:ro", src), "-v", fmt.Sprintf("%s:/to", dst), imageName, "-a", "/from/.", "/to/")
+	if rr, err := runCmd(exec.Command(ociBin, cmdArgs...)); err != nil {
+		return errors.Wrapf(err, "copy volume %s to %s: %s", src, dst, rr.Output())
+	}
+	return nil
+}
+
+// RemoveVolumePaths removes paths of the /var directory of the nodes from the volume of a node,
+// by running a temporary container of the image of the nodes
+func RemoveVolumePaths(ociBin, volume, imageName string, paths ...string) error {
+	cmdArgs := []string{"run", "--rm", "--entrypoint", "/bin/rm"}
+	if ociBin == Podman && runtime.GOOS == "linux" {
+		cmdArgs = append(cmdArgs, "--security-opt", "label=disable")
+	}
+	cmdArgs = append(cmdArgs, "-v", fmt.Sprintf("%s:/var", volume), imageName, "-rf")
+	cmdArgs = append(cmdArgs, paths...)
+	if rr, err := runCmd(exec.Command(ociBin, cmdArgs...)); err != nil {
+		return errors.Wrapf(err, "remove %v from volume %s: %s", paths, volume, rr.Output())
+	}
+	return nil
+}
+
 // createVolume creates a volume to be attached to the container with correct labels and prefixes based on profile name
 // Caution ! if volume already exists does NOT return an error and will not apply the minikube labels on it.
 // TODO: this should be fixed as a part of https://github.com/kubernetes/minikube/issues/6530
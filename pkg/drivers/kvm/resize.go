@@ -0,0 +1,82 @@
+//go:build linux && amd64
+
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvm
+
+import (
+	"fmt"
+
+	"github.com/docker/machine/libmachine/log"
+	"github.com/pkg/errors"
+	"libvirt.org/go/libvirt"
+
+	"k8s.io/minikube/pkg/drivers/common"
+)
+
+// Resources returns the CPUs, memory and disk size the domain was defined with
+func (d *Driver) Resources() common.Resources {
+	return common.Resources{CPUs: d.CPU, Memory: d.Memory, DiskSize: d.DiskSize}
+}
+
+// Resize changes the vCPUs and memory of the stopped domain in its persistent definition, and grows its raw disk image
+func (d *Driver) Resize(r common.Resources) error {
+	if d.NUMANodeCount > 1 && (r.CPUs != d.CPU || r.Memory != d.Memory) {
+		return fmt.Errorf("the CPUs and memory of a domain with %d NUMA nodes cannot be changed", d.NUMANodeCount)
+	}
+	if r.DiskSize < d.DiskSize {
+		return fmt.Errorf("the disk cannot shrink from %dMB to %dMB", d.DiskSize, r.DiskSize)
+	}
+
+	dom, conn, err := d.getDomain()
+	if err != nil {
+		return errors.Wrap(err, "getting domain")
+	}
+	defer func() {
+		if err := closeDomain(dom, conn); err != nil {
+			log.Errorf("failed closing domain: %v", err)
+		}
+	}()
+
+	// the maximum is set first, as the current value cannot exceed it
+	if r.CPUs != d.CPU {
+		if err := dom.SetVcpusFlags(uint(r.CPUs), libvirt.DOMAIN_VCPU_CONFIG|libvirt.DOMAIN_VCPU_MAXIMUM); err != nil {
+			return errors.Wrap(err, "setting maximum vcpus")
+		}
+		if err := dom.SetVcpusFlags(uint(r.CPUs), libvirt.DOMAIN_VCPU_CONFIG); err != nil {
+			return errors.Wrap(err, "setting vcpus")
+		}
+		d.CPU = r.CPUs
+	}
+	// libvirt sets the memory in KiB
+	if r.Memory != d.Memory {
+		if err := dom.SetMemoryFlags(uint64(r.Memory)<<10, libvirt.DOMAIN_MEM_CONFIG|libvirt.DOMAIN_MEM_MAXIMUM); err != nil {
+			return errors.Wrap(err, "setting maximum memory")
+		}
+		if err := dom.SetMemoryFlags(uint64(r.Memory)<<10, libvirt.DOMAIN_MEM_CONFIG); err != nil {
+			return errors.Wrap(err, "setting memory")
+		}
+		d.Memory = r.Memory
+	}
+	if r.DiskSize != d.DiskSize {
+		if err := common.GrowRawDisk(d.DiskPath, r.DiskSize); err != nil {
+			return errors.Wrap(err, "growing disk")
+		}
+		d.DiskSize = r.DiskSize
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+//go:build linux && amd64
+
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvm
+
+import (
+	"time"
+
+	"github.com/docker/machine/libmachine/log"
+	"github.com/pkg/errors"
+	"libvirt.org/go/libvirt"
+
+	"k8s.io/minikube/pkg/drivers/common"
+)
+
+// Usage returns the CPU time of the domain over common.UsageInterval, and the memory used by its qemu process, read from libvirt
+func (d *Driver) Usage() (*common.Usage, error) {
+	dom, conn, err := d.getDomain()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting domain")
+	}
+	defer func() {
+		if err := closeDomain(dom, conn); err != nil {
+			log.Errorf("failed closing domain: %v", err)
+		}
+	}()
+
+	before, err := dom.GetInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting domain info")
+	}
+	start := time.Now()
+	time.Sleep(common.UsageInterval)
+	after, err := dom.GetInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting domain info")
+	}
+
+	// libvirt reports the memory in KiB, and the CPU time in nanoseconds
+	u := &common.Usage{
+		CPUPercent:  common.CPUPercent(time.Duration(after.CpuTime-before.CpuTime), time.Since(start)),
+		MemoryUsed:  int64(after.Memory) << 10,
+		MemoryLimit: int64(after.MaxMem) << 10,
+	}
+	stats, err := dom.MemoryStats(uint32(libvirt.DOMAIN_MEMORY_STAT_NR), 0)
+	if err != nil {
+		log.Debugf("unable to get memory stats of domain: %v", err)
+		return u, nil
+	}
+	for _, s := range stats {
+		if s.Tag == int32(libvirt.DOMAIN_MEMORY_STAT_RSS) {
+			u.MemoryUsed = int64(s.Val) << 10
+		}
+	}
+	return u, nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qemu
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/minikube/pkg/drivers/common"
+)
+
+// Resources returns the CPUs, memory and disk size the VM was created with
+func (d *Driver) Resources() common.Resources {
+	return common.Resources{CPUs: d.CPU, Memory: d.Memory, DiskSize: d.DiskSize}
+}
+
+// Resize changes the CPUs and memory the stopped VM is started with, and grows its qcow2 disk image
+func (d *Driver) Resize(r common.Resources) error {
+	if r.DiskSize < d.DiskSize {
+		return fmt.Errorf("the disk cannot shrink from %dMB to %dMB", d.DiskSize, r.DiskSize)
+	}
+	if r.DiskSize != d.DiskSize {
+		// the image was created as large as the disk size, after the boot2docker tar header
+		if stdout, stderr, err := cmdOutErr("qemu-img", "resize", d.diskPath(), fmt.Sprintf("+%dM", r.DiskSize-d.DiskSize)); err != nil {
+			return errors.Wrapf(err, "qemu-img resize: %s %s", stdout, stderr)
+		}
+		d.DiskSize = r.DiskSize
+	}
+	d.CPU = r.CPUs
+	d.Memory = r.Memory
+	return nil
+}
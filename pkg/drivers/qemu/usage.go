@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qemu
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/minikube/pkg/drivers/common"
+)
+
+// clockTicks is the USER_HZ of the CPU times in /proc, which is 100 on all the architectures supported by Linux
+const clockTicks = 100
+
+// Usage returns the CPU time of the qemu process over common.UsageInterval and its resident memory,
+// with the memory of the machine queried over QMP
+func (d *Driver) Usage() (*common.Usage, error) {
+	if runtime.GOOS == "windows" {
+		return nil, errors.New("the qemu process is not tracked on windows")
+	}
+	p, err := os.ReadFile(d.pidfilePath())
+	if err != nil {
+		return nil, errors.Wrap(err, "read pidfile")
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(p)))
+	if err != nil {
+		return nil, errors.Wrap(err, "parse pidfile")
+	}
+
+	before, _, err := processUsage(pid)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	time.Sleep(common.UsageInterval)
+	after, rss, err := processUsage(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &common.Usage{
+		CPUPercent:  common.CPUPercent(after-before, time.Since(start)),
+		MemoryUsed:  rss,
+		MemoryLimit: int64(d.Memory) << 20,
+	}
+	// { "return": { "base-memory": 4294967296 } }
+	if ret, err := d.RunQMPCommand("query-memory-size-summary"); err == nil {
+		if base, ok := ret["base-memory"].(float64); ok {
+			u.MemoryLimit = int64(base)
+		}
+	}
+	return u, nil
+}
+
+// processUsage returns the CPU time and the resident memory in bytes of a process
+func processUsage(pid int) (time.Duration, int64, error) {
+	if runtime.GOOS == "linux" {
+		stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "read process stat")
+		}
+		return parseProcStat(string(stat), os.Getpagesize())
+	}
+	out, err := exec.Command("ps", "-o", "time=,rss=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "ps")
+	}
+	return parsePS(string(out))
+}
+
+// parseProcStat parses the CPU time and the resident memory of a process from /proc/PID/stat
+// ref: https://man7.org/linux/man-pages/man5/proc_pid_stat.5.html
+func parseProcStat(stat string, pageSize int) (time.Duration, int64, error) {
+	// the command, in parentheses, may contain spaces
+	i := strings.LastIndex(stat, ")")
+	if i < 0 {
+		return 0, 0, errors.Errorf("unexpected process stat %q", stat)
+	}
+	// fields from the state, which is the third one
+	fields := strings.Fields(stat[i+1:])
+	if len(fields) < 22 {
+		return 0, 0, errors.Errorf("unexpected process stat %q", stat)
+	}
+	utime, err := strconv.ParseInt(fields[11], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "parse utime")
+	}
+	stime, err := strconv.ParseInt(fields[12], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "parse stime")
+	}
+	rss, err := strconv.ParseInt(fields[21], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "parse rss")
+	}
+	return time.Duration(utime+stime) * time.Second / clockTicks, rss * int64(pageSize), nil
+}
+
+// parsePS parses the CPU time, as [[dd-]hh:]mm:ss[.hh], and the resident memory in KiB of a process, as printed by ps
+func parsePS(out string) (time.Duration, int64, error) {
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 0, 0, errors.Errorf("unexpected ps output %q", out)
+	}
+	var cpu time.Duration
+	days, clock, ok := strings.Cut(fields[0], "-")
+	if ok {
+		d, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "parse CPU time %q", fields[0])
+		}
+		cpu = time.Duration(d) * 24 * time.Hour
+	} else {
+		clock = days
+	}
+	parts := strings.Split(clock, ":")
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "parse CPU time %q", fields[0])
+		}
+		unit := time.Second
+		for j := i; j < len(parts)-1; j++ {
+			unit *= 60
+		}
+		cpu += time.Duration(v * float64(unit))
+	}
+	rss, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "parse rss %q", fields[1])
+	}
+	return cpu, rss << 10, nil
+}
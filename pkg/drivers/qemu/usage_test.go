@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qemu
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseProcStat(t *testing.T) {
+	stat := "4242 (qemu-system-x86 64) S 1 4242 4242 0 -1 4194624 123 0 0 0 1500 250 0 0 20 0 5 0 100 4000000000 262144 18446744073709551615"
+	cpu, rss, err := parseProcStat(stat, 4096)
+	if err != nil {
+		t.Fatalf("parseProcStat returned error: %v", err)
+	}
+	if cpu != 17500*time.Millisecond {
+		t.Errorf("got CPU time %s, want 17.5s", cpu)
+	}
+	if rss != 1<<30 {
+		t.Errorf("got rss %d, want %d", rss, 1<<30)
+	}
+
+	if _, _, err := parseProcStat("4242 (qemu) S 1", 4096); err == nil {
+		t.Error("expected an error for a truncated stat")
+	}
+}
+
+func TestParsePS(t *testing.T) {
+	tests := []struct {
+		out     string
+		wantCPU time.Duration
+		wantRSS int64
+		wantErr bool
+	}{
+		{"  1:02.50 1048576\n", 62500 * time.Millisecond, 1 << 30, false},
+		{"01:00:03 2048", time.Hour + 3*time.Second, 2 << 20, false},
+		{"2-00:00:00 1024", 48 * time.Hour, 1 << 20, false},
+		{"1:02.50", 0, 0, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.out, func(t *testing.T) {
+			cpu, rss, err := parsePS(tc.out)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, want error: %t", err, tc.wantErr)
+			}
+			if cpu != tc.wantCPU || rss != tc.wantRSS {
+				t.Errorf("parsePS(%q) = %s, %d, want %s, %d", tc.out, cpu, rss, tc.wantCPU, tc.wantRSS)
+			}
+		})
+	}
+}
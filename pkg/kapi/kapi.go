@@ -35,6 +35,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	watchtools "k8s.io/client-go/tools/watch"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/proxy"
 	"k8s.io/minikube/pkg/minikube/vmpath"
 	kconst "k8s.io/minikube/third_party/kubeadm/app/constants"
@@ -50,6 +51,8 @@ var (
 // ClientConfig returns the client configuration for a kubectl context
 func ClientConfig(ctx string) (*rest.Config, error) {
 	loader := clientcmd.NewDefaultClientConfigLoadingRules()
+	// the context of a cluster started with --kubeconfig-mode=separate is in the kubeconfig of its profile
+	loader.Precedence = append(loader.Precedence, localpath.Kubeconfig(ctx))
 	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, &clientcmd.ConfigOverrides{CurrentContext: ctx})
 	c, err := cc.ClientConfig()
 	if err != nil {
@@ -215,3 +218,33 @@ func ScaleDeployment(kcontext, namespace, deploymentName string, replicas int) e
 
 	return nil
 }
+
+// RestartPodsWithPodNetwork deletes all controller-managed pods that are not on the host network, so they get recreated with a new pod network.
+// It returns the namespaced names of the deleted pods.
+func RestartPodsWithPodNetwork(c kubernetes.Interface) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ReasonableMutateTime)
+	defer cancel()
+
+	pods, err := c.CoreV1().Pods(meta.NamespaceAll).List(ctx, meta.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %v", err)
+	}
+
+	deleted := []string{}
+	for _, pod := range pods.Items {
+		if pod.Spec.HostNetwork || pod.DeletionTimestamp != nil {
+			continue
+		}
+		name := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+		if len(pod.OwnerReferences) == 0 {
+			klog.Warningf("pod %q is not managed by a controller and will keep its old network until it is recreated", name)
+			continue
+		}
+		if err := c.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, meta.DeleteOptions{}); err != nil && !apierr.IsNotFound(err) {
+			return deleted, fmt.Errorf("deleting pod %q: %v", name, err)
+		}
+		deleted = append(deleted, name)
+	}
+	klog.Infof("restarted %d pod(s) with pod network: %v", len(deleted), deleted)
+	return deleted, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kapi
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRestartPodsWithPodNetwork(t *testing.T) {
+	owned := []meta.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "owner"}}
+	now := meta.Now()
+	pod := func(namespace, name string, owners []meta.OwnerReference, hostNetwork bool, deletion *meta.Time) *core.Pod {
+		return &core.Pod{
+			ObjectMeta: meta.ObjectMeta{Namespace: namespace, Name: name, OwnerReferences: owners, DeletionTimestamp: deletion},
+			Spec:       core.PodSpec{HostNetwork: hostNetwork},
+		}
+	}
+	client := fake.NewSimpleClientset(
+		pod("kube-system", "coredns-1", owned, false, nil),
+		pod("default", "web-1", owned, false, nil),
+		pod("kube-system", "kube-proxy-1", owned, true, nil),
+		pod("kube-system", "etcd-minikube", nil, true, nil),
+		pod("default", "standalone", nil, false, nil),
+		pod("default", "terminating", owned, false, &now),
+	)
+
+	deleted, err := RestartPodsWithPodNetwork(client)
+	if err != nil {
+		t.Fatalf("RestartPodsWithPodNetwork: %v", err)
+	}
+	sort.Strings(deleted)
+	if diff := cmp.Diff([]string{"default/web-1", "kube-system/coredns-1"}, deleted); diff != "" {
+		t.Errorf("deleted pods mismatch (-want +got):\n%s", diff)
+	}
+
+	pods, err := client.CoreV1().Pods(meta.NamespaceAll).List(context.Background(), meta.ListOptions{})
+	if err != nil {
+		t.Fatalf("listing pods: %v", err)
+	}
+	var left []string
+	for _, p := range pods.Items {
+		left = append(left, p.Namespace+"/"+p.Name)
+	}
+	sort.Strings(left)
+	if diff := cmp.Diff([]string{"default/standalone", "default/terminating", "kube-system/etcd-minikube", "kube-system/kube-proxy-1"}, left); diff != "" {
+		t.Errorf("remaining pods mismatch (-want +got):\n%s", diff)
+	}
+}
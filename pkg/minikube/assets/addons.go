@@ -46,6 +46,9 @@ type Addon struct {
 
 	// Registries currently only shows the default registry of images
 	Registries map[string]string
+
+	// Chart is an optional vendored Helm chart rendered and applied alongside Assets
+	Chart *HelmChart
 }
 
 // NetworkInfo contains control plane node IP address used for add on template
@@ -68,6 +71,13 @@ func NewAddon(assets []*BinAsset, enabled bool, addonName, maintainer, verifiedM
 	}
 }
 
+// NewChartAddon creates a new Addon backed by a vendored Helm chart
+func NewChartAddon(chart *HelmChart, assets []*BinAsset, enabled bool, addonName, maintainer, verifiedMaintainer, docs string, images, registries map[string]string) *Addon {
+	a := NewAddon(assets, enabled, addonName, maintainer, verifiedMaintainer, docs, images, registries)
+	a.Chart = chart
+	return a
+}
+
 // Name gets the addon name
 func (a *Addon) Name() string {
 	return a.addonName
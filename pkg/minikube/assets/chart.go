@@ -0,0 +1,426 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// HelmChart is a Helm chart vendored into minikube that is rendered locally into a single manifest.
+// Only self-contained charts are supported: they are rendered with the Go templates and the sprig
+// functions Helm provides, without pulling in the Helm library.
+type HelmChart struct {
+	// FS holds the chart sources, usually an embed.FS from deploy/addons
+	FS fs.FS
+	// Dir is the chart directory within FS (the one containing Chart.yaml)
+	Dir string
+	// ValuesTemplate is an optional path within FS to a values file that is evaluated
+	// with the addon template data before being passed to the chart
+	ValuesTemplate string
+	// ReleaseName is the name of the release the chart is rendered as
+	ReleaseName string
+	// Namespace is the release namespace, it is created by a manifest of its own so that it is kept on disable
+	Namespace string
+	// TargetDir and TargetName are where the rendered manifest is stored on the node
+	TargetDir  string
+	TargetName string
+}
+
+// chartMetadata is the part of Chart.yaml exposed to the templates as .Chart
+type chartMetadata struct {
+	APIVersion   string        `json:"apiVersion"`
+	Name         string        `json:"name"`
+	Version      string        `json:"version"`
+	AppVersion   string        `json:"appVersion"`
+	Dependencies []interface{} `json:"dependencies"`
+}
+
+// loadedChart is a chart read from FS
+type loadedChart struct {
+	Metadata chartMetadata
+	// Values are the chart defaults from values.yaml
+	Values map[string]interface{}
+	// Templates maps the template names, as Helm names them (<chart>/templates/<file>), to their contents
+	Templates map[string]string
+	// CRDs maps the files of the crds directory to their contents, they are not templated
+	CRDs map[string]string
+}
+
+// NewHelmChart creates a new HelmChart rendered to targetDir/<releaseName>-chart.yaml
+func NewHelmChart(fsys fs.FS, dir, valuesTemplate, releaseName, namespace, targetDir string) *HelmChart {
+	return &HelmChart{
+		FS:             fsys,
+		Dir:            dir,
+		ValuesTemplate: valuesTemplate,
+		ReleaseName:    releaseName,
+		Namespace:      namespace,
+		TargetDir:      targetDir,
+		TargetName:     releaseName + "-chart.yaml",
+	}
+}
+
+// GetTargetPath returns the path of the rendered manifest on the node
+func (h *HelmChart) GetTargetPath() string {
+	return path.Join(h.TargetDir, h.TargetName)
+}
+
+// load reads the chart from FS
+func (h *HelmChart) load() (*loadedChart, error) {
+	files := map[string][]byte{}
+	err := fs.WalkDir(h.FS, h.Dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := fs.ReadFile(h.FS, p)
+		if err != nil {
+			return err
+		}
+		files[strings.TrimPrefix(p, h.Dir+"/")] = data
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading chart %s", h.Dir)
+	}
+
+	c := &loadedChart{Values: map[string]interface{}{}, Templates: map[string]string{}, CRDs: map[string]string{}}
+	meta, ok := files["Chart.yaml"]
+	if !ok {
+		return nil, fmt.Errorf("chart %s has no Chart.yaml", h.Dir)
+	}
+	if err := yaml.Unmarshal(meta, &c.Metadata); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s/Chart.yaml", h.Dir)
+	}
+	if c.Metadata.Name == "" {
+		return nil, fmt.Errorf("chart %s has no name", h.Dir)
+	}
+	if len(c.Metadata.Dependencies) != 0 {
+		return nil, fmt.Errorf("chart %s has dependencies, which are not supported", h.Dir)
+	}
+	if values, ok := files["values.yaml"]; ok {
+		if err := yaml.Unmarshal(values, &c.Values); err != nil {
+			return nil, errors.Wrapf(err, "parsing %s/values.yaml", h.Dir)
+		}
+	}
+	for name, data := range files {
+		switch {
+		case strings.HasPrefix(name, "charts/"):
+			return nil, fmt.Errorf("chart %s has subcharts, which are not supported", h.Dir)
+		case strings.HasPrefix(name, "templates/"):
+			c.Templates[path.Join(c.Metadata.Name, name)] = string(data)
+		case strings.HasPrefix(name, "crds/"):
+			c.CRDs[name] = string(data)
+		}
+	}
+	return c, nil
+}
+
+// values evaluates the values template, if any, with the addon template data
+func (h *HelmChart) values(data interface{}) (map[string]interface{}, error) {
+	vals := map[string]interface{}{}
+	if h.ValuesTemplate == "" || data == nil {
+		return vals, nil
+	}
+	contents, err := fs.ReadFile(h.FS, h.ValuesTemplate)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", h.ValuesTemplate)
+	}
+	tpl, err := template.New(h.ValuesTemplate).Funcs(template.FuncMap{"default": defaultValue}).Parse(string(contents))
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", h.ValuesTemplate)
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return nil, errors.Wrapf(err, "evaluating %s", h.ValuesTemplate)
+	}
+	if err := yaml.Unmarshal(buf.Bytes(), &vals); err != nil {
+		return nil, errors.Wrapf(err, "parsing evaluated %s", h.ValuesTemplate)
+	}
+	return vals, nil
+}
+
+// mergeValues merges the values of src over the ones of dst, like Helm does with user supplied values:
+// maps are merged recursively and a null value removes the default
+func mergeValues(dst, src map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(dst))
+	for k, v := range dst {
+		merged[k] = v
+	}
+	for k, v := range src {
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := merged[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			merged[k] = mergeValues(dstMap, srcMap)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// kubeVersion is exposed to the templates as .Capabilities.KubeVersion
+type kubeVersion struct {
+	Version    string
+	Major      string
+	Minor      string
+	GitVersion string
+}
+
+// parseKubeVersion parses the Kubernetes version of the cluster for .Capabilities.KubeVersion
+func parseKubeVersion(version string) (kubeVersion, error) {
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return kubeVersion{}, err
+	}
+	kv := "v" + v.String()
+	return kubeVersion{Version: kv, Major: fmt.Sprint(v.Major), Minor: fmt.Sprint(v.Minor), GitVersion: kv}, nil
+}
+
+// funcMap returns the functions available to chart templates: the sprig functions, without the ones
+// reading the environment, and the ones Helm adds. include and tpl execute templates of t.
+func funcMap(t *template.Template) template.FuncMap {
+	f := sprig.TxtFuncMap()
+	delete(f, "env")
+	delete(f, "expandenv")
+
+	f["toYaml"] = func(v interface{}) string {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSuffix(string(data), "\n")
+	}
+	f["fromYaml"] = func(s string) map[string]interface{} {
+		m := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(s), &m); err != nil {
+			m["Error"] = err.Error()
+		}
+		return m
+	}
+	f["required"] = func(msg string, v interface{}) (interface{}, error) {
+		if v == nil {
+			return nil, errors.New(msg)
+		}
+		if s, ok := v.(string); ok && s == "" {
+			return nil, errors.New(msg)
+		}
+		return v, nil
+	}
+	f["fail"] = func(msg string) (string, error) {
+		return "", errors.New(msg)
+	}
+	// the chart is rendered offline, so there is never anything to look up
+	f["lookup"] = func(string, string, string, string) (map[string]interface{}, error) {
+		return map[string]interface{}{}, nil
+	}
+	f["include"] = func(name string, data interface{}) (string, error) {
+		var buf strings.Builder
+		if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	f["tpl"] = func(text string, data interface{}) (string, error) {
+		clone, err := t.Clone()
+		if err != nil {
+			return "", err
+		}
+		tpl, err := clone.New("tpl").Parse(text)
+		if err != nil {
+			return "", err
+		}
+		var buf strings.Builder
+		if err := tpl.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return strings.ReplaceAll(buf.String(), "<no value>", ""), nil
+	}
+	return f
+}
+
+// render renders the chart into a multi-document manifest
+func (h *HelmChart) render(kubernetesVersion string, data interface{}) ([]byte, error) {
+	c, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+	vals, err := h.values(data)
+	if err != nil {
+		return nil, err
+	}
+
+	kv := kubeVersion{}
+	if kubernetesVersion != "" {
+		kv, err = parseKubeVersion(kubernetesVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing Kubernetes version %s", kubernetesVersion)
+		}
+	}
+	top := map[string]interface{}{
+		"Values": mergeValues(c.Values, vals),
+		"Release": map[string]interface{}{
+			"Name":      h.ReleaseName,
+			"Namespace": h.Namespace,
+			"Revision":  1,
+			"IsInstall": true,
+			"IsUpgrade": false,
+			"Service":   "Helm",
+		},
+		"Chart": map[string]interface{}{
+			"Name":       c.Metadata.Name,
+			"Version":    c.Metadata.Version,
+			"AppVersion": c.Metadata.AppVersion,
+			"APIVersion": c.Metadata.APIVersion,
+		},
+		"Capabilities": map[string]interface{}{"KubeVersion": kv},
+	}
+
+	// as Helm does, missing values render as empty strings
+	t := template.New("chart").Option("missingkey=zero")
+	t.Funcs(funcMap(t))
+	names := make([]string, 0, len(c.Templates))
+	for name, contents := range c.Templates {
+		if _, err := t.New(name).Parse(contents); err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	crds := make([]string, 0, len(c.CRDs))
+	for name := range c.CRDs {
+		crds = append(crds, name)
+	}
+	sort.Strings(crds)
+	for _, name := range crds {
+		fmt.Fprintf(&buf, "---\n# Source: %s\n%s\n", name, strings.TrimSpace(c.CRDs[name]))
+	}
+	for _, name := range names {
+		base := path.Base(name)
+		// partials and notes are not Kubernetes objects
+		if strings.HasPrefix(base, "_") || strings.HasSuffix(name, "NOTES.txt") {
+			continue
+		}
+		vals := map[string]interface{}{"Template": map[string]interface{}{"Name": name, "BasePath": path.Join(c.Metadata.Name, "templates")}}
+		for k, v := range top {
+			vals[k] = v
+		}
+		var out strings.Builder
+		if err := t.ExecuteTemplate(&out, name, vals); err != nil {
+			return nil, errors.Wrapf(err, "rendering %s", name)
+		}
+		manifest := strings.TrimSpace(strings.ReplaceAll(out.String(), "<no value>", ""))
+		if manifest == "" {
+			continue
+		}
+		fmt.Fprintf(&buf, "---\n# Source: %s\n%s\n", name, manifest)
+	}
+	klog.V(1).Infof("Rendered chart %s as release %q with %d bytes", c.Metadata.Name, h.ReleaseName, buf.Len())
+	return buf.Bytes(), nil
+}
+
+// Render renders the chart with values evaluated from the addon template data
+func (h *HelmChart) Render(kubernetesVersion string, data interface{}) (*MemoryAsset, error) {
+	manifest, err := h.render(kubernetesVersion, data)
+	if err != nil {
+		return nil, err
+	}
+	return NewMemoryAsset(manifest, h.TargetDir, h.TargetName, "0640"), nil
+}
+
+// NamespaceTargetPath returns the path on the node of the manifest creating the release namespace
+func (h *HelmChart) NamespaceTargetPath() string {
+	return path.Join(h.TargetDir, h.ReleaseName+"-namespace.yaml")
+}
+
+// NamespaceAsset returns the manifest creating the release namespace, or nil if the chart is released in the default namespace.
+// It is only applied on enable, as the namespace may hold objects which are not part of the chart.
+func (h *HelmChart) NamespaceAsset() *MemoryAsset {
+	if h.Namespace == "" || h.Namespace == "default" {
+		return nil
+	}
+	manifest := fmt.Sprintf("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: %s\n", h.Namespace)
+	return NewMemoryAsset([]byte(manifest), h.TargetDir, path.Base(h.NamespaceTargetPath()), "0640")
+}
+
+// Images returns the container images referenced by the chart when rendered with values evaluated from the addon template data,
+// so that they include the images overridden with --images and --registries
+func (h *HelmChart) Images(kubernetesVersion string, data interface{}) ([]string, error) {
+	manifest, err := h.render(kubernetesVersion, data)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, doc := range strings.Split(string(manifest), "\n---\n") {
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return nil, errors.Wrap(err, "parsing rendered manifest")
+		}
+		collectImages(obj, seen)
+	}
+	images := make([]string, 0, len(seen))
+	for image := range seen {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+// collectImages walks a decoded object and records the image of every container it finds
+func collectImages(v interface{}, seen map[string]bool) {
+	switch o := v.(type) {
+	case map[string]interface{}:
+		for k, child := range o {
+			if k == "containers" || k == "initContainers" || k == "ephemeralContainers" {
+				if containers, ok := child.([]interface{}); ok {
+					for _, c := range containers {
+						if cm, ok := c.(map[string]interface{}); ok {
+							if image, ok := cm["image"].(string); ok && image != "" {
+								seen[image] = true
+							}
+						}
+					}
+				}
+				continue
+			}
+			collectImages(child, seen)
+		}
+	case []interface{}:
+		for _, child := range o {
+			collectImages(child, seen)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"io"
+	"path"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testChart() *HelmChart {
+	fsys := fstest.MapFS{
+		"demo/chart/Chart.yaml":             {Data: []byte("apiVersion: v2\nname: demo\nversion: 0.1.0\n")},
+		"demo/chart/values.yaml":            {Data: []byte("image: docker.io/demo/server:1.0\nreplicas: 1\n")},
+		"demo/chart/templates/_helpers.tpl": {Data: []byte(`{{- define "demo.name" -}}{{ .Release.Name }}-server{{- end -}}`)},
+		"demo/chart/templates/NOTES.txt":    {Data: []byte("thanks for installing {{ .Release.Name }}")},
+		"demo/chart/templates/deployment.yaml": {Data: []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "demo.name" . }}
+  namespace: {{ .Release.Namespace }}
+spec:
+  replicas: {{ .Values.replicas }}
+  template:
+    spec:
+      containers:
+      - name: server
+        image: {{ .Values.image }}
+`)},
+		"demo/chart/templates/disabled.yaml": {Data: []byte(`{{- if .Values.extra }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: extra
+{{- end }}
+`)},
+		"demo/values.yaml.tmpl": {Data: []byte("image: {{.Image}}\nreplicas: 2\n")},
+	}
+	return NewHelmChart(fsys, "demo/chart", "demo/values.yaml.tmpl", "demo", "demo-system", "/etc/kubernetes/addons")
+}
+
+func TestHelmChartRender(t *testing.T) {
+	c := testChart()
+	if c.GetTargetPath() != "/etc/kubernetes/addons/demo-chart.yaml" {
+		t.Errorf("unexpected target path %s", c.GetTargetPath())
+	}
+
+	f, err := c.Render("v1.33.0", struct{ Image string }{Image: "registry.example.com/demo/server:2.0"})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("reading rendered chart: %v", err)
+	}
+	manifest := string(data)
+
+	for _, want := range []string{
+		"name: demo-server",
+		"namespace: demo-system",
+		"replicas: 2",
+		"image: registry.example.com/demo/server:2.0",
+	} {
+		if !strings.Contains(manifest, want) {
+			t.Errorf("rendered manifest is missing %q:\n%s", want, manifest)
+		}
+	}
+	for _, unwanted := range []string{"thanks for installing", "kind: ConfigMap", "kind: Namespace"} {
+		if strings.Contains(manifest, unwanted) {
+			t.Errorf("rendered manifest should not contain %q:\n%s", unwanted, manifest)
+		}
+	}
+}
+
+func TestHelmChartNamespaceAsset(t *testing.T) {
+	c := testChart()
+	f := c.NamespaceAsset()
+	if f == nil {
+		t.Fatal("NamespaceAsset() = nil, want a manifest for demo-system")
+	}
+	if got := path.Join(f.GetTargetDir(), f.GetTargetName()); got != c.NamespaceTargetPath() {
+		t.Errorf("got namespace manifest at %s, want %s", got, c.NamespaceTargetPath())
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("reading namespace manifest: %v", err)
+	}
+	if !strings.Contains(string(data), "kind: Namespace\nmetadata:\n  name: demo-system") {
+		t.Errorf("unexpected namespace manifest:\n%s", data)
+	}
+
+	c.Namespace = "default"
+	if f := c.NamespaceAsset(); f != nil {
+		t.Errorf("NamespaceAsset() = %v, want nil for the default namespace", f)
+	}
+}
+
+func TestHelmChartImages(t *testing.T) {
+	tests := []struct {
+		description string
+		data        interface{}
+		want        []string
+	}{
+		{"chart defaults", nil, []string{"docker.io/demo/server:1.0"}},
+		{"custom image", struct{ Image string }{Image: "registry.example.com/demo/server:2.0"}, []string{"registry.example.com/demo/server:2.0"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			images, err := testChart().Images("v1.33.0", tc.data)
+			if err != nil {
+				t.Fatalf("Images: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, images); diff != "" {
+				t.Errorf("Images() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHelmChartTemplateFunctions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"fn/Chart.yaml":  {Data: []byte("apiVersion: v2\nname: fn\nversion: 0.2.0\n")},
+		"fn/values.yaml": {Data: []byte("labels:\n  app: fn\n  tier: backend\nresources:\n  limits:\n    cpu: 100m\n")},
+		"fn/templates/configmap.yaml": {Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name | trunc 63 | trimSuffix "-" }}
+  labels:
+    {{- toYaml .Values.labels | nindent 4 }}
+data:
+  chart: {{ printf "%s-%s" .Chart.Name .Chart.Version | quote }}
+  kube: {{ .Capabilities.KubeVersion.Minor | quote }}
+  missing: "{{ .Values.missing }}"
+  limits: {{ .Values.resources.limits | toJson | quote }}
+  env: {{ tpl "{{ .Release.Namespace }}" . | quote }}
+`)},
+	}
+	c := NewHelmChart(fsys, "fn", "", "fn", "fn-system", "/etc/kubernetes/addons")
+	manifest, err := c.render("v1.33.0", nil)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{
+		"name: fn\n",
+		"    app: fn\n    tier: backend\n",
+		`chart: "fn-0.2.0"`,
+		`kube: "33"`,
+		`missing: ""`,
+		`limits: "{\"cpu\":\"100m\"}"`,
+		`env: "fn-system"`,
+	} {
+		if !strings.Contains(string(manifest), want) {
+			t.Errorf("rendered manifest is missing %q:\n%s", want, manifest)
+		}
+	}
+
+	fsys["fn/charts/sub/Chart.yaml"] = &fstest.MapFile{Data: []byte("apiVersion: v2\nname: sub\nversion: 0.1.0\n")}
+	if _, err := c.render("v1.33.0", nil); err == nil {
+		t.Error("expected an error rendering a chart with subcharts")
+	}
+}
+
+func TestMergeValues(t *testing.T) {
+	defaults := map[string]interface{}{
+		"image":    "docker.io/demo/server:1.0",
+		"replicas": 1,
+		"service":  map[string]interface{}{"type": "ClusterIP", "port": 80},
+	}
+	overrides := map[string]interface{}{
+		"replicas": 2,
+		"image":    nil,
+		"service":  map[string]interface{}{"type": "NodePort"},
+	}
+	want := map[string]interface{}{
+		"replicas": 2,
+		"service":  map[string]interface{}{"type": "NodePort", "port": 80},
+	}
+	if diff := cmp.Diff(want, mergeValues(defaults, overrides)); diff != "" {
+		t.Errorf("mergeValues() mismatch (-want +got):\n%s", diff)
+	}
+	if defaults["image"] == nil {
+		t.Error("mergeValues() modified the defaults")
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+var (
+	// AuditPolicyPath is where the audit policy of the API server is copied to on the control-plane nodes
+	AuditPolicyPath = path.Join(vmpath.GuestAPIServerAuditDir, "policy.yaml")
+	// AuditLogPath is where the API server writes its audit log on the control-plane nodes
+	AuditLogPath = path.Join(vmpath.GuestAPIServerAuditLogDir, "audit.log")
+)
+
+const (
+	// auditLogMaxSize is the size in megabytes of the audit log before it is rotated
+	auditLogMaxSize = "50"
+	// auditLogMaxBackup is the number of rotated audit logs kept on the node
+	auditLogMaxBackup = "3"
+)
+
+// the health checks are polled every few seconds, and would flood the audit log
+const auditPolicyHealthRule = `  - level: None
+    nonResourceURLs: ["/healthz*", "/livez*", "/readyz*", "/version"]
+`
+
+// AuditPolicyPresets are the audit policies which can be given by name to --apiserver-audit-policy
+var AuditPolicyPresets = map[string]string{
+	// minimal logs the metadata of the changes made by users, without the reads and the heartbeats of the nodes and the controllers
+	"minimal": `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages: ["RequestReceived"]
+rules:
+` + auditPolicyHealthRule + `  - level: None
+    verbs: ["get", "list", "watch"]
+  - level: None
+    resources:
+      - group: "coordination.k8s.io"
+        resources: ["leases"]
+      - group: ""
+        resources: ["events"]
+  - level: Metadata
+`,
+	// metadata logs the metadata of all the requests
+	"metadata": `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages: ["RequestReceived"]
+rules:
+` + auditPolicyHealthRule + `  - level: Metadata
+`,
+	// request logs the body of all the requests, except for the resources which may hold secrets
+	"request": `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages: ["RequestReceived"]
+rules:
+` + auditPolicyHealthRule + `  - level: Metadata
+    resources:
+      - group: ""
+        resources: ["secrets", "configmaps", "serviceaccounts/token"]
+      - group: "authentication.k8s.io"
+        resources: ["tokenreviews"]
+  - level: Request
+`,
+}
+
+// AuditPolicyPresetNames returns the sorted names of the audit policy presets
+func AuditPolicyPresetNames() []string {
+	names := []string{}
+	for name := range AuditPolicyPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AuditPolicy returns the audit policy given to --apiserver-audit-policy, the name of a preset or the path of a policy file
+func AuditPolicy(policy string) ([]byte, error) {
+	if p, ok := AuditPolicyPresets[policy]; ok {
+		return []byte(p), nil
+	}
+
+	data, err := os.ReadFile(policy)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading audit policy (not one of the presets %s)", strings.Join(AuditPolicyPresetNames(), ", "))
+	}
+	var meta struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, errors.Wrapf(err, "parsing audit policy %s", policy)
+	}
+	if meta.Kind != "Policy" || !strings.HasPrefix(meta.APIVersion, "audit.k8s.io/") {
+		return nil, errors.Errorf("%s is not an audit policy: found kind %q of %q, want Policy of audit.k8s.io/v1", policy, meta.Kind, meta.APIVersion)
+	}
+	return data, nil
+}
+
+// auditExtraOptions returns the flags of the API server writing the audit log, which the --extra-config flags override
+func auditExtraOptions(k8s config.KubernetesConfig) config.ExtraOptionSlice {
+	if k8s.APIServerAuditPolicy == "" {
+		return nil
+	}
+	return config.ExtraOptionSlice{
+		{Component: Apiserver, Key: "audit-policy-file", Value: AuditPolicyPath},
+		{Component: Apiserver, Key: "audit-log-path", Value: AuditLogPath},
+		// the API server rotates the log itself, so that it doesn't fill the disk of the node
+		{Component: Apiserver, Key: "audit-log-maxsize", Value: auditLogMaxSize},
+		{Component: Apiserver, Key: "audit-log-maxbackup", Value: auditLogMaxBackup},
+	}
+}
+
+// auditVolumes returns the mounts of the audit policy and log into the static pod of the API server
+func auditVolumes(k8s config.KubernetesConfig) []hostPathMount {
+	if k8s.APIServerAuditPolicy == "" {
+		return nil
+	}
+	return []hostPathMount{
+		{Name: "audit-policy", HostPath: AuditPolicyPath, MountPath: AuditPolicyPath, ReadOnly: true, PathType: "File"},
+		{Name: "audit-log", HostPath: vmpath.GuestAPIServerAuditLogDir, MountPath: vmpath.GuestAPIServerAuditLogDir, PathType: "DirectoryOrCreate"},
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAuditPolicy(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	policy := "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n  - level: RequestResponse\n"
+
+	tests := []struct {
+		name    string
+		policy  string
+		want    string
+		wantErr bool
+	}{
+		{"preset", "metadata", AuditPolicyPresets["metadata"], false},
+		{"file", write("policy.yaml", policy), policy, false},
+		{"not a policy", write("pod.yaml", "apiVersion: v1\nkind: Pod\n"), "", true},
+		{"not yaml", write("policy.txt", "level: [RequestResponse"), "", true},
+		{"unknown", "verbose", "", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := AuditPolicy(tc.policy)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("AuditPolicy(%q) error = %v, want error: %v", tc.policy, err, tc.wantErr)
+			}
+			if string(got) != tc.want {
+				t.Errorf("AuditPolicy(%q) = %q, want %q", tc.policy, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuditPolicyPresets(t *testing.T) {
+	for _, name := range AuditPolicyPresetNames() {
+		p := filepath.Join(t.TempDir(), name+".yaml")
+		if err := os.WriteFile(p, []byte(AuditPolicyPresets[name]), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := AuditPolicy(p); err != nil {
+			t.Errorf("preset %s is not a valid audit policy: %v", name, err)
+		}
+	}
+}
@@ -18,9 +18,14 @@ limitations under the License.
 package bsutil
 
 import (
+	"bytes"
+	"debug/buildinfo"
 	"fmt"
+	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 
@@ -37,10 +42,21 @@ import (
 	"k8s.io/minikube/pkg/minikube/vmpath"
 )
 
+// kubeVersionLdflag is the linker flag which sets the version of the Kubernetes binaries
+var kubeVersionLdflag = regexp.MustCompile(`k8s\.io/component-base/version\.gitVersion=(v[^'"\s]+)`)
+
+// kubeVersion matches the version printed by the Kubernetes binaries
+var kubeVersion = regexp.MustCompile(`v\d+\.\d+\.\d+[^\s"]*`)
+
 // TransferBinaries transfers all required Kubernetes binaries
 func TransferBinaries(cfg config.KubernetesConfig, c command.Runner, sm sysinit.Manager, binariesURL string) error {
+	local, err := LocalBinaries(cfg.KubernetesBinariesDir)
+	if err != nil {
+		return err
+	}
+	// the binaries built locally are copied on every start, as they may have been rebuilt
 	ok, err := binariesExist(cfg, c)
-	if err == nil && ok {
+	if err == nil && ok && len(local) == 0 {
 		klog.Info("Found k8s binaries, skipping transfer")
 		return nil
 	}
@@ -56,9 +72,13 @@ func TransferBinaries(cfg config.KubernetesConfig, c command.Runner, sm sysinit.
 	for _, name := range constants.KubernetesReleaseBinaries {
 		name := name
 		g.Go(func() error {
-			src, err := download.Binary(name, cfg.KubernetesVersion, "linux", runtime.GOARCH, binariesURL)
-			if err != nil {
-				return errors.Wrapf(err, "downloading %s", name)
+			src, ok := local[name]
+			if !ok {
+				var err error
+				src, err = download.Binary(name, cfg.KubernetesVersion, "linux", runtime.GOARCH, binariesURL)
+				if err != nil {
+					return errors.Wrapf(err, "downloading %s", name)
+				}
 			}
 
 			if name == "kubelet" && sm.Active(name) {
@@ -77,6 +97,73 @@ func TransferBinaries(cfg config.KubernetesConfig, c command.Runner, sm sysinit.
 	return g.Wait()
 }
 
+// LocalBinaries returns the paths of the Kubernetes binaries in dir, the binaries built locally for --kubernetes-binaries-dir.
+// All of them are required, as the released binaries of their version would not exist.
+func LocalBinaries(dir string) (map[string]string, error) {
+	local := map[string]string{}
+	if dir == "" {
+		return local, nil
+	}
+	for _, name := range constants.KubernetesReleaseBinaries {
+		p := filepath.Join(dir, name)
+		fi, err := os.Stat(p)
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("%s not found in %s, which needs all of %s", name, dir, strings.Join(constants.KubernetesReleaseBinaries, ", "))
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "local %s", name)
+		}
+		if fi.IsDir() {
+			return nil, errors.Errorf("local %s is a directory", p)
+		}
+		local[name] = p
+	}
+	return local, nil
+}
+
+// LocalBinariesVersion returns the Kubernetes version of the binaries built locally in dir, read from kubeadm
+func LocalBinariesVersion(dir string) (string, error) {
+	local, err := LocalBinaries(dir)
+	if err != nil {
+		return "", err
+	}
+	return binaryVersion(local["kubeadm"], "version", "-o", "short")
+}
+
+// binaryVersion returns the version of a Kubernetes binary, from the linker flags recorded in the binary,
+// or else from its output when it can run on this host
+func binaryVersion(p string, args ...string) (string, error) {
+	if info, err := buildinfo.ReadFile(p); err == nil {
+		settings := map[string]string{}
+		for _, s := range info.Settings {
+			settings[s.Key] = s.Value
+		}
+		// the nodes run linux on the architecture of the host, like the binaries which are downloaded
+		if settings["GOOS"] != "" && (settings["GOOS"] != "linux" || settings["GOARCH"] != runtime.GOARCH) {
+			return "", errors.Errorf("%s is built for %s/%s, the nodes need it built for linux/%s", p, settings["GOOS"], settings["GOARCH"], runtime.GOARCH)
+		}
+		if m := kubeVersionLdflag.FindStringSubmatch(settings["-ldflags"]); m != nil {
+			return m[1], nil
+		}
+	}
+
+	// the linker flags are not recorded by the builds with -trimpath
+	if runtime.GOOS != "linux" {
+		return "", errors.Errorf("unable to read the version of %s, which can't run on %s", p, runtime.GOOS)
+	}
+	var stdout bytes.Buffer
+	cmd := exec.Command(p, args...)
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "%s %s", p, strings.Join(args, " "))
+	}
+	v := kubeVersion.FindString(stdout.String())
+	if v == "" {
+		return "", errors.Errorf("no version in the output of %s %s: %q", p, strings.Join(args, " "), stdout.String())
+	}
+	return v, nil
+}
+
 // binariesExist returns true if the binaries already exist
 func binariesExist(cfg config.KubernetesConfig, c command.Runner) (bool, error) {
 	dir := binRoot(cfg.KubernetesVersion)
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestKubeVersionLdflag(t *testing.T) {
+	ldflags := `-s -w -X 'k8s.io/component-base/version.buildDate=2025-10-01T10:00:00Z' -X 'k8s.io/component-base/version.gitVersion=v1.35.0-alpha.1.42+0123456789abcd' -X 'k8s.io/component-base/version.gitTreeState=clean'`
+	m := kubeVersionLdflag.FindStringSubmatch(ldflags)
+	if m == nil || m[1] != "v1.35.0-alpha.1.42+0123456789abcd" {
+		t.Errorf("kubeVersionLdflag of %q = %v, want v1.35.0-alpha.1.42+0123456789abcd", ldflags, m)
+	}
+}
+
+func TestLocalBinariesVersion(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the version is read from the output of the binaries on linux")
+	}
+	tests := []struct {
+		name    string
+		scripts map[string]string
+		want    string
+		wantErr bool
+	}{
+		{"kubeadm", map[string]string{"kubeadm": `echo v1.35.0-alpha.1.42+0123456789abcd`}, "v1.35.0-alpha.1.42+0123456789abcd", false},
+		{"dirty", map[string]string{"kubeadm": `echo v1.34.1-dirty`}, "v1.34.1-dirty", false},
+		{"no version", map[string]string{"kubeadm": `echo unknown`}, "", true},
+		{"failing", map[string]string{"kubeadm": `exit 1`}, "", true},
+		{"kubelet only", map[string]string{"kubeadm": "", "kubectl": ""}, "", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			// the other binaries are required, but their version is not read
+			scripts := map[string]string{"kubelet": `echo Kubernetes v1.34.0`, "kubectl": `echo v1.34.0`}
+			for name, script := range tc.scripts {
+				if script == "" {
+					delete(scripts, name)
+					continue
+				}
+				scripts[name] = script
+			}
+			for name, script := range scripts {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := LocalBinariesVersion(dir)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("LocalBinariesVersion() = %v, want error: %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("LocalBinariesVersion() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLocalBinaries(t *testing.T) {
+	local, err := LocalBinaries("")
+	if err != nil || len(local) != 0 {
+		t.Errorf("LocalBinaries(\"\") = %v, %v, want none", local, err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "kubelet"), nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LocalBinaries(dir); err == nil {
+		t.Errorf("LocalBinaries() with only kubelet succeeded")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "kubeadm"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LocalBinaries(dir); err == nil {
+		t.Errorf("LocalBinaries() with a kubeadm directory succeeded")
+	}
+
+	if err := os.Remove(filepath.Join(dir, "kubeadm")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "kubeadm"), nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	local, err = LocalBinaries(dir)
+	if err != nil {
+		t.Fatalf("LocalBinaries() = %v", err)
+	}
+	if len(local) != 3 || local["kubelet"] != filepath.Join(dir, "kubelet") {
+		t.Errorf("LocalBinaries() = %v, want kubeadm, kubelet and kubectl", local)
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"bytes"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+// The kinds of the component configs which can be given to --component-config
+const (
+	KubeletConfigurationKind       = "KubeletConfiguration"
+	KubeProxyConfigurationKind     = "KubeProxyConfiguration"
+	KubeSchedulerConfigurationKind = "KubeSchedulerConfiguration"
+)
+
+// SchedulerConfigPath is where the config of the scheduler is written to on the control-plane nodes
+var SchedulerConfigPath = path.Join("/etc/kubernetes/scheduler", "config.yaml")
+
+// componentConfigVersion is an API version of a component config, and the Kubernetes versions which serve it
+type componentConfigVersion struct {
+	APIVersion         string
+	GreaterThanOrEqual semver.Version
+	// LessThan is the first Kubernetes version which removed the API version, if any
+	LessThan semver.Version
+}
+
+// componentConfigVersions are the API versions of the component configs
+var componentConfigVersions = map[string][]componentConfigVersion{
+	KubeletConfigurationKind: {
+		{APIVersion: "kubelet.config.k8s.io/v1beta1"},
+	},
+	KubeProxyConfigurationKind: {
+		{APIVersion: "kubeproxy.config.k8s.io/v1alpha1"},
+	},
+	KubeSchedulerConfigurationKind: {
+		{APIVersion: "kubescheduler.config.k8s.io/v1", GreaterThanOrEqual: semver.MustParse("1.25.0")},
+		{APIVersion: "kubescheduler.config.k8s.io/v1beta3", GreaterThanOrEqual: semver.MustParse("1.23.0"), LessThan: semver.MustParse("1.29.0")},
+		{APIVersion: "kubescheduler.config.k8s.io/v1beta2", GreaterThanOrEqual: semver.MustParse("1.22.0"), LessThan: semver.MustParse("1.28.0")},
+	},
+}
+
+// kubeletFlagFields maps the kubelet flags set by minikube to the fields of KubeletConfiguration which they override,
+// so that setting the field in a component config drops the flag
+var kubeletFlagFields = map[string]string{
+	"cgroups-per-qos":          "cgroupsPerQOS",
+	"enforce-node-allocatable": "enforceNodeAllocatable",
+	"pod-cidr":                 "podCIDR",
+}
+
+// ParseComponentConfigs parses the YAML documents of component configs, and returns them by kind,
+// the documents of the same kind being merged in order
+func ParseComponentConfigs(docs []byte) (map[string]string, error) {
+	configs := map[string]map[string]interface{}{}
+	for i, doc := range splitYAMLDocuments(docs) {
+		m := map[string]interface{}{}
+		if err := yaml.Unmarshal(doc, &m); err != nil {
+			return nil, errors.Wrapf(err, "parsing component config #%d", i+1)
+		}
+		if len(m) == 0 {
+			continue
+		}
+		kind, _ := m["kind"].(string)
+		if _, ok := componentConfigVersions[kind]; !ok {
+			return nil, errors.Errorf("component config #%d is of kind %q, must be one of: %s", i+1, kind, strings.Join(componentConfigKinds(), ", "))
+		}
+		if prev, ok := configs[kind]; ok {
+			if prev["apiVersion"] != m["apiVersion"] {
+				return nil, errors.Errorf("the %s component configs have different API versions: %v and %v", kind, prev["apiVersion"], m["apiVersion"])
+			}
+			deepMerge(prev, m)
+			continue
+		}
+		configs[kind] = m
+	}
+
+	parsed := map[string]string{}
+	for kind, m := range configs {
+		b, err := yaml.Marshal(m)
+		if err != nil {
+			return nil, errors.Wrapf(err, "marshalling %s", kind)
+		}
+		parsed[kind] = string(b)
+	}
+	return parsed, nil
+}
+
+// ValidateComponentConfigs validates that the API versions of the component configs are served by the Kubernetes version
+func ValidateComponentConfigs(configs map[string]string, version semver.Version) error {
+	for kind, c := range configs {
+		m, err := componentConfig(c)
+		if err != nil {
+			return errors.Wrapf(err, "parsing %s", kind)
+		}
+		versions, ok := componentConfigVersions[kind]
+		if !ok {
+			return errors.Errorf("unknown component config kind %q, must be one of: %s", kind, strings.Join(componentConfigKinds(), ", "))
+		}
+
+		apiVersion, _ := m["apiVersion"].(string)
+		supported := []string{}
+		for _, v := range versions {
+			if version.LT(v.GreaterThanOrEqual) || (!v.LessThan.Equals(semver.Version{}) && version.GTE(v.LessThan)) {
+				continue
+			}
+			supported = append(supported, v.APIVersion)
+		}
+		if !slices.Contains(supported, apiVersion) {
+			return errors.Errorf("%s %s is not supported by Kubernetes v%s, supported API versions: %s", apiVersion, kind, version, strings.Join(supported, ", "))
+		}
+	}
+	return nil
+}
+
+// mergeComponentConfigs deep-merges the component configs of the cluster into the documents of the kubeadm config of the same kind
+func mergeComponentConfigs(kubeadmYAML []byte, configs map[string]string) ([]byte, error) {
+	if configs[KubeletConfigurationKind] == "" && configs[KubeProxyConfigurationKind] == "" {
+		return kubeadmYAML, nil
+	}
+
+	docs := splitYAMLDocuments(kubeadmYAML)
+	for i, doc := range docs {
+		m := map[string]interface{}{}
+		if err := yaml.Unmarshal(doc, &m); err != nil {
+			return nil, errors.Wrap(err, "parsing kubeadm config")
+		}
+		kind, _ := m["kind"].(string)
+		if configs[kind] == "" {
+			continue
+		}
+		c, err := componentConfig(configs[kind])
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", kind)
+		}
+		klog.Infof("merging %s into the kubeadm config:\n%s", kind, configs[kind])
+		deepMerge(m, c)
+		if docs[i], err = yaml.Marshal(m); err != nil {
+			return nil, errors.Wrapf(err, "marshalling %s", kind)
+		}
+	}
+	return bytes.Join(docs, []byte("---\n")), nil
+}
+
+// SchedulerConfig returns the config of the scheduler, the KubeSchedulerConfiguration of the cluster merged into the settings of kubeadm and minikube
+func SchedulerConfig(k8s config.KubernetesConfig) ([]byte, error) {
+	c, err := componentConfig(k8s.ComponentConfigs[KubeSchedulerConfigurationKind])
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", KubeSchedulerConfigurationKind)
+	}
+	// the scheduler ignores its flags once it reads a config file, which therefore starts from the flags set by kubeadm and minikube
+	m := map[string]interface{}{
+		"clientConnection": map[string]interface{}{"kubeconfig": "/etc/kubernetes/scheduler.conf"},
+		"leaderElection":   map[string]interface{}{"leaderElect": false},
+	}
+	deepMerge(m, c)
+	return yaml.Marshal(m)
+}
+
+// schedulerExtraOptions returns the flag of the scheduler reading its config, which the --extra-config flags override
+func schedulerExtraOptions(k8s config.KubernetesConfig) config.ExtraOptionSlice {
+	if k8s.ComponentConfigs[KubeSchedulerConfigurationKind] == "" {
+		return nil
+	}
+	return config.ExtraOptionSlice{{Component: Scheduler, Key: "config", Value: SchedulerConfigPath}}
+}
+
+// schedulerVolumes returns the mount of the config of the scheduler into its static pod
+func schedulerVolumes(k8s config.KubernetesConfig) []hostPathMount {
+	if k8s.ComponentConfigs[KubeSchedulerConfigurationKind] == "" {
+		return nil
+	}
+	return []hostPathMount{
+		{Name: "scheduler-config", HostPath: SchedulerConfigPath, MountPath: SchedulerConfigPath, ReadOnly: true, PathType: "File"},
+	}
+}
+
+// componentConfig parses a component config
+func componentConfig(c string) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(c), &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// componentConfigKinds returns the sorted kinds of the component configs
+func componentConfigKinds() []string {
+	kinds := []string{}
+	for kind := range componentConfigVersions {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// deepMerge merges src into dst: the maps are merged recursively, while the other values of src replace those of dst
+func deepMerge(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if sm, ok := v.(map[string]interface{}); ok {
+			if dm, ok := dst[k].(map[string]interface{}); ok {
+				deepMerge(dm, sm)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// splitYAMLDocuments splits a YAML stream into its documents
+func splitYAMLDocuments(data []byte) [][]byte {
+	docs := [][]byte{}
+	var doc []byte
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if strings.TrimRight(string(line), " \r\n") == "---" {
+			docs = append(docs, doc)
+			doc = nil
+			continue
+		}
+		doc = append(doc, line...)
+	}
+	return append(docs, doc)
+}
+
+// kubeletFlagOverridden returns whether the kubelet flag is overridden by a field of the KubeletConfiguration of the cluster
+func kubeletFlagOverridden(k8s config.KubernetesConfig, flag string) bool {
+	field, ok := kubeletFlagFields[flag]
+	if !ok || k8s.ComponentConfigs[KubeletConfigurationKind] == "" {
+		return false
+	}
+	c, err := componentConfig(k8s.ComponentConfigs[KubeletConfigurationKind])
+	if err != nil {
+		klog.Warningf("unable to parse %s: %v", KubeletConfigurationKind, err)
+		return false
+	}
+	_, set := c[field]
+	return set
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestParseComponentConfigs(t *testing.T) {
+	tests := []struct {
+		name    string
+		docs    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "merged",
+			docs: `---
+apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+maxPods: 50
+evictionHard:
+  memory.available: 100Mi
+---
+apiVersion: kubeproxy.config.k8s.io/v1alpha1
+kind: KubeProxyConfiguration
+mode: ipvs
+---
+apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+evictionHard:
+  nodefs.available: 10%
+`,
+			want: map[string]string{
+				KubeletConfigurationKind:   "apiVersion: kubelet.config.k8s.io/v1beta1\nevictionHard:\n  memory.available: 100Mi\n  nodefs.available: 10%\nkind: KubeletConfiguration\nmaxPods: 50\n",
+				KubeProxyConfigurationKind: "apiVersion: kubeproxy.config.k8s.io/v1alpha1\nkind: KubeProxyConfiguration\nmode: ipvs\n",
+			},
+		},
+		{
+			name:    "unknown kind",
+			docs:    "apiVersion: kubeadm.k8s.io/v1beta4\nkind: ClusterConfiguration\n",
+			wantErr: true,
+		},
+		{
+			name:    "different API versions",
+			docs:    "apiVersion: kubescheduler.config.k8s.io/v1\nkind: KubeSchedulerConfiguration\n---\napiVersion: kubescheduler.config.k8s.io/v1beta3\nkind: KubeSchedulerConfiguration\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid yaml",
+			docs:    "kind: [KubeletConfiguration\n",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseComponentConfigs([]byte(tc.docs))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseComponentConfigs() error = %v, want error: %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseComponentConfigs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateComponentConfigs(t *testing.T) {
+	scheduler := func(apiVersion string) map[string]string {
+		return map[string]string{KubeSchedulerConfigurationKind: "apiVersion: " + apiVersion + "\nkind: KubeSchedulerConfiguration\n"}
+	}
+	tests := []struct {
+		configs map[string]string
+		version string
+		wantErr bool
+	}{
+		{scheduler("kubescheduler.config.k8s.io/v1"), "1.34.0", false},
+		{scheduler("kubescheduler.config.k8s.io/v1beta3"), "1.28.0", false},
+		{scheduler("kubescheduler.config.k8s.io/v1beta3"), "1.29.0", true},
+		{scheduler("kubescheduler.config.k8s.io/v1"), "1.24.0", true},
+		{map[string]string{KubeletConfigurationKind: "apiVersion: kubelet.config.k8s.io/v1\nkind: KubeletConfiguration\n"}, "1.34.0", true},
+	}
+	for _, tc := range tests {
+		err := ValidateComponentConfigs(tc.configs, semver.MustParse(tc.version))
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ValidateComponentConfigs(%v, %s) = %v, want error: %v", tc.configs, tc.version, err, tc.wantErr)
+		}
+	}
+}
+
+func TestSchedulerConfig(t *testing.T) {
+	k8s := config.KubernetesConfig{ComponentConfigs: map[string]string{
+		KubeSchedulerConfigurationKind: "apiVersion: kubescheduler.config.k8s.io/v1\nkind: KubeSchedulerConfiguration\nclientConnection:\n  qps: 100\n",
+	}}
+	got, err := SchedulerConfig(k8s)
+	if err != nil {
+		t.Fatalf("SchedulerConfig() = %v", err)
+	}
+	want := `apiVersion: kubescheduler.config.k8s.io/v1
+clientConnection:
+  kubeconfig: /etc/kubernetes/scheduler.conf
+  qps: 100
+kind: KubeSchedulerConfiguration
+leaderElection:
+  leaderElect: false
+`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("SchedulerConfig() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestKubeletFlagOverridden(t *testing.T) {
+	k8s := config.KubernetesConfig{ComponentConfigs: map[string]string{
+		KubeletConfigurationKind: "apiVersion: kubelet.config.k8s.io/v1beta1\nkind: KubeletConfiguration\ncgroupsPerQOS: true\n",
+	}}
+	if !kubeletFlagOverridden(k8s, "cgroups-per-qos") {
+		t.Errorf("cgroups-per-qos is not overridden by cgroupsPerQOS")
+	}
+	if kubeletFlagOverridden(k8s, "pod-cidr") {
+		t.Errorf("pod-cidr is overridden without podCIDR")
+	}
+	if kubeletFlagOverridden(config.KubernetesConfig{}, "cgroups-per-qos") {
+		t.Errorf("cgroups-per-qos is overridden without component config")
+	}
+}
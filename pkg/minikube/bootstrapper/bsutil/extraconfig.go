@@ -40,9 +40,19 @@ const (
 
 // componentOptions holds extra args for a component
 type componentOptions struct {
-	Component string
-	ExtraArgs map[string]string
-	Pairs     map[string]string
+	Component    string
+	ExtraArgs    map[string]string
+	Pairs        map[string]string
+	ExtraVolumes []hostPathMount
+}
+
+// hostPathMount is a file or directory of the node mounted into the static pod of a component
+type hostPathMount struct {
+	Name      string
+	HostPath  string
+	MountPath string
+	ReadOnly  bool
+	PathType  string
 }
 
 // mapping of component to the section name in kubeadm.
@@ -181,6 +191,11 @@ func newComponentOptions(opts config.ExtraOptionSlice, version semver.Version, f
 // optionPairsForComponent generates a map of value pairs for a k8s component
 func optionPairsForComponent(component string, cp config.Node) map[string]string {
 	if component == Apiserver {
+		if cp.IPv6 != "" {
+			return map[string]string{
+				"certSANs": fmt.Sprintf(`["127.0.0.1", "::1", "localhost", "%s", "%s"]`, cp.IP, cp.IPv6),
+			}
+		}
 		return map[string]string{
 			"certSANs": fmt.Sprintf(`["127.0.0.1", "localhost", "%s"]`, cp.IP),
 		}
@@ -39,6 +39,10 @@ nodeRegistration:
   kubeletExtraArgs:
     node-ip: {{.NodeIP}}
   taints: []
+{{- if .PatchesDir}}
+patches:
+  directory: {{.PatchesDir}}
+{{- end}}
 ---
 apiVersion: kubeadm.k8s.io/v1beta3
 kind: ClusterConfiguration
@@ -51,6 +55,16 @@ kind: ClusterConfiguration
 {{- range $i, $val := printMapInOrder .ExtraArgs ": " }}
     {{$val}}
 {{- end}}
+{{- if .ExtraVolumes}}
+  extraVolumes:
+{{- range .ExtraVolumes}}
+    - name: {{.Name}}
+      hostPath: {{.HostPath}}
+      mountPath: {{.MountPath}}
+      readOnly: {{.ReadOnly}}
+      pathType: {{.PathType}}
+{{- end}}
+{{- end}}
 {{end -}}
 {{if .FeatureArgs}}featureGates:
 {{range $i, $val := .FeatureArgs}}{{$i}}: {{$val}}
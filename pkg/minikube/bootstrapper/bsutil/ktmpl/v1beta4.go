@@ -40,6 +40,10 @@ nodeRegistration:
     - name: "node-ip"
       value: "{{.NodeIP}}"
   taints: []
+{{- if .PatchesDir}}
+patches:
+  directory: {{.PatchesDir}}
+{{- end}}
 ---
 apiVersion: kubeadm.k8s.io/v1beta4
 kind: ClusterConfiguration
@@ -53,6 +57,16 @@ kind: ClusterConfiguration
     - name: "{{$key}}"
       value: "{{$val}}"
 {{- end}}
+{{- if .ExtraVolumes}}
+  extraVolumes:
+{{- range .ExtraVolumes}}
+    - name: {{.Name}}
+      hostPath: {{.HostPath}}
+      mountPath: {{.MountPath}}
+      readOnly: {{.ReadOnly}}
+      pathType: {{.PathType}}
+{{- end}}
+{{- end}}
 {{end -}}
 {{if .FeatureArgs}}featureGates:
 {{range $i, $val := .FeatureArgs}}{{$i}}: {{$val}}
@@ -19,9 +19,14 @@ package bsutil
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"os"
 	"path"
+	"path/filepath"
 	"slices"
+	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
@@ -67,10 +72,21 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 		return nil, errors.Wrap(err, "getting cgroup driver")
 	}
 
-	componentOpts, err := createExtraComponentConfig(k8s.ExtraOptions, version, componentFeatureArgs, n)
+	// the defaults of the audit log come first, so that the --extra-config flags override them
+	extraOpts := append(auditExtraOptions(k8s), schedulerExtraOptions(k8s)...)
+	extraOpts = append(extraOpts, k8s.ExtraOptions...)
+	componentOpts, err := createExtraComponentConfig(extraOpts, version, componentFeatureArgs, n)
 	if err != nil {
 		return nil, errors.Wrap(err, "generating extra component config for kubeadm")
 	}
+	for i := range componentOpts {
+		switch componentOpts[i].Component {
+		case componentToKubeadmConfigKey[Apiserver]:
+			componentOpts[i].ExtraVolumes = auditVolumes(k8s)
+		case componentToKubeadmConfigKey[Scheduler]:
+			componentOpts[i].ExtraVolumes = schedulerVolumes(k8s)
+		}
+	}
 
 	cnm, err := cni.New(&cc)
 	if err != nil {
@@ -82,6 +98,8 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 	if overrideCIDR != "" {
 		podCIDR = overrideCIDR
 	}
+	// dual-stack clusters take a comma separated list of subnets, the primary family first
+	podCIDR = strings.Join(cni.PodCIDRs(k8s, podCIDR), ",")
 	klog.Infof("Using pod CIDR: %s", podCIDR)
 
 	// ref: https://kubernetes.io/docs/reference/config-api/kubelet-config.v1beta1/#kubelet-config-k8s-io-v1beta1-KubeletConfiguration
@@ -133,11 +151,12 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 		ResolvConfSearchRegression bool
 		KubeletConfigOpts          map[string]string
 		PrependCriSocketUnix       bool
+		PatchesDir                 string
 	}{
 		CertDir:           vmpath.GuestKubernetesCertsDir,
 		ServiceCIDR:       constants.DefaultServiceCIDR,
 		PodSubnet:         podCIDR,
-		AdvertiseAddress:  n.IP,
+		AdvertiseAddress:  config.AdvertiseIP(k8s, n),
 		APIServerPort:     nodePort,
 		KubernetesVersion: k8s.KubernetesVersion,
 		EtcdDataDir:       EtcdDataDir(),
@@ -150,7 +169,7 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 		ComponentOptions:           componentOpts,
 		FeatureArgs:                kubeadmFeatureArgs,
 		DNSDomain:                  k8s.DNSDomain,
-		NodeIP:                     n.IP,
+		NodeIP:                     strings.Join(config.NodeIPs(k8s, n), ","),
 		CgroupDriver:               cgroupDriver,
 		ClientCAFile:               path.Join(vmpath.GuestKubernetesCertsDir, "ca.crt"),
 		StaticPodPath:              vmpath.GuestManifestsDir,
@@ -160,8 +179,8 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 		KubeletConfigOpts:          kubeletConfigOpts,
 	}
 
-	if k8s.ServiceCIDR != "" {
-		opts.ServiceCIDR = k8s.ServiceCIDR
+	if k8s.ServiceCIDR != "" || config.IPv6Enabled(k8s) {
+		opts.ServiceCIDR = strings.Join(config.ServiceCIDRs(k8s), ",")
 	}
 
 	configTmpl := ktmpl.V1Beta1
@@ -187,15 +206,31 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 		opts.PrependCriSocketUnix = true
 	}
 
+	if k8s.KubeadmPatches != "" {
+		opts.PatchesDir = KubeadmPatchesDir
+	}
+
 	klog.Infof("kubeadm options: %+v", opts)
 
 	b := bytes.Buffer{}
 	if err := configTmpl.Execute(&b, opts); err != nil {
 		return nil, err
 	}
-	klog.Infof("kubeadm config:\n%s\n", b.String())
+	kubeadmYAML, err := mergeComponentConfigs(b.Bytes(), k8s.ComponentConfigs)
+	if err != nil {
+		return nil, errors.Wrap(err, "merging component configs")
+	}
+	sum, err := nodeFilesChecksum(k8s)
+	if err != nil {
+		return nil, err
+	}
+	if sum != "" {
+		// the files are not part of the kubeadm config, their checksum lets a restart detect that they changed
+		kubeadmYAML = append([]byte(fmt.Sprintf("# checksum of the audit policy, scheduler config and kubeadm patches: %s\n", sum)), kubeadmYAML...)
+	}
+	klog.Infof("kubeadm config:\n%s\n", kubeadmYAML)
 
-	return b.Bytes(), nil
+	return kubeadmYAML, nil
 }
 
 // These are the components that can be configured
@@ -268,3 +303,34 @@ func kubeletConfigOpts(extraOpts config.ExtraOptionSlice) map[string]string {
 	}
 	return args
 }
+
+// nodeFilesChecksum returns the checksum of the files read by the control plane besides the kubeadm config, if any
+func nodeFilesChecksum(k8s config.KubernetesConfig) (string, error) {
+	h := sha256.New()
+	if k8s.APIServerAuditPolicy != "" {
+		policy, err := AuditPolicy(k8s.APIServerAuditPolicy)
+		if err != nil {
+			return "", errors.Wrap(err, "audit policy")
+		}
+		h.Write(policy)
+	}
+	h.Write([]byte(k8s.ComponentConfigs[KubeSchedulerConfigurationKind]))
+	if k8s.KubeadmPatches != "" {
+		files, err := kubeadmPatchFiles(k8s.KubeadmPatches)
+		if err != nil {
+			return "", err
+		}
+		for _, f := range files {
+			data, err := os.ReadFile(filepath.Join(k8s.KubeadmPatches, f))
+			if err != nil {
+				return "", errors.Wrap(err, "reading kubeadm patch")
+			}
+			h.Write([]byte(f))
+			h.Write(data)
+		}
+	}
+	if k8s.APIServerAuditPolicy == "" && k8s.ComponentConfigs[KubeSchedulerConfigurationKind] == "" && k8s.KubeadmPatches == "" {
+		return "", nil
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
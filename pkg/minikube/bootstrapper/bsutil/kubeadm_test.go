@@ -214,6 +214,11 @@ func TestGenerateKubeadmYAMLDNS(t *testing.T) {
 func TestGenerateKubeadmYAML(t *testing.T) {
 	extraOpts := getExtraOpts()
 	extraOptsPodCidr := getExtraOptsPodCidr()
+	componentConfigs := map[string]string{
+		KubeletConfigurationKind:       "apiVersion: kubelet.config.k8s.io/v1beta1\nkind: KubeletConfiguration\nmaxPods: 50\nevictionHard:\n  memory.available: 100Mi\n",
+		KubeProxyConfigurationKind:     "apiVersion: kubeproxy.config.k8s.io/v1alpha1\nkind: KubeProxyConfiguration\nmode: ipvs\n",
+		KubeSchedulerConfigurationKind: "apiVersion: kubescheduler.config.k8s.io/v1\nkind: KubeSchedulerConfiguration\npercentageOfNodesToScore: 50\n",
+	}
 	// test the 6 most recent releases
 	versions, err := recentReleases(6)
 	if err != nil {
@@ -240,6 +245,9 @@ func TestGenerateKubeadmYAML(t *testing.T) {
 		{"containerd-api-port", "containerd", false, config.ClusterConfig{Name: "mk", KubernetesConfig: config.KubernetesConfig{ContainerRuntime: constants.Containerd}, Nodes: []config.Node{{Port: 12345}}}},
 		{"containerd-pod-network-cidr", "containerd", false, config.ClusterConfig{Name: "mk", KubernetesConfig: config.KubernetesConfig{ContainerRuntime: constants.Containerd, ExtraOptions: extraOptsPodCidr}}},
 		{"image-repository", "docker", false, config.ClusterConfig{Name: "mk", KubernetesConfig: config.KubernetesConfig{ImageRepository: "test/repo"}}},
+		{"dual-stack", "docker", false, config.ClusterConfig{Name: "mk", KubernetesConfig: config.KubernetesConfig{IPFamily: constants.IPFamilyDual}, Nodes: []config.Node{{IPv6: "fd00::2"}}}},
+		{"audit", "docker", false, config.ClusterConfig{Name: "mk", KubernetesConfig: config.KubernetesConfig{APIServerAuditPolicy: "metadata", ExtraOptions: config.ExtraOptionSlice{{Component: Apiserver, Key: "audit-log-maxbackup", Value: "10"}}}}},
+		{"component-config", "docker", false, config.ClusterConfig{Name: "mk", KubernetesConfig: config.KubernetesConfig{KubeadmPatches: "testdata/kubeadm-patches", ComponentConfigs: componentConfigs}}},
 	}
 	for _, version := range versions {
 		for _, tc := range tests {
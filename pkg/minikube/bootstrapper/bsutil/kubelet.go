@@ -22,9 +22,11 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/ktmpl"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
@@ -83,7 +85,7 @@ func extraKubeletOpts(mc config.ClusterConfig, nc config.Node, r cruntime.Manage
 	}
 
 	if _, ok := extraOpts["node-ip"]; !ok {
-		extraOpts["node-ip"] = nc.IP
+		extraOpts["node-ip"] = strings.Join(config.NodeIPs(k8s, nc), ",")
 	}
 
 	if _, ok := extraOpts["hostname-override"]; !ok {
@@ -121,6 +123,14 @@ func extraKubeletOpts(mc config.ClusterConfig, nc config.Node, r cruntime.Manage
 		delete(extraOpts, opt)
 	}
 
+	// the fields of the KubeletConfiguration of --component-config replace the flags set by minikube, which would override them
+	for flag := range kubeletFlagFields {
+		if _, ok := extraOpts[flag]; ok && kubeletFlagOverridden(k8s, flag) && k8s.ExtraOptions.Get(flag, Kubelet) == "" {
+			klog.Infof("dropping kubelet flag %s, set by the KubeletConfiguration", flag)
+			delete(extraOpts, flag)
+		}
+	}
+
 	return extraOpts, nil
 }
 
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+// KubeadmPatchesDir is where the patches of --kubeadm-patches are copied to on the nodes
+var KubeadmPatchesDir = path.Join(vmpath.GuestPersistentDir, "kubeadm-patches")
+
+// kubeadmPatchTargets are the targets of the kubeadm patches, and the first Kubernetes version which supports them
+// ref: https://kubernetes.io/docs/setup/production-environment/tools/kubeadm/control-plane-flags/#patches
+var kubeadmPatchTargets = map[string]semver.Version{
+	"etcd":                    semver.MustParse("1.22.0"),
+	"kube-apiserver":          semver.MustParse("1.22.0"),
+	"kube-controller-manager": semver.MustParse("1.22.0"),
+	"kube-scheduler":          semver.MustParse("1.22.0"),
+	"kubeletconfiguration":    semver.MustParse("1.25.0"),
+}
+
+// kubeadmPatchTypes are the types of the kubeadm patches
+var kubeadmPatchTypes = []string{"strategic", "merge", "json"}
+
+// ValidateKubeadmPatches validates the names of the patches in dir, in the format target[suffix][+patchtype].extension, for the Kubernetes version
+func ValidateKubeadmPatches(dir string, version semver.Version) error {
+	files, err := kubeadmPatchFiles(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.Errorf("no patch in %s, the patches must be named target[suffix][+patchtype].yaml or .json", dir)
+	}
+
+	for _, f := range files {
+		name := strings.TrimSuffix(f, filepath.Ext(f))
+		if i := strings.LastIndex(name, "+"); i >= 0 {
+			patchType := name[i+1:]
+			if !slices.Contains(kubeadmPatchTypes, patchType) {
+				return errors.Errorf("invalid patch %s: unknown patch type %q, must be one of: %s", f, patchType, strings.Join(kubeadmPatchTypes, ", "))
+			}
+			name = name[:i]
+		}
+
+		target := ""
+		for t := range kubeadmPatchTargets {
+			if strings.HasPrefix(name, t) && len(t) > len(target) {
+				target = t
+			}
+		}
+		if target == "" {
+			return errors.Errorf("invalid patch %s: unknown target, must be one of: etcd, kube-apiserver, kube-controller-manager, kube-scheduler, kubeletconfiguration", f)
+		}
+		if version.LT(kubeadmPatchTargets[target]) {
+			return errors.Errorf("invalid patch %s: the target %s is supported from Kubernetes v%s", f, target, kubeadmPatchTargets[target])
+		}
+	}
+	return nil
+}
+
+// KubeadmPatchAssets returns the patches in dir, to be copied to KubeadmPatchesDir on the nodes
+func KubeadmPatchAssets(dir string) ([]assets.CopyableFile, error) {
+	files, err := kubeadmPatchFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	patches := []assets.CopyableFile{}
+	for _, f := range files {
+		a, err := assets.NewFileAsset(filepath.Join(dir, f), KubeadmPatchesDir, f, "0640")
+		if err != nil {
+			return nil, errors.Wrapf(err, "patch %s", f)
+		}
+		patches = append(patches, a)
+	}
+	return patches, nil
+}
+
+// kubeadmPatchFiles returns the names of the patches in dir, the files which kubeadm reads
+func kubeadmPatchFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading kubeadm patches")
+	}
+	files := []string{}
+	for _, e := range entries {
+		switch filepath.Ext(e.Name()) {
+		case ".yaml", ".json":
+			if !e.IsDir() {
+				files = append(files, e.Name())
+			}
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver/v4"
+)
+
+func TestValidateKubeadmPatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []string
+		version string
+		wantErr bool
+	}{
+		{"valid", []string{"kube-apiserver+strategic.yaml", "etcd0+json.json", "kubeletconfiguration.yaml", "README.md"}, "1.34.0", false},
+		{"empty", []string{"README.md"}, "1.34.0", true},
+		{"unknown target", []string{"coredns.yaml"}, "1.34.0", true},
+		{"unknown patch type", []string{"kube-scheduler+yaml.yaml"}, "1.34.0", true},
+		{"target too recent", []string{"kubeletconfiguration+merge.yaml"}, "1.24.0", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, f := range tc.files {
+				if err := os.WriteFile(filepath.Join(dir, f), []byte("{}"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := ValidateKubeadmPatches(dir, semver.MustParse(tc.version))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateKubeadmPatches(%v) = %v, want error: %v", tc.files, err, tc.wantErr)
+			}
+		})
+	}
+
+	if err := ValidateKubeadmPatches(filepath.Join(t.TempDir(), "missing"), semver.MustParse("1.34.0")); err == nil {
+		t.Errorf("ValidateKubeadmPatches() of a missing directory succeeded")
+	}
+}
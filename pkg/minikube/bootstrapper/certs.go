@@ -265,9 +265,21 @@ func generateProfileCerts(cfg config.ClusterConfig, n config.Node, shared shared
 
 	apiServerIPs := append([]net.IP{}, k8s.APIServerIPs...)
 	apiServerIPs = append(apiServerIPs, serviceIP, net.ParseIP(oci.DefaultBindIPV4), net.ParseIP("10.0.0.1"))
+	if config.IPv6Enabled(k8s) {
+		// the IPv6 service subnet always comes last
+		cidrs := config.ServiceCIDRs(k8s)
+		serviceIPv6, err := util.ServiceClusterIP(cidrs[len(cidrs)-1])
+		if err != nil {
+			return nil, errors.Wrap(err, "get ipv6 service cluster ip")
+		}
+		apiServerIPs = append(apiServerIPs, serviceIPv6, net.IPv6loopback)
+	}
 	// append ip addresses of all control-plane nodes
 	for _, n := range config.ControlPlanes(cfg) {
 		apiServerIPs = append(apiServerIPs, net.ParseIP(n.IP))
+		if n.IPv6 != "" {
+			apiServerIPs = append(apiServerIPs, net.ParseIP(n.IPv6))
+		}
 	}
 	if config.IsHA(cfg) {
 		apiServerIPs = append(apiServerIPs, net.ParseIP(cfg.KubernetesConfig.APIServerHAVIP))
@@ -61,7 +61,8 @@ func essentials(mirror string, v semver.Version) []string {
 
 // componentImage returns a Kubernetes component image to pull
 func componentImage(name string, v semver.Version, mirror string) string {
-	return fmt.Sprintf("%s:v%s", path.Join(kubernetesRepo(mirror), name), v)
+	// like kubeadm, as the build metadata of the versions built locally is not allowed in the tags
+	return fmt.Sprintf("%s:v%s", path.Join(kubernetesRepo(mirror), name), strings.ReplaceAll(v.String(), "+", "_"))
 }
 
 // tagFromKubeadm gets the image tag by running kubeadm image list command on the host machine (Linux only)
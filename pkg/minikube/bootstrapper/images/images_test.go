@@ -205,3 +205,11 @@ func TestTagFromLastMinor(t *testing.T) {
 		}
 	}
 }
+
+func TestComponentImage(t *testing.T) {
+	v := semver.MustParse("1.35.0-alpha.1.42+0123456789abcd")
+	want := "registry.k8s.io/kube-apiserver:v1.35.0-alpha.1.42_0123456789abcd"
+	if got := componentImage("kube-apiserver", v, ""); got != want {
+		t.Errorf("componentImage(%s) = %s, want %s", v, got, want)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"os/exec"
 	"path"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -158,6 +159,14 @@ func (k *Bootstrapper) clearStaleConfigs(cfg config.ClusterConfig) {
 
 	endpoint := fmt.Sprintf("https://%s", net.JoinHostPort(constants.ControlPlaneAlias, strconv.Itoa(cfg.APIServerPort)))
 	for _, path := range paths {
+		// the kubelet.conf of a copied cluster has the credentials of the node it was copied from
+		if cfg.CopiedFrom != "" && path == "/etc/kubernetes/kubelet.conf" {
+			klog.Infof("the data of the cluster was copied from %s - will remove %s", cfg.CopiedFrom, path)
+			if _, err := k.c.RunCmd(exec.Command("sudo", "rm", "-f", path)); err != nil {
+				klog.Errorf("rm failed: %v", err)
+			}
+			continue
+		}
 		_, err := k.c.RunCmd(exec.Command("sudo", "grep", endpoint, path))
 		if err != nil {
 			klog.Infof("%q may not be in %s - will remove: %v", endpoint, path, err)
@@ -616,7 +625,7 @@ func (k *Bootstrapper) restartPrimaryControlPlane(cfg config.ClusterConfig) erro
 	}
 
 	// Save the costly tax of reinstalling Kubernetes if the only issue is a missing kube context
-	if _, err := kubeconfig.UpdateEndpoint(cfg.Name, host, port, kubeconfig.PathFromEnv(), kubeconfig.NewExtension()); err != nil {
+	if _, err := kubeconfig.UpdateEndpoint(cfg.Name, host, port, config.KubeconfigPath(&cfg), kubeconfig.NewExtension()); err != nil {
 		klog.Warningf("unable to update kubeconfig (cluster will likely require a reset): %v", err)
 	}
 
@@ -763,10 +772,14 @@ func (k *Bootstrapper) JoinCluster(cc config.ClusterConfig, n config.Node, joinC
 		// ref: https://kubernetes.io/docs/reference/setup-tools/kubeadm/kubeadm-join/#options
 		// "If the node should host a new control plane instance, the IP address the API Server will advertise it's listening on. If not set the default network interface will be used."
 		// "If the node should host a new control plane instance, the port for the API Server to bind to."
-		joinCmd += " --apiserver-advertise-address=" + n.IP +
+		joinCmd += " --apiserver-advertise-address=" + config.AdvertiseIP(cc.KubernetesConfig, n) +
 			" --apiserver-bind-port=" + strconv.Itoa(n.Port)
 	}
 
+	if cc.KubernetesConfig.KubeadmPatches != "" {
+		joinCmd += " --patches=" + bsutil.KubeadmPatchesDir
+	}
+
 	if _, err := k.c.RunCmd(exec.Command("sudo", "/bin/bash", "-c", joinCmd)); err != nil {
 		return errors.Wrapf(err, "kubeadm join")
 	}
@@ -910,6 +923,19 @@ func (k *Bootstrapper) UpdateCluster(cfg config.ClusterConfig) error {
 		}
 	}
 
+	if dir := cfg.KubernetesConfig.KubernetesImagesDir; dir != "" {
+		_, provided, err := machine.LocalKubernetesImages(dir, imgs)
+		if err != nil {
+			return errors.Wrap(err, "local Kubernetes images")
+		}
+		local := map[string]bool{}
+		for _, img := range provided {
+			local[img] = true
+		}
+		// the cached images would replace the images built locally, which are loaded by UpdateNode
+		imgs = slices.DeleteFunc(imgs, func(img string) bool { return local[img] })
+	}
+
 	if cfg.KubernetesConfig.ShouldLoadCachedImages {
 		if err := machine.LoadCachedImages(&cfg, k.c, imgs, detect.ImageCacheDir(), false); err != nil {
 			out.FailureT("Unable to load cached images: {{.error}}", out.V{"error": err})
@@ -961,8 +987,23 @@ func (k *Bootstrapper) UpdateNode(cfg config.ClusterConfig, n config.Node, r cru
 			}
 			files = append(files, assets.NewMemoryAssetTarget(kubeadmCfg, constants.KubeadmYamlPath+".new", "0640"))
 		}
-		// deploy kube-vip for ha (multi-control plane) cluster
-		if config.IsHA(cfg) {
+		// every control-plane node runs an API server, which reads the audit policy from its node
+		if cfg.KubernetesConfig.APIServerAuditPolicy != "" {
+			policy, err := bsutil.AuditPolicy(cfg.KubernetesConfig.APIServerAuditPolicy)
+			if err != nil {
+				return errors.Wrap(err, "audit policy")
+			}
+			files = append(files, assets.NewMemoryAssetTarget(policy, bsutil.AuditPolicyPath, "0600"))
+		}
+		if cfg.KubernetesConfig.ComponentConfigs[bsutil.KubeSchedulerConfigurationKind] != "" {
+			schedulerCfg, err := bsutil.SchedulerConfig(cfg.KubernetesConfig)
+			if err != nil {
+				return errors.Wrap(err, "generating scheduler config")
+			}
+			files = append(files, assets.NewMemoryAssetTarget(schedulerCfg, bsutil.SchedulerConfigPath, "0600"))
+		}
+		// deploy kube-vip for ha (multi-control plane) cluster, unless its API servers are fronted by haproxy
+		if config.IsHA(cfg) && !config.IsHAProxy(cfg) {
 			// workaround for kube-vip
 			// only applicable for k8s v1.29+ during primary control-plane node's kubeadm init (ie, first boot)
 			// TODO (prezha): remove when fixed upstream - ref: https://github.com/kube-vip/kube-vip/issues/684#issuecomment-1864855405
@@ -980,6 +1021,29 @@ func (k *Bootstrapper) UpdateNode(cfg config.ClusterConfig, n config.Node, r cru
 		}
 	}
 
+	// the patches are read by 'kubeadm init' and 'kubeadm join' on every node, the stale ones of a previous start are removed
+	if _, err := k.c.RunCmd(exec.Command("sudo", "rm", "-rf", bsutil.KubeadmPatchesDir)); err != nil {
+		return errors.Wrap(err, "removing kubeadm patches")
+	}
+	if cfg.KubernetesConfig.KubeadmPatches != "" {
+		patches, err := bsutil.KubeadmPatchAssets(cfg.KubernetesConfig.KubeadmPatches)
+		if err != nil {
+			return errors.Wrap(err, "kubeadm patches")
+		}
+		files = append(files, patches...)
+	}
+
+	// the images built locally are loaded on every start, as they may have been rebuilt
+	if dir := cfg.KubernetesConfig.KubernetesImagesDir; dir != "" {
+		imgs, err := images.Kubeadm(cfg.KubernetesConfig.ImageRepository, cfg.KubernetesConfig.KubernetesVersion)
+		if err != nil {
+			return errors.Wrap(err, "kubeadm images")
+		}
+		if err := machine.LoadLocalKubernetesImages(&cfg, k.c, dir, imgs); err != nil {
+			return errors.Wrap(err, "loading local Kubernetes images")
+		}
+	}
+
 	sm := sysinit.New(k.c)
 
 	if err := bsutil.TransferBinaries(cfg.KubernetesConfig, k.c, sm, cfg.BinaryMirror); err != nil {
@@ -1010,7 +1074,7 @@ func (k *Bootstrapper) UpdateNode(cfg config.ClusterConfig, n config.Node, r cru
 		if err != nil {
 			return errors.Wrap(err, "get control-plane node")
 		}
-		cpIP = cp.IP
+		cpIP = config.AdvertiseIP(cfg.KubernetesConfig, cp)
 	}
 	if err := machine.AddHostAlias(k.c, constants.ControlPlaneAlias, net.ParseIP(cpIP)); err != nil {
 		return errors.Wrap(err, "add control-plane alias")
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package haproxy
+
+import (
+	"bytes"
+	"net"
+	"strconv"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+// haproxyTemplate is the config of haproxy, balancing the TCP connections to the API servers between the healthy control-plane nodes
+// ref: https://github.com/kubernetes/kubeadm/blob/main/docs/ha-considerations.md#haproxy-configuration
+var haproxyTemplate = template.Must(template.New("haproxyTemplate").Parse(`global
+  log stdout format raw local0 notice
+
+defaults
+  log global
+  mode tcp
+  option dontlognull
+  timeout connect 5s
+  timeout client 1h
+  timeout server 1h
+
+frontend apiserver
+  bind *:{{ .Port }}
+  default_backend apiservers
+
+backend apiservers
+  option httpchk GET /healthz
+  http-check expect status 200
+{{- range .Backends }}
+  server {{ .Name }} {{ .Address }} check check-ssl verify none
+{{- end }}
+`))
+
+// backend is an API server fronted by haproxy
+type backend struct {
+	Name    string
+	Address string
+}
+
+// Config generates the config of haproxy, with the control-plane nodes of the cluster as backends
+func Config(cc config.ClusterConfig) ([]byte, error) {
+	klog.Info("generating haproxy config ...")
+
+	params := struct {
+		Port     int
+		Backends []backend
+	}{
+		Port: cc.APIServerPort,
+	}
+	for _, n := range config.ControlPlanes(cc) {
+		// the node is added once it got its IP
+		if n.IP == "" {
+			continue
+		}
+		params.Backends = append(params.Backends, backend{Name: config.MachineName(cc, n), Address: net.JoinHostPort(n.IP, strconv.Itoa(n.Port))})
+	}
+
+	b := bytes.Buffer{}
+	if err := haproxyTemplate.Execute(&b, params); err != nil {
+		return nil, errors.Wrapf(err, "parse template")
+	}
+
+	klog.Infof("haproxy config:\n%s", b.String())
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package haproxy
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestConfig(t *testing.T) {
+	cc := config.ClusterConfig{
+		Name:          "ha",
+		APIServerPort: 8443,
+		Nodes: []config.Node{
+			{Name: "", IP: "192.168.49.2", Port: 8443, ControlPlane: true},
+			{Name: "m02", IP: "192.168.49.3", Port: 8443, ControlPlane: true},
+			{Name: "m03", Port: 8443, ControlPlane: true},
+			{Name: "m04", IP: "192.168.49.5", Worker: true},
+		},
+	}
+	got, err := Config(cc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := string(got)
+	for _, want := range []string{
+		"bind *:8443",
+		"server ha 192.168.49.2:8443 check",
+		"server ha-m02 192.168.49.3:8443 check",
+	} {
+		if !strings.Contains(cfg, want) {
+			t.Errorf("config does not contain %q:\n%s", want, cfg)
+		}
+	}
+	for _, unwanted := range []string{"ha-m03", "ha-m04"} {
+		if strings.Contains(cfg, unwanted) {
+			t.Errorf("config contains %q:\n%s", unwanted, cfg)
+		}
+	}
+}
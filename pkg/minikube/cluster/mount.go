@@ -133,6 +133,22 @@ func resolveGID(id string) string {
 	return fmt.Sprintf(`$(grep ^%s: /etc/group | cut -d: -f3)`, id)
 }
 
+// MountOwner returns the numeric ids of the owner of the mounted files in the node. The
+// 9P2000.L clients use the owner of the files reported by the server instead of dfltuid
+// and dfltgid, so that the server must report the files as owned by them.
+func MountOwner(r mountRunner, c *MountConfig) (uint32, uint32, error) {
+	rr, err := r.RunCmd(exec.Command("/bin/bash", "-c", fmt.Sprintf("echo %s %s", resolveUID(c.UID), resolveGID(c.GID))))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "resolve owner")
+	}
+
+	var uid, gid uint32
+	if _, err := fmt.Sscan(rr.Stdout.String(), &uid, &gid); err != nil {
+		return 0, 0, errors.Wrapf(err, "parse owner %q", rr.Stdout.String())
+	}
+	return uid, gid, nil
+}
+
 // mntCmd returns a mount command based on a config.
 func mntCmd(source string, target string, c *MountConfig) string {
 	options := map[string]string{
@@ -0,0 +1,268 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-ps"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/process"
+)
+
+// Health of a background mount
+const (
+	MountStarting    = "Starting"
+	MountHealthy     = "Mounted"
+	MountRemounting  = "Remounting"
+	MountUnreachable = "Unreachable"
+	MountStopped     = "Stopped"
+)
+
+// MountDaemonState is the state of a background mount, written by its process
+type MountDaemonState struct {
+	Name      string    `json:"name"`
+	Pid       int       `json:"pid"`
+	Health    string    `json:"health"`
+	Error     string    `json:"error,omitempty"`
+	Remounts  int       `json:"remounts"`
+	LastCheck time.Time `json:"lastCheck"`
+}
+
+// daemonRunning returns whether pid is a running minikube process serving a background mount or forward, replaced in tests
+var daemonRunning = func(pid int) bool {
+	entry, err := ps.FindProcess(pid)
+	if err != nil {
+		klog.Warningf("unable to find process %d: %v", pid, err)
+		return false
+	}
+	return entry != nil && strings.Contains(entry.Executable(), "minikube")
+}
+
+// MountDaemonArgs returns the arguments of the minikube process serving a background mount
+func MountDaemonArgs(profile string, m config.ManagedMount) []string {
+	args := []string{
+		"mount", m.Source + ":" + m.Target,
+		"--profile", profile,
+		"--name", m.Name,
+		"--daemon-child",
+		fmt.Sprintf("--%s=%s", constants.MountTypeFlag, m.Type),
+		fmt.Sprintf("--%s=%s", constants.MountUIDFlag, m.UID),
+		fmt.Sprintf("--%s=%s", constants.MountGIDFlag, m.GID),
+		fmt.Sprintf("--%s=%s", constants.Mount9PVersionFlag, m.Version),
+		fmt.Sprintf("--%s=%d", constants.MountMSizeFlag, m.MSize),
+		fmt.Sprintf("--%s=%d", constants.MountPortFlag, m.Port),
+	}
+	if m.IP != "" {
+		args = append(args, fmt.Sprintf("--%s=%s", constants.MountIPFlag, m.IP))
+	}
+	if m.Node != "" {
+		args = append(args, "--node", m.Node)
+	}
+	for _, o := range m.Options {
+		args = append(args, fmt.Sprintf("--%s=%s", constants.MountOptionsFlag, o))
+	}
+	if m.Notify {
+		args = append(args, "--notify", fmt.Sprintf("--notify-debounce=%s", m.NotifyDebounce))
+		for _, glob := range m.NotifyIgnore {
+			args = append(args, fmt.Sprintf("--notify-ignore=%s", glob))
+		}
+	}
+	return args
+}
+
+// StartMountDaemon starts a minikube process serving the mount in the background, and returns its pid
+func StartMountDaemon(profile string, m config.ManagedMount) (int, error) {
+	if err := os.MkdirAll(filepath.Dir(localpath.MountPID(profile, m.Name)), 0o755); err != nil {
+		return 0, errors.Wrap(err, "create mounts directory")
+	}
+	logFile, err := os.OpenFile(localpath.MountLog(profile, m.Name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return 0, errors.Wrap(err, "open log file")
+	}
+	defer logFile.Close()
+
+	path, err := os.Executable()
+	if err != nil {
+		return 0, errors.Wrap(err, "find minikube executable")
+	}
+	cmd := exec.Command(path, MountDaemonArgs(profile, m)...)
+	cmd.Env = append(os.Environ(), constants.IsMinikubeChildProcess+"=true")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	detachProcess(cmd)
+	klog.Infof("starting background mount: %s", cmd.Args)
+	if err := cmd.Start(); err != nil {
+		return 0, errors.Wrap(err, "start mount process")
+	}
+	pid := cmd.Process.Pid
+	if err := process.WritePidfile(localpath.MountPID(profile, m.Name), pid); err != nil {
+		return pid, errors.Wrap(err, "write pid file")
+	}
+	if err := WriteMountDaemonState(profile, &MountDaemonState{Name: m.Name, Pid: pid, Health: MountStarting}); err != nil {
+		klog.Warningf("unable to write state of mount %s: %v", m.Name, err)
+	}
+	return pid, cmd.Process.Release()
+}
+
+// WriteMountDaemonState saves the state of a background mount
+func WriteMountDaemonState(profile string, st *MountDaemonState) error {
+	b, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal")
+	}
+	path := localpath.MountState(profile, st.Name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return os.Rename(tmp, path)
+}
+
+// ReadMountDaemonState returns the state of a background mount, which is stopped if its process is not running
+func ReadMountDaemonState(profile, name string) *MountDaemonState {
+	st := &MountDaemonState{Name: name, Health: MountStopped}
+	pid, err := process.ReadPidfile(localpath.MountPID(profile, name))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			klog.Warningf("unable to read pid of mount %s: %v", name, err)
+		}
+		return st
+	}
+	if !daemonRunning(pid) {
+		return st
+	}
+	b, err := os.ReadFile(localpath.MountState(profile, name))
+	if err == nil {
+		err = json.Unmarshal(b, st)
+	}
+	if err != nil {
+		klog.Warningf("unable to read state of mount %s: %v", name, err)
+		st.Health = MountStarting
+	}
+	st.Pid = pid
+	return st
+}
+
+// StopMountDaemon stops the process of a background mount, killing it if it does not exit before the timeout.
+// It returns false if the process was not running.
+func StopMountDaemon(profile, name string, timeout time.Duration) (bool, error) {
+	st := ReadMountDaemonState(profile, name)
+	if st.Health == MountStopped {
+		RemoveMountDaemonFiles(profile, name)
+		return false, nil
+	}
+	p, err := os.FindProcess(st.Pid)
+	if err != nil {
+		return true, errors.Wrapf(err, "find process %d", st.Pid)
+	}
+	// the process unmounts the directory on interrupt, which is not supported on windows
+	if err := p.Signal(os.Interrupt); err != nil {
+		klog.Infof("unable to interrupt mount process %d, killing it: %v", st.Pid, err)
+		timeout = 0
+	}
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if !daemonRunning(st.Pid) {
+			RemoveMountDaemonFiles(profile, name)
+			return true, nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	if err := p.Kill(); err != nil && err != os.ErrProcessDone {
+		return true, errors.Wrapf(err, "kill process %d", st.Pid)
+	}
+	RemoveMountDaemonFiles(profile, name)
+	return true, nil
+}
+
+// RemoveMountDaemonFiles removes the pid and state files of a background mount, its log is kept for troubleshooting
+func RemoveMountDaemonFiles(profile, name string) {
+	for _, path := range []string{localpath.MountPID(profile, name), localpath.MountState(profile, name)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			klog.Warningf("unable to remove %s: %v", path, err)
+		}
+	}
+}
+
+// IsMounted returns whether the target is mounted with the filesystem type and responding, and an error if
+// the node cannot be reached. A 9p mount whose server went away stays mounted but fails on access.
+func IsMounted(r mountRunner, target, fsType string) (bool, error) {
+	// the target and type are arguments of the script, so that they are not interpreted by the shell
+	script := `if [ "$(findmnt -n -o FSTYPE -M "$1")" != "$2" ]; then echo unmounted; elif ! sudo timeout 5 ls "$1" >/dev/null 2>&1; then echo stale; else echo ok; fi`
+	rr, err := r.RunCmd(exec.Command("/bin/bash", "-c", script, "-", target, fsType))
+	if err != nil {
+		return false, errors.Wrap(err, "check mount")
+	}
+	status := strings.TrimSpace(rr.Stdout.String())
+	if status != "ok" {
+		klog.Infof("mount %s is %s", target, status)
+	}
+	return status == "ok", nil
+}
+
+// MountWatchdog checks a mount periodically, and mounts it again when it is gone, for example after the node
+// rebooted or the file server restarted
+type MountWatchdog struct {
+	// Interval is the delay between two checks
+	Interval time.Duration
+	// Check returns whether the mount is healthy, or an error if the node is unreachable
+	Check func() (bool, error)
+	// Remount mounts the directory again
+	Remount func() error
+	// Report is called with the health after each check
+	Report func(health string, err error)
+}
+
+// Run checks the mount until the context is done
+func (w *MountWatchdog) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		ok, err := w.Check()
+		if err != nil {
+			w.Report(MountUnreachable, err)
+			continue
+		}
+		if ok {
+			w.Report(MountHealthy, nil)
+			continue
+		}
+		w.Report(MountRemounting, nil)
+		if err := w.Remount(); err != nil {
+			w.Report(MountRemounting, err)
+			continue
+		}
+		w.Report(MountHealthy, nil)
+	}
+}
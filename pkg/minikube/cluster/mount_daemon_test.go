@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/process"
+)
+
+func TestMountDaemonArgs(t *testing.T) {
+	got := strings.Join(MountDaemonArgs("p1", config.ManagedMount{
+		Name: "src", Source: "/home/src", Target: "/src", Node: "p1-m02", Type: "9p", UID: "docker", GID: "docker",
+		Version: "9p2000.L", MSize: 262144, Options: []string{"cache=loose"},
+	}), " ")
+	want := "mount /home/src:/src --profile p1 --name src --daemon-child --type=9p --uid=docker --gid=docker --9p-version=9p2000.L --msize=262144 --port=0 --node p1-m02 --options=cache=loose"
+	if got != want {
+		t.Errorf("MountDaemonArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestReadMountDaemonState(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	if err := os.MkdirAll(filepath.Dir(localpath.MountPID("p1", "src")), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	running := map[int]bool{}
+	orig := daemonRunning
+	t.Cleanup(func() { daemonRunning = orig })
+	daemonRunning = func(pid int) bool { return running[pid] }
+
+	if st := ReadMountDaemonState("p1", "src"); st.Health != MountStopped {
+		t.Errorf("expected a mount without pid file to be stopped, got %+v", st)
+	}
+
+	if err := process.WritePidfile(localpath.MountPID("p1", "src"), 1234); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteMountDaemonState("p1", &MountDaemonState{Name: "src", Pid: 1234, Health: MountRemounting, Remounts: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if st := ReadMountDaemonState("p1", "src"); st.Health != MountStopped {
+		t.Errorf("expected a mount whose process exited to be stopped, got %+v", st)
+	}
+
+	running[1234] = true
+	st := ReadMountDaemonState("p1", "src")
+	if st.Health != MountRemounting || st.Pid != 1234 || st.Remounts != 2 {
+		t.Errorf("unexpected state: %+v", st)
+	}
+
+	if stopped, err := StopMountDaemon("p1", "other", 0); stopped || err != nil {
+		t.Errorf("StopMountDaemon of a mount that is not running = %v, %v", stopped, err)
+	}
+	running[1234] = false
+	if _, err := StopMountDaemon("p1", "src", 0); err != nil {
+		t.Fatalf("StopMountDaemon: %v", err)
+	}
+	if _, err := os.Stat(localpath.MountPID("p1", "src")); !os.IsNotExist(err) {
+		t.Errorf("expected the pid file to be removed: %v", err)
+	}
+}
+
+// localRunner runs the commands on the host
+type localRunner struct{}
+
+func (localRunner) RunCmd(cmd *exec.Cmd) (*command.RunResult, error) {
+	rr := &command.RunResult{Args: cmd.Args}
+	cmd.Stdout = &rr.Stdout
+	cmd.Stderr = &rr.Stderr
+	return rr, cmd.Run()
+}
+
+func TestIsMountedQuotesTarget(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "injected")
+	target := filepath.Join(dir, "mount point") + "; touch " + marker + "; echo $(touch " + marker + ")"
+
+	mounted, err := IsMounted(localRunner{}, target, "9p")
+	if err != nil {
+		t.Fatalf("IsMounted: %v", err)
+	}
+	if mounted {
+		t.Errorf("expected %q not to be mounted", target)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("expected the target not to be interpreted by the shell, %s exists", marker)
+	}
+}
+
+func TestMountWatchdog(t *testing.T) {
+	checks := []struct {
+		ok  bool
+		err error
+	}{
+		{ok: true},
+		{err: errors.New("connection refused")},
+		{ok: false},
+		{ok: true},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var reports []string
+	remounts := 0
+	w := &MountWatchdog{
+		Interval: time.Millisecond,
+		Check: func() (bool, error) {
+			c := checks[0]
+			if len(checks) > 1 {
+				checks = checks[1:]
+			} else {
+				cancel()
+			}
+			return c.ok, c.err
+		},
+		Remount: func() error {
+			remounts++
+			return nil
+		},
+		Report: func(health string, err error) {
+			if err != nil {
+				health += ": " + err.Error()
+			}
+			reports = append(reports, health)
+		},
+	}
+	w.Run(ctx)
+
+	want := []string{MountHealthy, MountUnreachable + ": connection refused", MountRemounting, MountHealthy, MountHealthy}
+	if diff := cmp.Diff(want, reports); diff != "" {
+		t.Errorf("unexpected reports (-want +got):\n%s", diff)
+	}
+	if remounts != 1 {
+		t.Errorf("expected a single remount, got %d", remounts)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultNotifyDebounce is how long changes are collected before being replayed in the node
+	DefaultNotifyDebounce = 200 * time.Millisecond
+	// replayBatchSize is the maximum number of paths replayed by a single command
+	replayBatchSize = 200
+)
+
+// DefaultNotifyIgnore are the paths of the host directory whose changes are not replayed by default
+var DefaultNotifyIgnore = []string{".git", "*.swp", "*~"}
+
+// replayScript generates inotify events in the node for the paths given as arguments: files are opened for
+// writing, which is reported as IN_CLOSE_WRITE, and the parent directory of removed files is touched.
+const replayScript = `for f in "$@"; do if [ -f "$f" ]; then touch -c "$f" && : >> "$f"; elif [ -e "$f" ]; then touch -c "$f"; else touch -c "$(dirname "$f")"; fi; done`
+
+// MountNotifier watches the host directory of a 9p mount, and replays its changes in the node
+// where the 9p client does not report them to inotify
+type MountNotifier struct {
+	// Source is the host directory
+	Source string
+	// Target is the directory of the node
+	Target string
+	// Ignore are globs of the paths relative to the source to ignore, a glob without '/' matches any path element
+	Ignore []string
+	// Debounce is how long changes are collected before being replayed
+	Debounce time.Duration
+	// Replay generates the events for the paths of the node
+	Replay func(paths []string) error
+}
+
+// Run watches the source directory until the context is done
+func (n *MountNotifier) Run(ctx context.Context) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "create watcher")
+	}
+	defer w.Close()
+	if err := n.watchTree(w, n.Source); err != nil {
+		return err
+	}
+	klog.Infof("replaying the changes of %s in %s", n.Source, n.Target)
+
+	pending := map[string]bool{}
+	timer := time.NewTimer(n.Debounce)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			klog.Warningf("error watching %s: %v", n.Source, err)
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			target, ok := n.handle(w, ev)
+			if !ok {
+				continue
+			}
+			pending[target] = true
+			timer.Reset(n.Debounce)
+		case <-timer.C:
+			paths := []string{}
+			for p := range pending {
+				paths = append(paths, p)
+			}
+			sort.Strings(paths)
+			pending = map[string]bool{}
+			if err := n.Replay(paths); err != nil {
+				klog.Warningf("unable to replay changes of %v: %v", paths, err)
+			}
+		}
+	}
+}
+
+// handle returns the path of the node to replay an event at, and watches the new directories
+func (n *MountNotifier) handle(w *fsnotify.Watcher, ev fsnotify.Event) (string, bool) {
+	// changes of attributes are also caused by the replay itself
+	if !ev.Has(fsnotify.Create) && !ev.Has(fsnotify.Write) && !ev.Has(fsnotify.Remove) && !ev.Has(fsnotify.Rename) {
+		return "", false
+	}
+	rel, err := filepath.Rel(n.Source, ev.Name)
+	if err != nil {
+		return "", false
+	}
+	rel = filepath.ToSlash(rel)
+	if n.ignored(rel) {
+		return "", false
+	}
+	if ev.Has(fsnotify.Create) {
+		if fi, err := os.Lstat(ev.Name); err == nil && fi.IsDir() {
+			if err := n.watchTree(w, ev.Name); err != nil {
+				klog.Warningf("unable to watch %s: %v", ev.Name, err)
+			}
+		}
+	}
+	return path.Join(n.Target, rel), true
+}
+
+// watchTree watches a directory and its subdirectories, except the ignored ones
+func (n *MountNotifier) watchTree(w *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// the directory might be gone already
+			klog.Infof("not watching %s: %v", p, err)
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if rel, err := filepath.Rel(n.Source, p); err == nil && rel != "." && n.ignored(filepath.ToSlash(rel)) {
+			return filepath.SkipDir
+		}
+		if err := w.Add(p); err != nil {
+			return errors.Wrapf(err, "watch %s", p)
+		}
+		return nil
+	})
+}
+
+// ignored returns whether a slash-separated path relative to the source matches an ignore glob
+func (n *MountNotifier) ignored(rel string) bool {
+	for _, glob := range n.Ignore {
+		if !strings.Contains(glob, "/") {
+			for _, elem := range strings.Split(rel, "/") {
+				if ok, _ := path.Match(glob, elem); ok {
+					return true
+				}
+			}
+			continue
+		}
+		glob = strings.TrimPrefix(glob, "/")
+		if prefix, ok := strings.CutSuffix(glob, "/**"); ok {
+			if rel == prefix || strings.HasPrefix(rel, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(glob, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplayChanges generates inotify events for the paths of the node
+func ReplayChanges(r mountRunner, paths []string) error {
+	for len(paths) > 0 {
+		batch := paths
+		if len(batch) > replayBatchSize {
+			batch = batch[:replayBatchSize]
+		}
+		paths = paths[len(batch):]
+		args := append([]string{"/bin/bash", "-c", replayScript, "replay"}, batch...)
+		if _, err := r.RunCmd(exec.Command("sudo", args...)); err != nil {
+			return errors.Wrap(err, "replay changes")
+		}
+	}
+	return nil
+}
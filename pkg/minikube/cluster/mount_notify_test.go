@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/command"
+)
+
+func TestMountNotifierIgnored(t *testing.T) {
+	n := &MountNotifier{Ignore: []string{".git", "*.swp", "node_modules/**", "build/*.o"}}
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: ".git", want: true},
+		{path: ".git/index", want: true},
+		{path: "src/.main.go.swp", want: true},
+		{path: "node_modules/react/index.js", want: true},
+		{path: "web/node_modules/react/index.js", want: false},
+		{path: "build/main.o", want: true},
+		{path: "build/sub/main.o", want: false},
+		{path: "src/main.go", want: false},
+	}
+	for _, tc := range tests {
+		if got := n.ignored(tc.path); got != tc.want {
+			t.Errorf("ignored(%q) = %v, want %v", tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestMountNotifierReplaysChanges(t *testing.T) {
+	src := t.TempDir()
+	if err := os.Mkdir(filepath.Join(src, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	replayed := make(chan []string, 10)
+	n := &MountNotifier{
+		Source:   src,
+		Target:   "/src",
+		Ignore:   DefaultNotifyIgnore,
+		Debounce: 50 * time.Millisecond,
+		Replay: func(paths []string) error {
+			replayed <- paths
+			return nil
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- n.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run: %v", err)
+		}
+	}()
+
+	next := func() []string {
+		t.Helper()
+		select {
+		case paths := <-replayed:
+			return paths
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for changes to be replayed")
+		}
+		return nil
+	}
+
+	// wait for the watcher to be ready, the changes are collected into a single replay
+	deadline := time.Now().Add(10 * time.Second)
+	var paths []string
+	for len(paths) == 0 && time.Now().Before(deadline) {
+		if err := os.WriteFile(filepath.Join(src, "main.go"), []byte("package main"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(src, ".git", "index"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case paths = <-replayed:
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	if diff := cmp.Diff([]string{"/src/main.go"}, paths); diff != "" {
+		t.Errorf("unexpected replayed paths (-want +got):\n%s", diff)
+	}
+
+	// new directories are watched
+	if err := os.Mkdir(filepath.Join(src, "pkg"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"/src/pkg"}, next()); diff != "" {
+		t.Errorf("unexpected replayed paths (-want +got):\n%s", diff)
+	}
+	if err := os.WriteFile(filepath.Join(src, "pkg", "lib.go"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"/src/pkg/lib.go"}, next()); diff != "" {
+		t.Errorf("unexpected replayed paths (-want +got):\n%s", diff)
+	}
+}
+
+// recordingRunner records the arguments of the commands
+type recordingRunner struct {
+	args [][]string
+}
+
+func (r *recordingRunner) RunCmd(cmd *exec.Cmd) (*command.RunResult, error) {
+	r.args = append(r.args, cmd.Args)
+	return &command.RunResult{}, nil
+}
+
+func TestReplayChanges(t *testing.T) {
+	paths := []string{}
+	for i := 0; i < replayBatchSize+1; i++ {
+		paths = append(paths, fmt.Sprintf("/src/file %d", i))
+	}
+	r := &recordingRunner{}
+	if err := ReplayChanges(r, paths); err != nil {
+		t.Fatalf("ReplayChanges: %v", err)
+	}
+	if len(r.args) != 2 {
+		t.Fatalf("expected 2 commands, got %d", len(r.args))
+	}
+	if r.args[0][0] != "sudo" || r.args[0][4] != "replay" || len(r.args[0]) != 5+replayBatchSize {
+		t.Errorf("unexpected command: %v", r.args[0][:6])
+	}
+	if diff := cmp.Diff([]string{fmt.Sprintf("/src/file %d", replayBatchSize)}, r.args[1][5:]); diff != "" {
+		t.Errorf("unexpected second batch (-want +got):\n%s", diff)
+	}
+}
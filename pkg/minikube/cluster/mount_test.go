@@ -17,9 +17,11 @@ limitations under the License.
 package cluster
 
 import (
+	"os/exec"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/command"
 )
 
 func TestMntCmd(t *testing.T) {
@@ -75,3 +77,35 @@ func TestMntCmd(t *testing.T) {
 		})
 	}
 }
+
+// ownerRunner runs the commands resolving the owner with a fixed output
+type ownerRunner struct {
+	cmd    string
+	output string
+}
+
+func (r *ownerRunner) RunCmd(cmd *exec.Cmd) (*command.RunResult, error) {
+	r.cmd = cmd.Args[2]
+	rr := &command.RunResult{Args: cmd.Args}
+	rr.Stdout.WriteString(r.output)
+	return rr, nil
+}
+
+func TestMountOwner(t *testing.T) {
+	r := &ownerRunner{output: "1000 999\n"}
+	uid, gid, err := MountOwner(r, &MountConfig{UID: "docker", GID: "docker"})
+	if err != nil {
+		t.Fatalf("MountOwner: %v", err)
+	}
+	if uid != 1000 || gid != 999 {
+		t.Errorf("MountOwner() = %d:%d, want 1000:999", uid, gid)
+	}
+	if want := "echo $(id -u docker) $(grep ^docker: /etc/group | cut -d: -f3)"; r.cmd != want {
+		t.Errorf("MountOwner ran %q, want %q", r.cmd, want)
+	}
+
+	r = &ownerRunner{output: "\n"}
+	if _, _, err := MountOwner(r, &MountConfig{UID: "missing", GID: "missing"}); err == nil {
+		t.Errorf("MountOwner() of a missing user succeeded")
+	}
+}
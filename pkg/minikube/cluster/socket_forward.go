@@ -0,0 +1,300 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/process"
+)
+
+// ForwardedSocket is a unix socket of a node, forwarded to a local unix socket
+type ForwardedSocket struct {
+	Local  string
+	Remote string
+}
+
+// SocketDialer opens a connection to a unix socket of the node
+type SocketDialer func(remote string) (io.ReadWriteCloser, error)
+
+// SocketForwarder forwards the connections to local unix sockets to the sockets of a node
+type SocketForwarder struct {
+	Sockets []ForwardedSocket
+	Dial    SocketDialer
+}
+
+// Run serves the local sockets until the context is done
+func (f *SocketForwarder) Run(ctx context.Context) error {
+	listeners := []net.Listener{}
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
+
+	for _, s := range f.Sockets {
+		if err := os.MkdirAll(filepath.Dir(s.Local), 0o700); err != nil {
+			return errors.Wrap(err, "create sockets directory")
+		}
+		// the socket of a previous forwarder which did not exit cleanly
+		if err := os.Remove(s.Local); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "remove %s", s.Local)
+		}
+		l, err := net.Listen("unix", s.Local)
+		if err != nil {
+			return errors.Wrapf(err, "listen on %s", s.Local)
+		}
+		listeners = append(listeners, l)
+	}
+
+	go func() {
+		<-ctx.Done()
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i, l := range listeners {
+		wg.Add(1)
+		go func(l net.Listener, remote string) {
+			defer wg.Done()
+			for {
+				c, err := l.Accept()
+				if err != nil {
+					if ctx.Err() == nil {
+						klog.Warningf("stopped accepting connections to %s: %v", l.Addr(), err)
+					}
+					return
+				}
+				go f.forward(c, remote)
+			}
+		}(l, f.Sockets[i].Remote)
+	}
+	wg.Wait()
+	return nil
+}
+
+// forward copies the data of a local connection to a new connection to the remote socket, both ways
+func (f *SocketForwarder) forward(local net.Conn, remote string) {
+	defer local.Close()
+	rc, err := f.Dial(remote)
+	if err != nil {
+		klog.Warningf("unable to connect to %s: %v", remote, err)
+		return
+	}
+	defer rc.Close()
+
+	go func() {
+		if _, err := io.Copy(rc, local); err != nil {
+			klog.V(2).Infof("forwarding to %s: %v", remote, err)
+		}
+		// the end of the requests is forwarded, the responses may still come
+		if cw, ok := rc.(interface{ CloseWrite() error }); ok {
+			_ = cw.CloseWrite()
+		}
+	}()
+	if _, err := io.Copy(local, rc); err != nil {
+		klog.V(2).Infof("forwarding from %s: %v", remote, err)
+	}
+}
+
+// SSHSocketDialer connects to the sockets of a node with SSH sessions running socat as root,
+// as the sockets of the container runtimes are not accessible to the SSH user
+type SSHSocketDialer struct {
+	// NewClient connects to the node, it is called again once the connection is broken
+	NewClient func() (*ssh.Client, error)
+
+	mu     sync.Mutex
+	client *ssh.Client
+}
+
+// Dial implements SocketDialer
+func (d *SSHSocketDialer) Dial(remote string) (io.ReadWriteCloser, error) {
+	for attempt := 0; ; attempt++ {
+		client, err := d.connect()
+		if err != nil {
+			return nil, errors.Wrap(err, "connect to the node")
+		}
+
+		sess, err := client.NewSession()
+		if err != nil {
+			// the connection is broken, the node may have been restarted
+			d.reset(client)
+			if attempt == 0 {
+				continue
+			}
+			return nil, errors.Wrap(err, "new session")
+		}
+
+		c, err := startSocketSession(sess, remote)
+		if err != nil {
+			sess.Close()
+			return nil, err
+		}
+		return c, nil
+	}
+}
+
+func (d *SSHSocketDialer) connect() (*ssh.Client, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.client != nil {
+		return d.client, nil
+	}
+
+	client, err := d.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	d.client = client
+	return client, nil
+}
+
+func (d *SSHSocketDialer) reset(client *ssh.Client) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.client == client {
+		d.client.Close()
+		d.client = nil
+	}
+}
+
+// sessionConn is a connection to a socket through the input and output of socat
+type sessionConn struct {
+	sess   *ssh.Session
+	stdin  io.WriteCloser
+	stdout io.Reader
+}
+
+func startSocketSession(sess *ssh.Session, remote string) (*sessionConn, error) {
+	stdin, err := sess.StdinPipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "stdin")
+	}
+	stdout, err := sess.StdoutPipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "stdout")
+	}
+	if err := sess.Start(fmt.Sprintf("sudo socat STDIO UNIX-CONNECT:%s", remote)); err != nil {
+		return nil, errors.Wrap(err, "start socat")
+	}
+	return &sessionConn{sess: sess, stdin: stdin, stdout: stdout}, nil
+}
+
+func (c *sessionConn) Read(b []byte) (int, error)  { return c.stdout.Read(b) }
+func (c *sessionConn) Write(b []byte) (int, error) { return c.stdin.Write(b) }
+
+// CloseWrite closes the input of socat, which shuts the socket down for writing
+func (c *sessionConn) CloseWrite() error { return c.stdin.Close() }
+
+func (c *sessionConn) Close() error {
+	c.stdin.Close()
+	return c.sess.Close()
+}
+
+// SocketForwardDaemonRunning returns whether the process forwarding the sockets name of profile is running
+func SocketForwardDaemonRunning(profile, name string) bool {
+	pid, err := process.ReadPidfile(localpath.SocketForwardPID(profile, name))
+	if err != nil {
+		return false
+	}
+	return daemonRunning(pid)
+}
+
+// StartSocketForwardDaemon starts a minikube process with args forwarding the sockets name of
+// profile in the background, and waits until the local socket accepts connections
+func StartSocketForwardDaemon(profile, name string, args []string, socket string, timeout time.Duration) error {
+	pidPath := localpath.SocketForwardPID(profile, name)
+	if err := os.MkdirAll(filepath.Dir(pidPath), 0o700); err != nil {
+		return errors.Wrap(err, "create sockets directory")
+	}
+	logFile, err := os.OpenFile(localpath.SocketForwardLog(profile, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "open log file")
+	}
+	defer logFile.Close()
+
+	path, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "find minikube executable")
+	}
+	cmd := exec.Command(path, args...)
+	cmd.Env = append(os.Environ(), constants.IsMinikubeChildProcess+"=true")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	detachProcess(cmd)
+	klog.Infof("starting socket forwarding: %s", cmd.Args)
+	if err := cmd.Start(); err != nil {
+		return errors.Wrap(err, "start forwarding process")
+	}
+	if err := process.WritePidfile(pidPath, cmd.Process.Pid); err != nil {
+		return errors.Wrap(err, "write pid file")
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if c, err := net.Dial("unix", socket); err == nil {
+			c.Close()
+			return nil
+		}
+		select {
+		case err := <-exited:
+			return errors.Errorf("forwarding process exited: %v, see %s", err, localpath.SocketForwardLog(profile, name))
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	return errors.Errorf("timed out waiting for %s, see %s", socket, localpath.SocketForwardLog(profile, name))
+}
+
+// StopSocketForwardDaemon stops the process forwarding the sockets name of profile, if it is running
+func StopSocketForwardDaemon(profile, name string) error {
+	pidPath := localpath.SocketForwardPID(profile, name)
+	pid, err := process.ReadPidfile(pidPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if daemonRunning(pid) {
+		p, err := os.FindProcess(pid)
+		if err != nil {
+			return errors.Wrapf(err, "find process %d", pid)
+		}
+		if err := p.Kill(); err != nil {
+			return errors.Wrapf(err, "kill process %d", pid)
+		}
+	}
+	return os.Remove(pidPath)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSocketForwarder(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets are not supported by the test on windows")
+	}
+
+	dir, err := os.MkdirTemp("", "fwd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	local := filepath.Join(dir, "sockets", "containerd.sock")
+	// a stale socket of a previous forwarder
+	if err := os.MkdirAll(filepath.Dir(local), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(local, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// the socket of the node answers the requests in upper case, once they are complete
+	remote, err := net.Listen("unix", filepath.Join(dir, "remote.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer remote.Close()
+	go func() {
+		for {
+			s, err := remote.Accept()
+			if err != nil {
+				return
+			}
+			b, _ := io.ReadAll(s)
+			_, _ = s.Write(bytes.ToUpper(b))
+			s.Close()
+		}
+	}()
+
+	dialed := make(chan string, 1)
+	f := &SocketForwarder{
+		Sockets: []ForwardedSocket{{Local: local, Remote: "/run/containerd/containerd.sock"}},
+		Dial: func(r string) (io.ReadWriteCloser, error) {
+			dialed <- r
+			return net.Dial("unix", remote.Addr().String())
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- f.Run(ctx) }()
+
+	var c net.Conn
+	for i := 0; i < 50; i++ {
+		if c, err = net.Dial("unix", local); err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("connect to the forwarded socket: %v", err)
+	}
+
+	if _, err := c.Write([]byte("version")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.(*net.UnixConn).CloseWrite(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(c)
+	c.Close()
+	if err != nil {
+		t.Fatalf("read the response: %v", err)
+	}
+	if string(got) != "VERSION" {
+		t.Errorf("response = %q, want VERSION", got)
+	}
+	if remote := <-dialed; remote != "/run/containerd/containerd.sock" {
+		t.Errorf("dialed %s, want /run/containerd/containerd.sock", remote)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() = %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run() did not return once cancelled")
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/pkg/errors"
 
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
@@ -218,6 +219,10 @@ func GetState(sts []*Status, profile string, cc *config.ClusterConfig) State {
 			"kubeconfig": {Name: "kubeconfig", StatusCode: statusCode(sts[0].Kubeconfig), StatusName: codeNames[statusCode(sts[0].Kubeconfig)]},
 		},
 	}
+	if config.IsHAProxy(*cc) {
+		lb := statusCode(LoadBalancerStatus(*cc))
+		cs.Components["loadbalancer"] = BaseState{Name: oci.LoadBalancerName(cc.Name), StatusCode: lb, StatusName: codeNames[lb]}
+	}
 	healthyCPs := 0
 	for _, st := range sts {
 		ns := NodeState{
@@ -353,6 +358,16 @@ func GetState(sts []*Status, profile string, cc *config.ClusterConfig) State {
 	return cs
 }
 
+// LoadBalancerStatus returns the status of the haproxy load balancer of an HA cluster created with --ha-lb=haproxy
+func LoadBalancerStatus(cc config.ClusterConfig) string {
+	st, err := oci.ContainerStatus(cc.Driver, oci.LoadBalancerName(cc.Name))
+	if err != nil {
+		klog.Infof("load balancer status: %v", err)
+		return Nonexistent
+	}
+	return st.String()
+}
+
 // NodeStatus looks up the status of a node
 func NodeStatus(api libmachine.API, cc config.ClusterConfig, n config.Node) (*Status, error) {
 	controlPlane := n.ControlPlane
@@ -454,7 +469,7 @@ func NodeStatus(api libmachine.API, cc config.ClusterConfig, n config.Node) (*St
 	if err != nil {
 		klog.Errorf("forwarded endpoint: %v", err)
 		st.Kubeconfig = Misconfigured
-	} else if err := kubeconfig.VerifyEndpoint(cc.Name, hostname, port, ""); err != nil && st.Host != state.Starting.String() {
+	} else if err := kubeconfig.VerifyEndpoint(cc.Name, hostname, port, config.KubeconfigPath(&cc)); err != nil && st.Host != state.Starting.String() {
 		klog.Errorf("kubeconfig endpoint: %v", err)
 		st.Kubeconfig = Misconfigured
 	}
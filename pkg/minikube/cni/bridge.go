@@ -49,7 +49,13 @@ var bridgeConf = template.Must(template.New("bridge").Parse(`
       "hairpinMode": true,
       "ipam": {
           "type": "host-local",
+{{- if gt (len .PodCIDRs) 1}}
+          "ranges": [{{range $i, $cidr := .PodCIDRs}}{{if $i}},{{end}}
+              [{ "subnet": "{{$cidr}}" }]{{end}}
+          ]
+{{- else}}
           "subnet": "{{.PodCIDR}}"
+{{- end}}
       }
     },
     {
@@ -76,7 +82,8 @@ func (c Bridge) String() string {
 }
 
 func (c Bridge) netconf() (assets.CopyableFile, error) {
-	input := &tmplInput{PodCIDR: DefaultPodCIDR}
+	cidrs := PodCIDRs(c.cc.KubernetesConfig, DefaultPodCIDR)
+	input := &tmplInput{PodCIDR: cidrs[0], PodCIDRs: cidrs}
 
 	b := bytes.Buffer{}
 	if err := bridgeConf.Execute(&b, input); err != nil {
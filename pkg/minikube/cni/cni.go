@@ -44,8 +44,14 @@ const (
 	// DefaultPodCIDR is the default CIDR to use in minikube CNI's.
 	DefaultPodCIDR = "10.244.0.0/16"
 
+	// DefaultPodCIDRv6 is the default IPv6 CIDR to use in minikube CNI's for IPv6 and dual-stack clusters.
+	DefaultPodCIDRv6 = "fd00:10:244::/56"
+
 	// DefaultConfDir is the default CNI Config Directory path
 	DefaultConfDir = "/etc/cni/net.d"
+
+	// binDir is the CNI plugin binaries directory path
+	binDir = "/opt/cni/bin"
 )
 
 // Runner is the subset of command.Runner this package consumes
@@ -70,6 +76,7 @@ type Manager interface {
 type tmplInput struct {
 	ImageName    string
 	PodCIDR      string
+	PodCIDRs     []string
 	DefaultRoute string
 	CNIConfDir   string
 }
@@ -107,6 +114,22 @@ func New(cc *config.ClusterConfig) (Manager, error) {
 	return cnm, err
 }
 
+// PodCIDRs returns the pod subnets of the cluster for its IP families given the IPv4 one, the primary family first
+func PodCIDRs(k config.KubernetesConfig, cidr string) []string {
+	cidrs := []string{}
+	if config.IPv4Enabled(k) {
+		cidrs = append(cidrs, cidr)
+	}
+	if config.IPv6Enabled(k) {
+		v6 := k.PodCIDRv6
+		if v6 == "" {
+			v6 = DefaultPodCIDRv6
+		}
+		cidrs = append(cidrs, v6)
+	}
+	return cidrs
+}
+
 // IsDisabled checks if CNI is disabled
 func IsDisabled(cc config.ClusterConfig) bool {
 	if cc.KubernetesConfig.NetworkPlugin != "" && cc.KubernetesConfig.NetworkPlugin != "cni" {
@@ -17,8 +17,12 @@ limitations under the License.
 package cni
 
 import (
+	"encoding/json"
+	"io"
+	"strings"
 	"testing"
 
+	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/config"
 )
 
@@ -52,3 +56,66 @@ func TestChooseDefault(t *testing.T) {
 		}
 	}
 }
+
+func TestCleanupNode(t *testing.T) {
+	r := command.NewFakeCommandRunner()
+	r.SetCommandToOutput(map[string]string{
+		"sudo rm -f /etc/cni/net.d/10-flannel.conflist /opt/cni/bin/flannel": "",
+		"sudo ip link delete flannel.1":                                      "",
+		`sudo find /etc/cni/net.d -maxdepth 1 -type f ( ( -name *bridge* -or -name *podman* ) -and -not -name *.mk_disabled ) -printf "%p, " -exec sh -c "sudo mv {} {}.mk_disabled" ;`: "/etc/cni/net.d/100-crio-bridge.conf, ",
+	})
+	// cni0 is not registered: a missing link must not fail the cleanup
+	if err := CleanupNode(r, Flannel{}); err != nil {
+		t.Errorf("CleanupNode(Flannel) = %v", err)
+	}
+
+	// nothing is left behind by a disabled CNI except default bridges
+	if err := CleanupNode(r, Disabled{}); err != nil {
+		t.Errorf("CleanupNode(Disabled) = %v", err)
+	}
+}
+
+func TestPodCIDRs(t *testing.T) {
+	tests := []struct {
+		k    config.KubernetesConfig
+		want []string
+	}{
+		{config.KubernetesConfig{}, []string{DefaultPodCIDR}},
+		{config.KubernetesConfig{IPFamily: "ipv4"}, []string{DefaultPodCIDR}},
+		{config.KubernetesConfig{IPFamily: "ipv6"}, []string{DefaultPodCIDRv6}},
+		{config.KubernetesConfig{IPFamily: "dual", PodCIDRv6: "fd00:1::/56"}, []string{DefaultPodCIDR, "fd00:1::/56"}},
+	}
+	for _, tc := range tests {
+		got := PodCIDRs(tc.k, DefaultPodCIDR)
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("PodCIDRs(%q) = %v; want = %v", tc.k.IPFamily, got, tc.want)
+		}
+	}
+}
+
+func TestBridgeDualStack(t *testing.T) {
+	f, err := Bridge{cc: config.ClusterConfig{KubernetesConfig: config.KubernetesConfig{IPFamily: "dual"}}}.netconf()
+	if err != nil {
+		t.Fatalf("netconf: %v", err)
+	}
+	b, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("reading netconf: %v", err)
+	}
+	var conf struct {
+		Plugins []struct {
+			IPAM struct {
+				Ranges [][]struct {
+					Subnet string `json:"subnet"`
+				} `json:"ranges"`
+			} `json:"ipam"`
+		} `json:"plugins"`
+	}
+	if err := json.Unmarshal(b, &conf); err != nil {
+		t.Fatalf("netconf is not valid json: %v\n%s", err, b)
+	}
+	ranges := conf.Plugins[0].IPAM.Ranges
+	if len(ranges) != 2 || ranges[0][0].Subnet != DefaultPodCIDR || ranges[1][0].Subnet != DefaultPodCIDRv6 {
+		t.Errorf("unexpected ipam ranges: %+v", ranges)
+	}
+}
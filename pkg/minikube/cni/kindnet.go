@@ -19,6 +19,7 @@ package cni
 import (
 	"bytes"
 	"os/exec"
+	"strings"
 	"text/template"
 
 	"github.com/pkg/errors"
@@ -172,11 +173,16 @@ func (c KindNet) String() string {
 
 // manifest returns a Kubernetes manifest for a CNI
 func (c KindNet) manifest() (assets.CopyableFile, error) {
+	defaultRoute := "0.0.0.0/0"
+	if !config.IPv4Enabled(c.cc.KubernetesConfig) {
+		defaultRoute = "::/0"
+	}
 	input := &tmplInput{
-		DefaultRoute: "0.0.0.0/0", // assumes IPv4
-		PodCIDR:      DefaultPodCIDR,
-		ImageName:    images.KindNet(c.cc.KubernetesConfig.ImageRepository),
-		CNIConfDir:   DefaultConfDir,
+		DefaultRoute: defaultRoute,
+		// kindnet takes a comma separated list of subnets for dual-stack clusters
+		PodCIDR:    strings.Join(PodCIDRs(c.cc.KubernetesConfig, DefaultPodCIDR), ","),
+		ImageName:  images.KindNet(c.cc.KubernetesConfig.ImageRepository),
+		CNIConfDir: DefaultConfDir,
 	}
 
 	b := bytes.Buffer{}
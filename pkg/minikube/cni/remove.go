@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cni
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+// manifest returns the Kubernetes manifest a CNI manager applies, or nil if it does not apply one
+func manifest(cnm Manager) (assets.CopyableFile, error) {
+	switch c := cnm.(type) {
+	case Calico:
+		return c.manifest()
+	case Cilium:
+		b, err := c.GenerateCiliumYAML()
+		if err != nil {
+			return nil, err
+		}
+		return manifestAsset(b), nil
+	case Flannel:
+		return c.manifest()
+	case KindNet:
+		return c.manifest()
+	case Custom:
+		return assets.NewFileAsset(c.manifest, path.Dir(manifestPath()), path.Base(manifestPath()), "0644")
+	}
+	return nil, nil
+}
+
+// nodeLeftovers returns the config files, network interfaces and plugin binaries a CNI manager leaves on every node.
+// The standard plugins shipped with the node image, which bridge and kindnet use, are not leftovers.
+func nodeLeftovers(cnm Manager) (confs []string, links []string, bins []string) {
+	switch cnm.(type) {
+	case Bridge:
+		return []string{"1-k8s.conflist"}, []string{"bridge"}, nil
+	case Calico:
+		return []string{"10-calico.conflist", "calico-kubeconfig"}, []string{"tunl0", "vxlan.calico"}, []string{"calico", "calico-ipam"}
+	case Cilium:
+		return []string{"05-cilium.conflist"}, []string{"cilium_host", "cilium_net", "cilium_vxlan"}, []string{"cilium-cni"}
+	case Flannel:
+		return []string{"10-flannel.conflist"}, []string{"flannel.1", "cni0"}, []string{"flannel"}
+	case KindNet:
+		return []string{"10-kindnet.conflist"}, nil, nil
+	}
+	return nil, nil, nil
+}
+
+// Remove deletes the Kubernetes objects applied by a CNI manager. The provided runner is for the control plane
+func Remove(cc config.ClusterConfig, cnm Manager, r Runner) error {
+	m, err := manifest(cnm)
+	if err != nil {
+		return errors.Wrap(err, "manifest")
+	}
+	if m == nil {
+		klog.Infof("%s does not apply a manifest - nothing to delete", cnm)
+		return nil
+	}
+	if c, ok := m.(*assets.FileAsset); ok {
+		defer func() {
+			if err := c.Close(); err != nil {
+				klog.Warningf("error closing the file %s: %v", c.GetSourcePath(), err)
+			}
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	kubectl := kapi.KubectlBinaryPath(cc.KubernetesConfig.KubernetesVersion)
+	klog.Infof("deleting %s manifest using %s ...", cnm, kubectl)
+
+	if err := r.Copy(m); err != nil {
+		return errors.Wrapf(err, "copy")
+	}
+
+	cmd := exec.CommandContext(ctx, "sudo", kubectl, "delete", "--ignore-not-found", "--wait=true", fmt.Sprintf("--kubeconfig=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig")), "-f", manifestPath())
+	if rr, err := r.RunCmd(cmd); err != nil {
+		return errors.Wrapf(err, "cmd: %s output: %s", rr.Command(), rr.Output())
+	}
+	return nil
+}
+
+// CleanupNode removes the config files, network interfaces and plugin binaries a CNI manager left on a node (designated by runner),
+// and disables all default bridge CNIs so they do not take over before the next CNI is ready.
+func CleanupNode(r Runner, cnm Manager) error {
+	confs, links, bins := nodeLeftovers(cnm)
+	if len(confs) > 0 || len(bins) > 0 {
+		paths := []string{}
+		for _, c := range confs {
+			paths = append(paths, path.Join(DefaultConfDir, c))
+		}
+		for _, b := range bins {
+			paths = append(paths, path.Join(binDir, b))
+		}
+		if _, err := r.RunCmd(exec.Command("sudo", append([]string{"rm", "-f"}, paths...)...)); err != nil {
+			return fmt.Errorf("failed to remove %s cni configs and binaries: %v", cnm, err)
+		}
+		klog.Infof("removed [%s] cni config(s) and binaries", strings.Join(paths, ", "))
+	}
+	for _, l := range links {
+		// links only exist once the cni has configured at least one pod, so a missing link is fine
+		if _, err := r.RunCmd(exec.Command("sudo", "ip", "link", "delete", l)); err != nil {
+			klog.Infof("unable to delete %q link (most likely it does not exist): %v", l, err)
+		}
+	}
+	return disableAllBridgeCNIs(r)
+}
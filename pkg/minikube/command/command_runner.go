@@ -17,7 +17,6 @@ limitations under the License.
 package command
 
 import (
-	"bufio"
 	"bytes"
 	"fmt"
 	"io"
@@ -85,6 +84,25 @@ type Runner interface {
 	ReadableFile(sourcePath string) (assets.ReadableFile, error)
 }
 
+// streamWriter is an output of a command written to its writer only
+type streamWriter struct {
+	io.Writer
+}
+
+// Stream makes the runners write the output of a command to w only, without keeping it in the RunResult
+// and in the errors, for large or binary outputs like archives
+func Stream(w io.Writer) io.Writer {
+	return &streamWriter{w}
+}
+
+// outputWriter returns the writer of an output of a command, which is also kept in buf unless it is streamed
+func outputWriter(w io.Writer, buf *bytes.Buffer) io.Writer {
+	if sw, ok := w.(*streamWriter); ok {
+		return sw
+	}
+	return io.MultiWriter(w, buf)
+}
+
 // Command returns a human readable command string that does not induce eye fatigue
 func (rr RunResult) Command() string {
 	var sb strings.Builder
@@ -113,32 +131,55 @@ func (rr RunResult) Output() string {
 
 // teePrefix copies bytes from a reader to writer, logging each new line.
 func teePrefix(prefix string, r io.Reader, w io.Writer, logger func(format string, args ...interface{})) error {
-	logMutex.Lock()
-	defer logMutex.Unlock()
-
-	scanner := bufio.NewScanner(r)
-	scanner.Split(bufio.ScanBytes)
+	// streamed outputs are not logged, they are not made of lines
+	if sw, ok := w.(*streamWriter); ok {
+		_, err := io.Copy(sw, r)
+		return err
+	}
+	buf := make([]byte, 32*1024)
 	var line bytes.Buffer
 
-	for scanner.Scan() {
-		b := scanner.Bytes()
+	// the lock is held for each read only, so that the outputs of concurrent commands are streamed
+	tee := func(b []byte) error {
+		logMutex.Lock()
+		defer logMutex.Unlock()
 		if _, err := w.Write(b); err != nil {
 			return err
 		}
-		if bytes.IndexAny(b, "\r\n") == 0 {
-			if line.Len() > 0 {
-				logger("%s%s", prefix, line.String())
-				line.Reset()
+		for _, c := range b {
+			if c == '\r' || c == '\n' {
+				if line.Len() > 0 {
+					logger("%s%s", prefix, line.String())
+					line.Reset()
+				}
+				continue
+			}
+			line.WriteByte(c)
+		}
+		return nil
+	}
+
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := tee(buf[:n]); err != nil {
+				return err
 			}
-			continue
 		}
-		line.Write(b)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
 	}
 	// Catch trailing output in case stream does not end with a newline
+	logMutex.Lock()
+	defer logMutex.Unlock()
 	if line.Len() > 0 {
 		logger("%s%s", prefix, line.String())
 	}
-	return scanner.Err()
+	return nil
 }
 
 // fileExists checks that the same file exists on the other end
@@ -59,7 +59,7 @@ func (e *execRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
 		var so bytes.Buffer
 		outb = io.MultiWriter(&so, &rr.Stdout)
 	} else {
-		outb = io.MultiWriter(cmd.Stdout, &rr.Stdout)
+		outb = outputWriter(cmd.Stdout, &rr.Stdout)
 	}
 
 	if cmd.Stderr == nil {
@@ -101,7 +101,7 @@ func (*execRunner) StartCmd(cmd *exec.Cmd) (*StartedCmd, error) {
 		var so bytes.Buffer
 		outb = io.MultiWriter(&so, &rr.Stdout)
 	} else {
-		outb = io.MultiWriter(cmd.Stdout, &rr.Stdout)
+		outb = outputWriter(cmd.Stdout, &rr.Stdout)
 	}
 
 	if cmd.Stderr == nil {
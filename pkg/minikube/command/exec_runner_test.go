@@ -0,0 +1,43 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestExecRunnerStream(t *testing.T) {
+	var w bytes.Buffer
+	cmd := exec.Command("/bin/sh", "-c", "echo archive; echo failed >&2; exit 1")
+	cmd.Stdout = Stream(&w)
+	rr, err := NewExecRunner(false).RunCmd(cmd)
+	if err == nil {
+		t.Fatalf("expected the command to fail")
+	}
+	if got := w.String(); got != "archive\n" {
+		t.Errorf("streamed output = %q, want %q", got, "archive\n")
+	}
+	if rr.Stdout.Len() != 0 || strings.Contains(err.Error(), "archive\n") {
+		t.Errorf("expected the streamed output not to be kept, got %q and error %v", rr.Stdout.String(), err)
+	}
+	if !strings.Contains(err.Error(), "failed") {
+		t.Errorf("expected the error to contain stderr, got %v", err)
+	}
+}
@@ -97,7 +97,7 @@ func (k *kicRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
 		var so bytes.Buffer
 		outb = io.MultiWriter(&so, &rr.Stdout)
 	} else {
-		outb = io.MultiWriter(oc.Stdout, &rr.Stdout)
+		outb = outputWriter(oc.Stdout, &rr.Stdout)
 	}
 
 	if oc.Stderr == nil {
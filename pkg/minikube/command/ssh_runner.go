@@ -187,10 +187,6 @@ func teeSSH(s *ssh.Session, cmd string, outB io.Writer, errB io.Writer) error {
 
 // RunCmd implements the Command Runner interface to run a exec.Cmd object
 func (s *SSHRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
-	if cmd.Stdin != nil {
-		return nil, fmt.Errorf("SSHRunner does not support stdin - you could be the first to add it")
-	}
-
 	rr := &RunResult{Args: cmd.Args}
 	klog.Infof("Run: %v", rr.Command())
 
@@ -201,7 +197,7 @@ func (s *SSHRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
 		var so bytes.Buffer
 		outb = io.MultiWriter(&so, &rr.Stdout)
 	} else {
-		outb = io.MultiWriter(cmd.Stdout, &rr.Stdout)
+		outb = outputWriter(cmd.Stdout, &rr.Stdout)
 	}
 
 	if cmd.Stderr == nil {
@@ -224,6 +220,8 @@ func (s *SSHRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
 		}
 	}()
 
+	// the remote stdin is closed once cmd.Stdin is read to the end
+	sess.Stdin = cmd.Stdin
 	err = teeSSH(sess, shellquote.Join(cmd.Args...), outb, errb)
 	elapsed := time.Since(start)
 
@@ -291,7 +289,7 @@ func (s *SSHRunner) StartCmd(cmd *exec.Cmd) (*StartedCmd, error) {
 		var so bytes.Buffer
 		outb = io.MultiWriter(&so, &rr.Stdout)
 	} else {
-		outb = io.MultiWriter(cmd.Stdout, &rr.Stdout)
+		outb = outputWriter(cmd.Stdout, &rr.Stdout)
 	}
 
 	if cmd.Stderr == nil {
@@ -61,3 +61,21 @@ func TestTeePrefix(t *testing.T) {
 		t.Errorf("log=%q, want: %q", gotLog, wantLog)
 	}
 }
+
+func TestTeePrefixStream(t *testing.T) {
+	var out bytes.Buffer
+	logged := false
+	logSink := func(string, ...interface{}) {
+		logged = true
+	}
+	in := strings.NewReader("binary\r\ndata\n")
+	if err := teePrefix(":", in, Stream(&out), logSink); err != nil {
+		t.Fatalf("teePrefix: %v", err)
+	}
+	if got := out.String(); got != "binary\r\ndata\n" {
+		t.Errorf("output=%q, want: %q", got, "binary\r\ndata\n")
+	}
+	if logged {
+		t.Errorf("expected streamed outputs not to be logged")
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/viper"
 
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/localpath"
 )
 
@@ -254,3 +255,8 @@ func IsHA(cc ClusterConfig) bool {
 	}
 	return viper.GetBool("ha")
 }
+
+// IsHAProxy returns true if the API servers of the HA (multi-control plane) cluster are fronted by an haproxy container rather than by kube-vip
+func IsHAProxy(cc ClusterConfig) bool {
+	return IsHA(cc) && cc.KubernetesConfig.HALoadBalancer == constants.HALoadBalancerHAProxy
+}
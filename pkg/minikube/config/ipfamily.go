@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+// IPv4Enabled returns whether the cluster uses IPv4, which is the case unless it is IPv6-only
+func IPv4Enabled(k KubernetesConfig) bool {
+	return k.IPFamily != constants.IPFamilyIPv6
+}
+
+// IPv6Enabled returns whether the cluster is IPv6-only or dual-stack
+func IPv6Enabled(k KubernetesConfig) bool {
+	return k.IPFamily == constants.IPFamilyIPv6 || k.IPFamily == constants.IPFamilyDual
+}
+
+// ServiceCIDRs returns the service subnets of the cluster, the primary family first
+func ServiceCIDRs(k KubernetesConfig) []string {
+	cidrs := []string{}
+	if IPv4Enabled(k) {
+		cidr := k.ServiceCIDR
+		if cidr == "" {
+			cidr = constants.DefaultServiceCIDR
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	if IPv6Enabled(k) {
+		cidr := k.ServiceCIDRv6
+		if cidr == "" {
+			cidr = constants.DefaultServiceCIDRv6
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs
+}
+
+// NodeIPs returns the addresses of a node for the IP families of the cluster, the primary family first
+func NodeIPs(k KubernetesConfig, n Node) []string {
+	ips := []string{}
+	if IPv4Enabled(k) && n.IP != "" {
+		ips = append(ips, n.IP)
+	}
+	if IPv6Enabled(k) && n.IPv6 != "" {
+		ips = append(ips, n.IPv6)
+	}
+	return ips
+}
+
+// AdvertiseIP returns the address the Kubernetes components of a node advertise, which is the IPv6 one on IPv6-only clusters
+func AdvertiseIP(k KubernetesConfig, n Node) string {
+	if !IPv4Enabled(k) && n.IPv6 != "" {
+		return n.IPv6
+	}
+	return n.IP
+}
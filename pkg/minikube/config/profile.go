@@ -334,6 +334,14 @@ func ProfileFolderPath(profile string, miniHome ...string) string {
 	return filepath.Join(miniPath, "profiles", profile)
 }
 
+// KubeconfigPath returns the path to the kubeconfig holding the context of the cluster
+func KubeconfigPath(cc *ClusterConfig) string {
+	if cc != nil && cc.KubeconfigMode == kubeconfig.SeparateMode {
+		return localpath.Kubeconfig(cc.Name)
+	}
+	return kubeconfig.PathFromEnv()
+}
+
 // MachineName returns the name of the machine, as seen by the hypervisor given the cluster and node names
 func MachineName(cc ClusterConfig, n Node) string {
 	// For single node cluster, default to back to old naming
@@ -37,6 +37,7 @@ type ClusterConfig struct {
 	Name                    string
 	KeepContext             bool   // used by start and profile command to or not to switch kubectl's current context
 	EmbedCerts              bool   // used by kubeconfig.Setup
+	KubeconfigMode          string // "merge" the context into the kubeconfig of the user, or write a "separate" kubeconfig of the profile
 	MinikubeISO             string // ISO used for VM-drivers.
 	KicBaseImage            string // base-image used for docker/podman drivers.
 	Memory                  int
@@ -82,6 +83,9 @@ type ClusterConfig struct {
 	StartHostTimeout        time.Duration
 	ScheduledStop           *ScheduledStopConfig
 	ExposedPorts            []string // Only used by the docker and podman driver
+	PublishedPorts          []string // Only used by the docker and podman driver, ports published after creation by 'minikube ports add'
+	ConnectedProfiles       []string // Only used by the docker and podman driver, profiles connected by 'minikube network connect'
+	CopiedFrom              string   // Only used by the docker and podman driver, profile whose data was copied by 'minikube profile rename' or 'clone --keep-data', until next start
 	ListenAddress           string   // Only used by the docker and podman driver
 	Network                 string   // only used by docker driver
 	Subnet                  string   // only used by the docker and podman driver
@@ -97,7 +101,8 @@ type ClusterConfig struct {
 	MountPort               uint16
 	MountType               string
 	MountUID                string
-	BinaryMirror            string // Mirror location for kube binaries (kubectl, kubelet, & kubeadm)
+	ManagedMounts           []ManagedMount // Background mounts created by 'minikube mount --daemon', established again on start
+	BinaryMirror            string         // Mirror location for kube binaries (kubectl, kubelet, & kubeadm)
 	DisableOptimizations    bool
 	DisableMetrics          bool
 	DisableCoreDNSLog       bool
@@ -113,25 +118,36 @@ type ClusterConfig struct {
 
 // KubernetesConfig contains the parameters used to configure the VM Kubernetes.
 type KubernetesConfig struct {
-	KubernetesVersion   string
-	ClusterName         string
-	Namespace           string
-	APIServerHAVIP      string
-	APIServerName       string
-	APIServerNames      []string
-	APIServerIPs        []net.IP
-	DNSDomain           string
-	ContainerRuntime    string
-	CRISocket           string
-	NetworkPlugin       string
-	FeatureGates        string // https://kubernetes.io/docs/reference/command-line-tools-reference/feature-gates/
-	ServiceCIDR         string // the subnet which Kubernetes services will be deployed to
-	ImageRepository     string
-	LoadBalancerStartIP string // currently only used by MetalLB addon
-	LoadBalancerEndIP   string // currently only used by MetalLB addon
-	CustomIngressCert   string // used by Ingress addon
-	RegistryAliases     string // currently only used by registry-aliases addon
-	ExtraOptions        ExtraOptionSlice
+	KubernetesVersion    string
+	ClusterName          string
+	Namespace            string
+	APIServerHAVIP       string
+	HALoadBalancer       string // kube-vip or haproxy, fronting the API servers of HA clusters at APIServerHAVIP
+	APIServerName        string
+	APIServerNames       []string
+	APIServerIPs         []net.IP
+	DNSDomain            string
+	ContainerRuntime     string
+	CRISocket            string
+	NetworkPlugin        string
+	FeatureGates         string // https://kubernetes.io/docs/reference/command-line-tools-reference/feature-gates/
+	ServiceCIDR          string // the subnet which Kubernetes services will be deployed to
+	ServiceCIDRv6        string // the IPv6 subnet which Kubernetes services will be deployed to, only used with IPv6 and dual-stack
+	PodCIDRv6            string // the IPv6 subnet pods are assigned from, only used with IPv6 and dual-stack
+	IPFamily             string // ipv4, ipv6 or dual
+	ImageRepository      string
+	LoadBalancerStartIP  string // currently only used by MetalLB addon
+	LoadBalancerEndIP    string // currently only used by MetalLB addon
+	CustomIngressCert    string // used by Ingress addon
+	RegistryAliases      string // currently only used by registry-aliases addon
+	APIServerAuditPolicy string // the name of an audit policy preset or the path of an audit policy file
+	ExtraOptions         ExtraOptionSlice
+
+	KubeadmPatches   string            // the directory of the kubeadm patches on the host
+	ComponentConfigs map[string]string // the component configs by kind, merged into the configs generated by minikube
+
+	KubernetesBinariesDir string // the directory of the Kubernetes binaries built locally on the host, copied instead of the released ones
+	KubernetesImagesDir   string // the directory of the image tarballs of the control plane built locally on the host
 
 	ShouldLoadCachedImages bool
 
@@ -143,6 +159,7 @@ type KubernetesConfig struct {
 type Node struct {
 	Name              string
 	IP                string
+	IPv6              string // only set for IPv6 and dual-stack clusters
 	Port              int
 	KubernetesVersion string
 	ContainerRuntime  string
@@ -181,3 +198,23 @@ type ScheduledStopConfig struct {
 	InitiationTime int64
 	Duration       time.Duration
 }
+
+// ManagedMount is a background mount of a host directory into a node, created by 'minikube mount --daemon'
+type ManagedMount struct {
+	Name    string
+	Source  string
+	Target  string
+	Node    string // empty for the primary control-plane node
+	Type    string
+	UID     string
+	GID     string
+	Version string
+	MSize   int
+	IP      string
+	Port    uint16
+	Options []string
+	// Notify replays the changes of the host directory in the node, for watchers relying on inotify
+	Notify         bool
+	NotifyIgnore   []string
+	NotifyDebounce time.Duration
+}
@@ -80,6 +80,18 @@ const (
 	ClusterDNSDomain = "cluster.local"
 	// DefaultServiceCIDR is The CIDR to be used for service cluster IPs
 	DefaultServiceCIDR = "10.96.0.0/12"
+	// DefaultServiceCIDRv6 is the CIDR to be used for IPv6 service cluster IPs
+	DefaultServiceCIDRv6 = "fd00:10:96::/112"
+	// IPFamilyIPv4 runs the cluster on IPv4 only
+	IPFamilyIPv4 = "ipv4"
+	// IPFamilyIPv6 runs the cluster on IPv6 only
+	IPFamilyIPv6 = "ipv6"
+	// IPFamilyDual runs the cluster on both IPv4 and IPv6
+	IPFamilyDual = "dual"
+	// HALoadBalancerKubeVip fronts the API servers of HA (multi-control plane) clusters with a kube-vip virtual IP
+	HALoadBalancerKubeVip = "kube-vip"
+	// HALoadBalancerHAProxy fronts the API servers of HA (multi-control plane) clusters with an haproxy container
+	HALoadBalancerHAProxy = "haproxy"
 	// HostAlias is a DNS alias to the container/VM host IP
 	HostAlias = "host.minikube.internal"
 	// ControlPlaneAlias is a DNS alias pointing to the apiserver frontend
@@ -107,6 +119,15 @@ const (
 	// MinikubeActivePodmanEnv holds the podman service that the user's shell is pointing at
 	// value would be profile or empty if pointing to the user's host.
 	MinikubeActivePodmanEnv = "MINIKUBE_ACTIVE_PODMAN"
+	// ContainerdAddressEnv is used for nerdctl settings
+	ContainerdAddressEnv = "CONTAINERD_ADDRESS"
+	// ContainerdNamespaceEnv is used for nerdctl settings
+	ContainerdNamespaceEnv = "CONTAINERD_NAMESPACE"
+	// BuildkitHostEnv is used for nerdctl and buildctl settings
+	BuildkitHostEnv = "BUILDKIT_HOST"
+	// MinikubeActiveNerdctlEnv holds the containerd service that the user's shell is pointing at
+	// value would be profile or empty if pointing to the user's host.
+	MinikubeActiveNerdctlEnv = "MINIKUBE_ACTIVE_NERDCTL"
 	// MinikubeForceSystemdEnv is used to force systemd as cgroup manager for the container runtime
 	MinikubeForceSystemdEnv = "MINIKUBE_FORCE_SYSTEMD"
 	// TestDiskUsedEnv is used in integration tests for insufficient storage with 'minikube status' (in %)
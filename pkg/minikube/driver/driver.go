@@ -233,6 +233,11 @@ func SupportsVirtiofsMounts(name string) bool {
 	return IsVFKit(name) || IsKrunkit(name)
 }
 
+// SupportsResize returns if driver can change the CPUs, memory and disk size of an existing machine
+func SupportsResize(name string) bool {
+	return IsKIC(name) || IsKVM(name) || IsQEMU(name)
+}
+
 // AllowsPreload returns if preload is allowed for the driver
 func AllowsPreload(driverName string) bool {
 	return !BareMetal(driverName) && !IsSSH(driverName)
@@ -30,7 +30,12 @@ import (
 // ControlPlaneEndpoint returns the location where callers can reach this cluster.
 func ControlPlaneEndpoint(cc *config.ClusterConfig, cp *config.Node, driverName string) (string, net.IP, int, error) {
 	if NeedsPortForward(driverName) {
-		port, err := oci.ForwardedPort(cc.Driver, cc.Name, cp.Port)
+		container := cc.Name
+		// the haproxy load balancer only fronts the API servers
+		if config.IsHAProxy(*cc) && cp.Port == cc.APIServerPort {
+			container = oci.LoadBalancerName(cc.Name)
+		}
+		port, err := oci.ForwardedPort(cc.Driver, container, cp.Port)
 		if err != nil {
 			klog.Warningf("failed to get forwarded control plane port %v", err)
 		}
@@ -0,0 +1,283 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package etcd manages the etcd of the control plane, by running etcdctl and etcdutl in its static pod
+package etcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+const (
+	// snapshotFile is the snapshot on the node, in the data dir which is mounted into the static pod,
+	// and in vmpath.GuestPersistentDir once it is given to the user of the runner to be copied to the host
+	snapshotFile = "minikube-snapshot.db"
+	// restoreDir is where etcdutl restores the snapshot on the node, before it replaces the data of the member
+	restoreDir = "minikube-restore"
+)
+
+// manifestPath is the manifest of the static pod of etcd on the control-plane nodes
+var manifestPath = path.Join(vmpath.GuestManifestsDir, "etcd.yaml")
+
+// Member is the etcd member of a control-plane node, as configured by kubeadm in its static pod
+type Member struct {
+	// Name is the name of the member
+	Name string
+	// PeerURL is the URL advertised to the other members
+	PeerURL string
+	// ClientURL is the URL of the clients on the node
+	ClientURL string
+	// DataDir is where the member stores its data, on the node and in the pod
+	DataDir string
+	// CACert, Cert and Key are the certificates of the clients
+	CACert string
+	Cert   string
+	Key    string
+}
+
+// ReadMember reads the etcd member of the control-plane node from the manifest of its static pod
+func ReadMember(r command.Runner) (Member, error) {
+	rr, err := r.RunCmd(exec.Command("sudo", "cat", manifestPath))
+	if err != nil {
+		return Member{}, errors.Wrap(err, "reading the etcd manifest")
+	}
+	return parseMember(rr.Stdout.Bytes())
+}
+
+// parseMember returns the etcd member configured by the flags of the static pod of etcd
+func parseMember(manifest []byte) (Member, error) {
+	var pod struct {
+		Spec struct {
+			Containers []struct {
+				Name    string   `json:"name"`
+				Command []string `json:"command"`
+			} `json:"containers"`
+		} `json:"spec"`
+	}
+	if err := yaml.Unmarshal(manifest, &pod); err != nil {
+		return Member{}, errors.Wrap(err, "parsing the etcd manifest")
+	}
+
+	flags := map[string]string{}
+	for _, c := range pod.Spec.Containers {
+		if c.Name != "etcd" {
+			continue
+		}
+		for _, arg := range c.Command {
+			if k, v, ok := strings.Cut(strings.TrimPrefix(arg, "--"), "="); ok {
+				flags[k] = v
+			}
+		}
+	}
+
+	m := Member{
+		Name:      flags["name"],
+		PeerURL:   firstURL(flags["initial-advertise-peer-urls"]),
+		ClientURL: firstURL(flags["listen-client-urls"]),
+		DataDir:   flags["data-dir"],
+		CACert:    flags["trusted-ca-file"],
+		Cert:      flags["cert-file"],
+		Key:       flags["key-file"],
+	}
+	for flag, v := range map[string]string{"name": m.Name, "initial-advertise-peer-urls": m.PeerURL, "listen-client-urls": m.ClientURL, "data-dir": m.DataDir} {
+		if v == "" {
+			return Member{}, errors.Errorf("the etcd manifest has no --%s", flag)
+		}
+	}
+	return m, nil
+}
+
+// firstURL returns the first of the comma-separated URLs
+func firstURL(urls string) string {
+	u, _, _ := strings.Cut(urls, ",")
+	return u
+}
+
+// InitialCluster returns the initial cluster of the members, restored together from a snapshot
+func InitialCluster(members []Member) string {
+	ic := []string{}
+	for _, m := range members {
+		ic = append(ic, fmt.Sprintf("%s=%s", m.Name, m.PeerURL))
+	}
+	return strings.Join(ic, ",")
+}
+
+// SaveSnapshot saves a snapshot of etcd to dst on the host, taken by the member of the control-plane node
+func SaveSnapshot(r command.Runner, cr cruntime.Manager, m Member, dst string) error {
+	id, err := container(cr)
+	if err != nil {
+		return err
+	}
+
+	snapshot := path.Join(m.DataDir, snapshotFile)
+	copied := path.Join(vmpath.GuestPersistentDir, snapshotFile)
+	defer removeFiles(r, snapshot, copied)
+	if _, err := execInContainer(r, id, m.etcdctl("snapshot", "save", snapshot)...); err != nil {
+		return errors.Wrap(err, "etcdctl snapshot save")
+	}
+	// the data dir is only readable by root, while the SSH runners copy the files as the user of the node
+	script := fmt.Sprintf("sudo mv %s %s && sudo chown $(id -u):$(id -g) %s", snapshot, copied, copied)
+	if _, err := r.RunCmd(exec.Command("/bin/bash", "-c", script)); err != nil {
+		return errors.Wrap(err, "moving the snapshot out of the data dir")
+	}
+
+	// the snapshot holds the secrets of the cluster
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	a, err := assets.NewFileAsset(dst, vmpath.GuestPersistentDir, snapshotFile, "0600")
+	if err != nil {
+		return errors.Wrapf(err, "creating copyable file asset: %s", dst)
+	}
+	defer func() {
+		if err := a.Close(); err != nil {
+			klog.Warningf("error closing the file %s: %v", a.GetSourcePath(), err)
+		}
+	}()
+	if err := r.CopyFrom(a); err != nil {
+		return errors.Wrap(err, "copying the snapshot")
+	}
+	return nil
+}
+
+// PrepareRestore copies the snapshot at src on the host to the control-plane node, and restores it next to the data of the member,
+// as a member of initialCluster. The etcd of the node keeps running until the restored data replaces its data with ReplaceData.
+func PrepareRestore(r command.Runner, cr cruntime.Manager, m Member, src, initialCluster string) error {
+	id, err := container(cr)
+	if err != nil {
+		return err
+	}
+
+	snapshot := path.Join(m.DataDir, snapshotFile)
+	restored := path.Join(m.DataDir, restoreDir)
+	removeFiles(r, restored)
+	a, err := assets.NewFileAsset(src, m.DataDir, snapshotFile, "0600")
+	if err != nil {
+		return errors.Wrapf(err, "creating copyable file asset: %s", src)
+	}
+	defer func() {
+		if err := a.Close(); err != nil {
+			klog.Warningf("error closing the file %s: %v", a.GetSourcePath(), err)
+		}
+	}()
+	if err := r.Copy(a); err != nil {
+		return errors.Wrap(err, "copying the snapshot")
+	}
+	defer removeFiles(r, snapshot)
+
+	// etcdctl can no longer restore snapshots since etcd v3.6, etcdutl can since v3.5
+	if _, err := execInContainer(r, id, "etcdutl", "snapshot", "restore", snapshot,
+		"--data-dir="+restored,
+		"--name="+m.Name,
+		"--initial-cluster="+initialCluster,
+		"--initial-advertise-peer-urls="+m.PeerURL); err != nil {
+		return errors.Wrap(err, "etcdutl snapshot restore")
+	}
+	return nil
+}
+
+// ReplaceData replaces the data of the member with the data restored by PrepareRestore, once etcd is stopped
+func ReplaceData(r command.Runner, m Member) error {
+	member := path.Join(m.DataDir, "member")
+	restored := path.Join(m.DataDir, restoreDir)
+	script := fmt.Sprintf("rm -rf %s && mv %s %s && rm -rf %s", member, path.Join(restored, "member"), member, restored)
+	if _, err := r.RunCmd(exec.Command("sudo", "/bin/bash", "-c", script)); err != nil {
+		return errors.Wrap(err, "replacing the etcd data")
+	}
+	return nil
+}
+
+// RemoveMember removes the member with the name from the etcd cluster of the member m, if it is still a member
+func RemoveMember(r command.Runner, cr cruntime.Manager, m Member, name string) error {
+	id, err := container(cr)
+	if err != nil {
+		return err
+	}
+	rr, err := execInContainer(r, id, m.etcdctl("member", "list", "--write-out=json")...)
+	if err != nil {
+		return errors.Wrap(err, "etcdctl member list")
+	}
+	var list struct {
+		Members []struct {
+			ID   uint64 `json:"ID"`
+			Name string `json:"name"`
+		} `json:"members"`
+	}
+	if err := json.Unmarshal(rr.Stdout.Bytes(), &list); err != nil {
+		return errors.Wrap(err, "parsing the etcd members")
+	}
+	for _, member := range list.Members {
+		if member.Name != name {
+			continue
+		}
+		klog.Infof("removing the etcd member %s (%x)", name, member.ID)
+		if _, err := execInContainer(r, id, m.etcdctl("member", "remove", fmt.Sprintf("%x", member.ID))...); err != nil {
+			return errors.Wrapf(err, "etcdctl member remove %s", name)
+		}
+	}
+	return nil
+}
+
+// etcdctl returns the command line of etcdctl connecting to the member
+func (m Member) etcdctl(args ...string) []string {
+	cmd := []string{"etcdctl", "--endpoints=" + m.ClientURL}
+	if m.CACert != "" {
+		cmd = append(cmd, "--cacert="+m.CACert, "--cert="+m.Cert, "--key="+m.Key)
+	}
+	return append(cmd, args...)
+}
+
+// container returns the ID of the running container of etcd
+func container(cr cruntime.Manager) (string, error) {
+	ids, err := cr.ListContainers(cruntime.ListContainersOptions{State: cruntime.Running, Name: "etcd", Namespaces: []string{"kube-system"}})
+	if err != nil {
+		return "", errors.Wrap(err, "listing the etcd containers")
+	}
+	if len(ids) == 0 {
+		return "", errors.New("etcd is not running")
+	}
+	return ids[0], nil
+}
+
+// execInContainer runs a command in the container of etcd, through the CRI of the node
+func execInContainer(r command.Runner, id string, args ...string) (*command.RunResult, error) {
+	return r.RunCmd(exec.Command("sudo", append([]string{"crictl", "exec", id}, args...)...))
+}
+
+// removeFiles removes files on the node, which are only left behind on failures
+func removeFiles(r command.Runner, paths ...string) {
+	if _, err := r.RunCmd(exec.Command("sudo", append([]string{"rm", "-rf"}, paths...)...)); err != nil {
+		klog.Warningf("unable to remove %s: %v", strings.Join(paths, ", "), err)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+// the static pod of etcd written by kubeadm, shortened
+const etcdManifest = `apiVersion: v1
+kind: Pod
+metadata:
+  name: etcd
+  namespace: kube-system
+spec:
+  containers:
+  - command:
+    - etcd
+    - --advertise-client-urls=https://192.168.49.3:2379
+    - --cert-file=/var/lib/minikube/certs/etcd/server.crt
+    - --data-dir=/var/lib/minikube/etcd
+    - --initial-advertise-peer-urls=https://192.168.49.3:2380
+    - --initial-cluster=ha-m02=https://192.168.49.3:2380,ha=https://192.168.49.2:2380
+    - --initial-cluster-state=existing
+    - --key-file=/var/lib/minikube/certs/etcd/server.key
+    - --listen-client-urls=https://127.0.0.1:2379,https://192.168.49.3:2379
+    - --name=ha-m02
+    - --trusted-ca-file=/var/lib/minikube/certs/etcd/ca.crt
+    image: registry.k8s.io/etcd:3.6.4-0
+    name: etcd
+`
+
+func TestParseMember(t *testing.T) {
+	m, err := parseMember([]byte(etcdManifest))
+	if err != nil {
+		t.Fatalf("parseMember() = %v", err)
+	}
+	want := Member{
+		Name:      "ha-m02",
+		PeerURL:   "https://192.168.49.3:2380",
+		ClientURL: "https://127.0.0.1:2379",
+		DataDir:   "/var/lib/minikube/etcd",
+		CACert:    "/var/lib/minikube/certs/etcd/ca.crt",
+		Cert:      "/var/lib/minikube/certs/etcd/server.crt",
+		Key:       "/var/lib/minikube/certs/etcd/server.key",
+	}
+	if diff := cmp.Diff(want, m); diff != "" {
+		t.Errorf("parseMember() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := parseMember([]byte("apiVersion: v1\nkind: Pod\nspec:\n  containers:\n  - name: etcd\n    command: [etcd]\n")); err == nil {
+		t.Errorf("parseMember() of a manifest without flags succeeded")
+	}
+}
+
+func TestInitialCluster(t *testing.T) {
+	members := []Member{
+		{Name: "ha", PeerURL: "https://192.168.49.2:2380"},
+		{Name: "ha-m02", PeerURL: "https://192.168.49.3:2380"},
+	}
+	want := "ha=https://192.168.49.2:2380,ha-m02=https://192.168.49.3:2380"
+	if got := InitialCluster(members); got != want {
+		t.Errorf("InitialCluster() = %q, want %q", got, want)
+	}
+}
+
+// fakeRuntime runs the container of etcd
+type fakeRuntime struct {
+	cruntime.Manager
+}
+
+func (fakeRuntime) ListContainers(cruntime.ListContainersOptions) ([]string, error) {
+	return []string{"etcd"}, nil
+}
+
+// userRunner runs the commands as a user of the node, like the SSH runners, which can only read
+// the files the user owns. It runs the commands used with the snapshots, on files named by their paths.
+type userRunner struct {
+	command.FakeCommandRunner
+	// owners maps the files on the node to their owner
+	owners map[string]string
+}
+
+func (r *userRunner) RunCmd(cmd *exec.Cmd) (*command.RunResult, error) {
+	rr := &command.RunResult{Args: cmd.Args}
+	line := strings.Join(cmd.Args, " ")
+	if cmd.Args[0] == "/bin/bash" {
+		line = strings.ReplaceAll(cmd.Args[2], "$(id -u):$(id -g)", "docker")
+	}
+	for _, c := range strings.Split(line, " && ") {
+		args := strings.Fields(c)
+		if args[0] != "sudo" {
+			return rr, fmt.Errorf("%s: permission denied", c)
+		}
+		switch args = args[1:]; {
+		case args[0] == "crictl" && strings.Contains(c, "snapshot save"):
+			r.owners[args[len(args)-1]] = "root"
+		case args[0] == "mv":
+			r.owners[args[2]] = r.owners[args[1]]
+			delete(r.owners, args[1])
+		case args[0] == "chown":
+			r.owners[args[2]] = args[1]
+		case args[0] == "rm":
+			for _, p := range args[2:] {
+				delete(r.owners, p)
+			}
+		default:
+			return rr, fmt.Errorf("unexpected command %q", c)
+		}
+	}
+	return rr, nil
+}
+
+func (r *userRunner) CopyFrom(f assets.CopyableFile) error {
+	src := path.Join(f.GetTargetDir(), f.GetTargetName())
+	if r.owners[src] != "docker" {
+		return fmt.Errorf("stat %s: permission denied", src)
+	}
+	_, err := io.Copy(f, strings.NewReader("snapshot"))
+	return err
+}
+
+func TestSaveSnapshot(t *testing.T) {
+	m, err := parseMember([]byte(etcdManifest))
+	if err != nil {
+		t.Fatalf("parseMember() = %v", err)
+	}
+	r := &userRunner{owners: map[string]string{}}
+	dst := filepath.Join(t.TempDir(), "snapshot.db")
+	if err := SaveSnapshot(r, fakeRuntime{}, m, dst); err != nil {
+		t.Fatalf("SaveSnapshot() = %v", err)
+	}
+
+	data, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "snapshot" {
+		t.Errorf("got the snapshot %q, want %q", data, "snapshot")
+	}
+	if len(r.owners) != 0 {
+		t.Errorf("expected the snapshot to be removed from the node, got %v", r.owners)
+	}
+}
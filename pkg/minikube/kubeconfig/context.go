@@ -17,6 +17,8 @@ limitations under the License.
 package kubeconfig
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/klog/v2"
@@ -73,7 +75,7 @@ func SetCurrentContext(name string, configPath ...string) error {
 	return writeToFile(kcfg, fPath)
 }
 
-// DeleteContext deletes the specified machine's kubeconfig context
+// DeleteContext deletes the specified machine's kubeconfig cluster, user and contexts, including the contexts of other users of its cluster
 func DeleteContext(machineName string, configPath ...string) error {
 	fPath := PathFromEnv()
 	if configPath != nil {
@@ -90,12 +92,76 @@ func DeleteContext(machineName string, configPath ...string) error {
 	}
 
 	delete(kcfg.Clusters, machineName)
-	delete(kcfg.AuthInfos, machineName)
-	delete(kcfg.Contexts, machineName)
+	for name := range kcfg.AuthInfos {
+		if name == machineName || strings.HasSuffix(name, "@"+machineName) {
+			delete(kcfg.AuthInfos, name)
+		}
+	}
+	for name, context := range kcfg.Contexts {
+		if name != machineName && context.Cluster != machineName {
+			continue
+		}
+		delete(kcfg.Contexts, name)
+		if kcfg.CurrentContext == name {
+			kcfg.CurrentContext = ""
+		}
+	}
 
-	if kcfg.CurrentContext == machineName {
-		kcfg.CurrentContext = ""
+	if err := writeToFile(kcfg, fPath); err != nil {
+		return errors.Wrap(err, "writing kubeconfig")
+	}
+	return nil
+}
+
+// RenameContext renames the kubeconfig cluster, user and contexts of a machine, including the contexts of other users of its cluster,
+// and points the client certificates under oldDir to newDir
+func RenameContext(oldName, newName, oldDir, newDir string, configPath ...string) error {
+	fPath := PathFromEnv()
+	if configPath != nil {
+		fPath = configPath[0]
+	}
+	kcfg, err := readOrNew(fPath)
+	if err != nil {
+		return errors.Wrap(err, "Error getting kubeconfig status")
+	}
+
+	if kcfg == nil || api.IsConfigEmpty(kcfg) {
+		klog.V(2).Info("kubeconfig is empty")
+		return nil
+	}
+
+	rename := func(name string) string {
+		if name == oldName {
+			return newName
+		}
+		if user, ok := strings.CutSuffix(name, "@"+oldName); ok {
+			return UserContext(newName, user)
+		}
+		return name
+	}
+	if cluster, ok := kcfg.Clusters[oldName]; ok {
+		delete(kcfg.Clusters, oldName)
+		kcfg.Clusters[newName] = cluster
+	}
+	for name, user := range kcfg.AuthInfos {
+		if rename(name) == name {
+			continue
+		}
+		user.ClientCertificate = strings.Replace(user.ClientCertificate, oldDir, newDir, 1)
+		user.ClientKey = strings.Replace(user.ClientKey, oldDir, newDir, 1)
+		delete(kcfg.AuthInfos, name)
+		kcfg.AuthInfos[rename(name)] = user
+	}
+	for name, context := range kcfg.Contexts {
+		if context.Cluster != oldName {
+			continue
+		}
+		context.Cluster = newName
+		context.AuthInfo = rename(context.AuthInfo)
+		delete(kcfg.Contexts, name)
+		kcfg.Contexts[rename(name)] = context
 	}
+	kcfg.CurrentContext = rename(kcfg.CurrentContext)
 
 	if err := writeToFile(kcfg, fPath); err != nil {
 		return errors.Wrap(err, "writing kubeconfig")
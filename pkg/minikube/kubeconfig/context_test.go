@@ -48,6 +48,72 @@ func TestDeleteContext(t *testing.T) {
 	}
 }
 
+func TestDeleteContextUsers(t *testing.T) {
+	// See kubeconfig_test
+	fn := tempFile(t, kubeConfigWithoutHTTPS)
+	defer os.Remove(fn)
+	if _, err := SetUserContext("la-croix", "dev", "default", "/home/la-croix/users/dev.crt", "/home/la-croix/users/dev.key", false, fn); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetCurrentContext("dev@la-croix", fn); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteContext("la-croix", fn); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := readOrNew(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cfg.Clusters) != 0 || len(cfg.AuthInfos) != 0 || len(cfg.Contexts) != 0 {
+		t.Errorf("expected the user contexts to be deleted with the cluster, got %v, %v and %v", cfg.Clusters, cfg.AuthInfos, cfg.Contexts)
+	}
+	if cfg.CurrentContext != "" {
+		t.Errorf("expected the current context to be unset, got %s", cfg.CurrentContext)
+	}
+}
+
+func TestRenameContext(t *testing.T) {
+	// See kubeconfig_test
+	fn := tempFile(t, kubeConfigWithoutHTTPS)
+	defer os.Remove(fn)
+	if _, err := SetUserContext("la-croix", "dev", "default", "/home/la-croix/users/dev.crt", "/home/la-croix/users/dev.key", false, fn); err != nil {
+		t.Fatal(err)
+	}
+	if err := RenameContext("la-croix", "perrier", "/home/la-croix", "/home/perrier", fn); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := readOrNew(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := cfg.Clusters["perrier"]; !ok || len(cfg.Clusters) != 1 {
+		t.Errorf("expected only the cluster perrier, got %v", cfg.Clusters)
+	}
+	if cfg.CurrentContext != "perrier" {
+		t.Errorf("expected current context perrier, got %s", cfg.CurrentContext)
+	}
+	for _, name := range []string{"perrier", "dev@perrier"} {
+		context, ok := cfg.Contexts[name]
+		if !ok {
+			t.Fatalf("expected context %s, got %v", name, cfg.Contexts)
+		}
+		if context.Cluster != "perrier" || context.AuthInfo != name {
+			t.Errorf("expected context %s of cluster perrier and user %s, got %+v", name, name, context)
+		}
+	}
+	if user := cfg.AuthInfos["dev@perrier"]; user == nil || user.ClientCertificate != "/home/perrier/users/dev.crt" || user.ClientKey != "/home/perrier/users/dev.key" {
+		t.Errorf("expected the certificates of user dev@perrier under /home/perrier, got %+v", user)
+	}
+	if len(cfg.Contexts) != 2 || len(cfg.AuthInfos) != 2 {
+		t.Errorf("expected 2 contexts and users, got %v and %v", cfg.Contexts, cfg.AuthInfos)
+	}
+}
+
 func TestSetCurrentContext(t *testing.T) {
 	f, err := os.CreateTemp("/tmp", "kubeconfig")
 	if err != nil {
@@ -18,6 +18,7 @@ package kubeconfig
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path"
@@ -35,6 +36,13 @@ import (
 	"k8s.io/minikube/pkg/util/lock"
 )
 
+const (
+	// MergeMode merges the contexts of the clusters into the kubeconfig of the user
+	MergeMode = "merge"
+	// SeparateMode writes the context of each cluster into a kubeconfig of its profile
+	SeparateMode = "separate"
+)
+
 // UpdateEndpoint overwrites the IP stored in kubeconfig with the provided IP.
 // It will also fix missing cluster or context in kubeconfig, if needed.
 // Returns if the change was made and any error occurred.
@@ -52,7 +60,7 @@ func UpdateEndpoint(contextName string, host string, port int, configPath string
 		return false, errors.Wrap(err, "get kubeconfig")
 	}
 
-	address := "https://" + host + ":" + strconv.Itoa(port)
+	address := "https://" + net.JoinHostPort(host, strconv.Itoa(port))
 
 	// check & fix kubeconfig if the cluster or context setting is missing, or server address needs updating
 	errs := configIssues(cfg, contextName, address)
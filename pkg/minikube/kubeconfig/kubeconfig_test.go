@@ -129,6 +129,28 @@ users:
     client-key: /home/la-croix/apiserver.key
 `)
 
+var kubeConfigIPv6Localhost = []byte(`
+apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority: /home/la-croix/apiserver.crt
+    server: https://[::1]:8443
+  name: minikube
+contexts:
+- context:
+    cluster: minikube
+    user: minikube
+  name: minikube
+current-context: minikube
+kind: Config
+preferences: {}
+users:
+- name: minikube
+  user:
+    client-certificate: /home/la-croix/apiserver.crt
+    client-key: /home/la-croix/apiserver.key
+`)
+
 var kubeConfigLocalhost12345 = []byte(`
 apiVersion: v1
 clusters:
@@ -296,7 +318,7 @@ func TestUpdate(t *testing.T) {
 
 			test.cfg.SetPath(filepath.Join(tmpDir, "kubeconfig"))
 			if len(test.existingCfg) != 0 {
-				if err := os.WriteFile(test.cfg.filePath(), test.existingCfg, 0600); err != nil {
+				if err := os.WriteFile(test.cfg.Path(), test.existingCfg, 0600); err != nil {
 					t.Fatalf("WriteFile: %v", err)
 				}
 			}
@@ -307,7 +329,7 @@ func TestUpdate(t *testing.T) {
 			if err == nil && test.err {
 				t.Errorf("Expected error but got none")
 			}
-			config, err := readOrNew(test.cfg.filePath())
+			config, err := readOrNew(test.cfg.Path())
 			if err != nil {
 				t.Errorf("Error reading kubeconfig file: %v", err)
 			}
@@ -324,6 +346,63 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestExport(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, f := range []string{"ca.crt", "client.crt", "client.key"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, f), []byte(f+" data"), 0600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	for _, embed := range []bool{false, true} {
+		t.Run("embed="+strconv.FormatBool(embed), func(t *testing.T) {
+			data, err := Export(&Settings{
+				ClusterName:          "test",
+				Namespace:            "apps",
+				ClusterServerAddress: "https://10.0.0.5:8443",
+				ClientCertificate:    filepath.Join(tmpDir, "client.crt"),
+				ClientKey:            filepath.Join(tmpDir, "client.key"),
+				CertificateAuthority: filepath.Join(tmpDir, "ca.crt"),
+				KeepContext:          true,
+				EmbedCerts:           embed,
+			})
+			if err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+
+			config, err := decode(data)
+			if err != nil {
+				t.Fatalf("decode exported kubeconfig: %v", err)
+			}
+			if len(config.Clusters) != 1 || len(config.Contexts) != 1 || len(config.AuthInfos) != 1 {
+				t.Errorf("exported kubeconfig has other contexts: %s", data)
+			}
+			if config.CurrentContext != "test" {
+				t.Errorf("current context = %q, want test", config.CurrentContext)
+			}
+			cluster := config.Clusters["test"]
+			if cluster == nil || cluster.Server != "https://10.0.0.5:8443" {
+				t.Fatalf("exported cluster = %+v, want the server https://10.0.0.5:8443", cluster)
+			}
+			if _, ok := cluster.Extensions["cluster_info"]; !ok {
+				t.Errorf("exported cluster has no minikube extension")
+			}
+			if config.Contexts["test"].Namespace != "apps" {
+				t.Errorf("namespace = %q, want apps", config.Contexts["test"].Namespace)
+			}
+
+			user := config.AuthInfos["test"]
+			if embed {
+				if string(cluster.CertificateAuthorityData) != "ca.crt data" || string(user.ClientKeyData) != "client.key data" || cluster.CertificateAuthority != "" {
+					t.Errorf("certificates are not embedded: %s", data)
+				}
+			} else if user.ClientKey != filepath.Join(tmpDir, "client.key") || len(user.ClientKeyData) != 0 {
+				t.Errorf("certificates are not referenced by path: %s", data)
+			}
+		})
+	}
+}
+
 func TestVerifyEndpoint(t *testing.T) {
 
 	var tests = []struct {
@@ -446,6 +525,14 @@ func TestUpdateIP(t *testing.T) {
 			status:      true,
 			expCfg:      kubeConfigLocalhost12345,
 		},
+		{
+			description: "ipv6 IP",
+			hostname:    "::1",
+			port:        8443,
+			existing:    kubeConfigLocalhost,
+			status:      true,
+			expCfg:      kubeConfigIPv6Localhost,
+		},
 		{
 			description: "no clusters",
 			hostname:    "192.168.10.100",
@@ -801,3 +888,53 @@ func TestGetKubeConfigPath(t *testing.T) {
 		}
 	}
 }
+
+func TestSetUserContext(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(configPath, kubeConfigWithoutHTTPSUpdated, 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := SetUserContext("missing", "alice", "", "/home/alice.crt", "/home/alice.key", false, configPath); err == nil {
+		t.Errorf("SetUserContext() of a missing cluster succeeded")
+	}
+
+	name, err := SetUserContext("minikube", "alice", "dev", "/home/alice.crt", "/home/alice.key", false, configPath)
+	if err != nil {
+		t.Fatalf("SetUserContext: %v", err)
+	}
+	if name != "alice@minikube" {
+		t.Errorf("context = %q, want alice@minikube", name)
+	}
+
+	config, err := readOrNew(configPath)
+	if err != nil {
+		t.Fatalf("Error reading kubeconfig file: %v", err)
+	}
+	context := config.Contexts[name]
+	if context == nil || context.Cluster != "minikube" || context.AuthInfo != name || context.Namespace != "dev" {
+		t.Fatalf("user context = %+v", context)
+	}
+	if user := config.AuthInfos[name]; user == nil || user.ClientKey != "/home/alice.key" {
+		t.Errorf("user = %+v, want the key /home/alice.key", user)
+	}
+	if config.CurrentContext != "minikube" {
+		t.Errorf("current context = %q, want it unchanged", config.CurrentContext)
+	}
+	if _, ok := config.Clusters[name]; ok {
+		t.Errorf("a cluster was added for the user")
+	}
+
+	if err := DeleteContext(name, configPath); err != nil {
+		t.Fatalf("DeleteContext: %v", err)
+	}
+	if config, err = readOrNew(configPath); err != nil {
+		t.Fatalf("Error reading kubeconfig file: %v", err)
+	}
+	if _, ok := config.AuthInfos[name]; ok {
+		t.Errorf("the user was not deleted")
+	}
+	if _, ok := config.Clusters["minikube"]; !ok {
+		t.Errorf("the cluster was deleted with its user")
+	}
+}
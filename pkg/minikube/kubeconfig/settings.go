@@ -25,6 +25,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/clientcmd/api/latest"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/util/lock"
 )
@@ -71,8 +72,8 @@ func (k *Settings) SetPath(kubeConfigFile string) {
 	k.kubeConfigFile.Store(kubeConfigFile)
 }
 
-// filePath gets the kubeconfig file
-func (k *Settings) filePath() string {
+// Path gets the kubeconfig file
+func (k *Settings) Path() string {
 	return k.kubeConfigFile.Load().(string)
 }
 
@@ -138,7 +139,7 @@ func PopulateFromSettings(cfg *Settings, apiCfg *api.Config) error {
 // activeContext is true when minikube is the CurrentContext
 // If no CurrentContext is set, the given name will be used.
 func Update(kcs *Settings) error {
-	spec := lock.PathMutexSpec(filepath.Join(kcs.filePath(), "settings.Update"))
+	spec := lock.PathMutexSpec(filepath.Join(kcs.Path(), "settings.Update"))
 	klog.Infof("acquiring lock: %+v", spec)
 	releaser, err := mutex.Acquire(spec)
 	if err != nil {
@@ -147,8 +148,8 @@ func Update(kcs *Settings) error {
 	defer releaser.Release()
 
 	// read existing config or create new if does not exist
-	klog.Infoln("Updating kubeconfig: ", kcs.filePath())
-	kcfg, err := readOrNew(kcs.filePath())
+	klog.Infoln("Updating kubeconfig: ", kcs.Path())
+	kcfg, err := readOrNew(kcs.Path())
 	if err != nil {
 		return err
 	}
@@ -162,8 +163,27 @@ func Update(kcs *Settings) error {
 	}
 
 	// write back to disk
-	if err := writeToFile(kcfg, kcs.filePath()); err != nil {
+	if err := writeToFile(kcfg, kcs.Path()); err != nil {
 		return errors.Wrap(err, "writing kubeconfig")
 	}
 	return nil
 }
+
+// Export returns a kubeconfig holding only the context of kcs, which is self-contained
+// when the certificates are embedded.
+func Export(kcs *Settings) ([]byte, error) {
+	kcfg := api.NewConfig()
+	ext := NewExtension()
+	kcs.ExtensionCluster = ext
+	kcs.ExtensionContext = ext
+	kcs.KeepContext = false
+	if err := PopulateFromSettings(kcs, kcfg); err != nil {
+		return nil, err
+	}
+
+	data, err := runtime.Encode(latest.Codec, kcfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "encode kubeconfig")
+	}
+	return data, nil
+}
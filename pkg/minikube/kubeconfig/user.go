@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/juju/mutex/v2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/util/lock"
+)
+
+// UserContext returns the name of the context of the user name of the cluster
+func UserContext(clusterName, name string) string {
+	return name + "@" + clusterName
+}
+
+// SetUserContext writes the context of the user name of the cluster, authenticated by a client certificate,
+// into the kubeconfig at configPath, which must already hold the cluster. It returns the name of the context.
+func SetUserContext(clusterName, name, namespace, certPath, keyPath string, embedCerts bool, configPath string) (string, error) {
+	spec := lock.PathMutexSpec(filepath.Join(configPath, "settings.Update"))
+	klog.Infof("acquiring lock: %+v", spec)
+	releaser, err := mutex.Acquire(spec)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to acquire lock for %+v", spec)
+	}
+	defer releaser.Release()
+
+	kcfg, err := readOrNew(configPath)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := kcfg.Clusters[clusterName]; !ok {
+		return "", errors.Errorf("%q does not appear in %s", clusterName, configPath)
+	}
+
+	contextName := UserContext(clusterName, name)
+	user := api.NewAuthInfo()
+	if embedCerts {
+		if user.ClientCertificateData, err = os.ReadFile(certPath); err != nil {
+			return "", errors.Wrapf(err, "reading ClientCertificate %s", certPath)
+		}
+		if user.ClientKeyData, err = os.ReadFile(keyPath); err != nil {
+			return "", errors.Wrapf(err, "reading ClientKey %s", keyPath)
+		}
+	} else {
+		user.ClientCertificate = certPath
+		user.ClientKey = keyPath
+	}
+	kcfg.AuthInfos[contextName] = user
+
+	context := api.NewContext()
+	context.Cluster = clusterName
+	context.Namespace = namespace
+	context.AuthInfo = contextName
+	context.Extensions = map[string]runtime.Object{"context_info": NewExtension()}
+	kcfg.Contexts[contextName] = context
+
+	if err := writeToFile(kcfg, configPath); err != nil {
+		return "", errors.Wrap(err, "writing kubeconfig")
+	}
+	return contextName, nil
+}
@@ -106,6 +106,66 @@ func PID(profile string) string {
 	return path.Join(Profile(profile), "pid")
 }
 
+// TunnelPID returns the path to the pid file of the tunnel running in the background for profile
+func TunnelPID(profile string) string {
+	return path.Join(Profile(profile), "tunnel.pid")
+}
+
+// TunnelLog returns the path to the log file of the tunnel running in the background for profile
+func TunnelLog(profile string) string {
+	return path.Join(Profile(profile), "tunnel.log")
+}
+
+// TunnelState returns the path to the JSON state of the tunnel running in the background for profile
+func TunnelState(profile string) string {
+	return path.Join(Profile(profile), "tunnel.json")
+}
+
+// MountPID returns the path to the pid file of the background mount name of profile
+func MountPID(profile, name string) string {
+	return path.Join(Profile(profile), "mounts", name+".pid")
+}
+
+// MountLog returns the path to the log file of the background mount name of profile
+func MountLog(profile, name string) string {
+	return path.Join(Profile(profile), "mounts", name+".log")
+}
+
+// MountState returns the path to the JSON state of the background mount name of profile
+func MountState(profile, name string) string {
+	return path.Join(Profile(profile), "mounts", name+".json")
+}
+
+// Kubeconfig returns the path to the separate kubeconfig of profile
+func Kubeconfig(profile string) string {
+	return path.Join(Profile(profile), "kubeconfig")
+}
+
+// UserCert returns the path to the client certificate of the user name of profile
+func UserCert(profile, name string) string {
+	return path.Join(Profile(profile), "users", name+".crt")
+}
+
+// UserKey returns the path to the client key of the user name of profile
+func UserKey(profile, name string) string {
+	return path.Join(Profile(profile), "users", name+".key")
+}
+
+// ForwardedSocket returns the path to the local unix socket forwarding the socket name of the node of profile
+func ForwardedSocket(profile, name string) string {
+	return path.Join(Profile(profile), "sockets", name+".sock")
+}
+
+// SocketForwardPID returns the path to the pid file of the process forwarding the sockets name of profile
+func SocketForwardPID(profile, name string) string {
+	return path.Join(Profile(profile), "sockets", name+".pid")
+}
+
+// SocketForwardLog returns the path to the log file of the process forwarding the sockets name of profile
+func SocketForwardLog(profile, name string) string {
+	return path.Join(Profile(profile), "sockets", name+".log")
+}
+
 // ClientKey returns client certificate path, used by kubeconfig
 func ClientKey(name string) string {
 	newKey := filepath.Join(Profile(name), "client.key")
@@ -131,6 +191,11 @@ func CACert() string {
 	return filepath.Join(MiniPath(), "ca.crt")
 }
 
+// CAKey returns the key of the minikube CA certificate shared between profiles
+func CAKey() string {
+	return filepath.Join(MiniPath(), "ca.key")
+}
+
 // MachinePath returns the minikube machine path of a machine
 func MachinePath(machine string, miniHome ...string) string {
 	miniPath := MiniPath()
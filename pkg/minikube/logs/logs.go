@@ -26,6 +26,7 @@ import (
 	"os/exec"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -33,6 +34,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/audit"
 	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
 	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/cruntime"
@@ -110,6 +112,22 @@ func Follow(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.Cluster
 	return nil
 }
 
+// APIServerAudit writes the last lines of the audit log of the API server, and follows it if asked to
+func APIServerAudit(cr logRunner, lines int, follow bool, logOutput io.Writer) error {
+	args := []string{"tail", "-n", strconv.Itoa(lines)}
+	if follow {
+		// -F keeps following the log once the API server rotates it
+		args = append(args, "-F")
+	}
+	cmd := exec.Command("sudo", append(args, bsutil.AuditLogPath)...)
+	cmd.Stdout = logOutput
+	cmd.Stderr = logOutput
+	if _, err := cr.RunCmd(cmd); err != nil {
+		return errors.Wrap(err, "apiserver audit log")
+	}
+	return nil
+}
+
 // IsProblem returns whether this line matches a known problem
 func IsProblem(line string) bool {
 	return rootCauseRe.MatchString(line) && !ignoreCauseRe.MatchString(line)
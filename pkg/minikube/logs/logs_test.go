@@ -17,8 +17,12 @@ limitations under the License.
 package logs
 
 import (
+	"bytes"
+	"os/exec"
+	"strings"
 	"testing"
 
+	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/config"
 )
 
@@ -89,3 +93,37 @@ func TestEnabledAddonPods(t *testing.T) {
 		}
 	}
 }
+
+// recordingRunner records the commands it is given, and writes their arguments to their output
+type recordingRunner struct {
+	cmds []string
+}
+
+func (r *recordingRunner) RunCmd(cmd *exec.Cmd) (*command.RunResult, error) {
+	r.cmds = append(r.cmds, strings.Join(cmd.Args, " "))
+	_, err := cmd.Stdout.Write([]byte(cmd.Args[len(cmd.Args)-1] + "\n"))
+	return &command.RunResult{Args: cmd.Args}, err
+}
+
+func TestAPIServerAudit(t *testing.T) {
+	tests := []struct {
+		follow bool
+		want   string
+	}{
+		{false, "sudo tail -n 20 /var/log/kubernetes/audit/audit.log"},
+		{true, "sudo tail -n 20 -F /var/log/kubernetes/audit/audit.log"},
+	}
+	for _, tc := range tests {
+		r := &recordingRunner{}
+		var b bytes.Buffer
+		if err := APIServerAudit(r, 20, tc.follow, &b); err != nil {
+			t.Fatalf("APIServerAudit() = %v", err)
+		}
+		if len(r.cmds) != 1 || r.cmds[0] != tc.want {
+			t.Errorf("APIServerAudit(follow=%v) ran %q, want %q", tc.follow, r.cmds, tc.want)
+		}
+		if b.String() != "/var/log/kubernetes/audit/audit.log\n" {
+			t.Errorf("APIServerAudit() output = %q, want the output of tail", b.String())
+		}
+	}
+}
@@ -0,0 +1,458 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/command"
+)
+
+// CopyOptions configures CopyPaths
+type CopyOptions struct {
+	// Archive preserves the owners of the files, instead of giving the copies to the user copying them
+	Archive bool
+}
+
+// ExpandCopySource returns the paths matching pattern, a glob of files in the node of
+// the runner, or on the host if the runner is nil.
+func ExpandCopySource(r command.Runner, pattern string) ([]string, error) {
+	if r == nil {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "glob %s", pattern)
+		}
+		if len(matches) == 0 {
+			return nil, &os.PathError{Op: "lstat", Path: pattern, Err: os.ErrNotExist}
+		}
+		return matches, nil
+	}
+
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{pattern}, nil
+	}
+
+	script := fmt.Sprintf(`shopt -s nullglob; for p in %s; do printf '%%s\n' "$p"; done`, escapeGlob(pattern))
+	rr, err := r.RunCmd(exec.Command("sudo", "/bin/bash", "-c", script))
+	if err != nil {
+		return nil, errors.Wrapf(err, "glob %s", pattern)
+	}
+
+	matches := []string{}
+	for _, m := range strings.Split(rr.Stdout.String(), "\n") {
+		if m != "" {
+			matches = append(matches, m)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, &os.PathError{Op: "lstat", Path: pattern, Err: os.ErrNotExist}
+	}
+	return matches, nil
+}
+
+// escapeGlob quotes the characters of a glob for bash, but the ones matching files
+func escapeGlob(pattern string) string {
+	var sb strings.Builder
+	for _, c := range pattern {
+		if !strings.ContainsRune("*?[]/._-", c) && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') && !('0' <= c && c <= '9') {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+// CopyPaths copies the files and directories srcs, from the node of the src runner
+// to the node of the dst runner, as a tar archive streamed between them. A nil
+// runner is the host. A single source is copied as target, unless target is a
+// directory or ends with a '/', several sources are copied into the directory target.
+func CopyPaths(src command.Runner, srcs []string, dst command.Runner, target string, opts CopyOptions) error {
+	if len(srcs) == 0 {
+		return errors.New("nothing to copy")
+	}
+
+	names := []string{}
+	for _, s := range srcs {
+		name := copyName(src, s)
+		if name == "/" || name == "." || name == ".." || name == "" {
+			return errors.Errorf("cannot copy %s", s)
+		}
+		names = append(names, name)
+	}
+
+	into := len(srcs) > 1 || strings.HasSuffix(target, "/")
+	if dst == nil {
+		into = into || strings.HasSuffix(target, string(filepath.Separator))
+	}
+
+	pr, pw := io.Pipe()
+	errc := make(chan error, 1)
+	go func() {
+		var err error
+		if src == nil {
+			err = writeHostArchive(pw, srcs)
+		} else {
+			err = writeNodeArchive(src, pw, srcs)
+		}
+		pw.CloseWithError(err)
+		errc <- err
+	}()
+
+	var err error
+	if dst == nil {
+		err = extractHostArchive(pr, target, names[0], into, opts)
+	} else {
+		err = extractNodeArchive(dst, pr, target, names[0], into, opts)
+	}
+	pr.CloseWithError(err)
+
+	werr := <-errc
+	switch {
+	case err != nil && werr != nil:
+		return errors.Errorf("extract archive: %v (create archive: %v)", err, werr)
+	case err != nil:
+		return errors.Wrap(err, "extract archive")
+	case werr != nil:
+		return errors.Wrap(werr, "create archive")
+	}
+	return nil
+}
+
+// copyName returns the name of a copied source in the archive
+func copyName(r command.Runner, src string) string {
+	if r == nil {
+		return filepath.Base(filepath.Clean(src))
+	}
+	return path.Base(path.Clean(src))
+}
+
+// writeHostArchive writes a tar archive of files of the host
+func writeHostArchive(w io.Writer, srcs []string) error {
+	tw := tar.NewWriter(w)
+	for _, src := range srcs {
+		src = filepath.Clean(src)
+		base := filepath.Base(src)
+		err := filepath.Walk(src, func(p string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			rel, err := filepath.Rel(src, p)
+			if err != nil {
+				return err
+			}
+
+			link := ""
+			if fi.Mode()&os.ModeSymlink != 0 {
+				if link, err = os.Readlink(p); err != nil {
+					return err
+				}
+			}
+
+			hdr, err := tar.FileInfoHeader(fi, filepath.ToSlash(link))
+			if err != nil {
+				return err
+			}
+			// the owners are preserved by id, the names of the host are meaningless in the nodes
+			hdr.Uname, hdr.Gname = "", ""
+			hdr.Name = path.Join(base, filepath.ToSlash(rel))
+			if fi.IsDir() {
+				hdr.Name += "/"
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if !fi.Mode().IsRegular() {
+				return nil
+			}
+
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(tw, f)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// writeNodeArchive writes a tar archive of files of the node
+func writeNodeArchive(r command.Runner, w io.Writer, srcs []string) error {
+	args := []string{"tar", "--numeric-owner", "-cf", "-"}
+	for _, src := range srcs {
+		src = path.Clean(src)
+		name := path.Base(src)
+		if strings.HasPrefix(name, "-") {
+			name = "./" + name
+		}
+		args = append(args, "-C", path.Dir(src), name)
+	}
+
+	c := exec.Command("sudo", args...)
+	// the archive is streamed, only the errors of tar are kept
+	c.Stdout = command.Stream(w)
+	if _, err := r.RunCmd(c); err != nil {
+		return err
+	}
+	return nil
+}
+
+// extractNodeScript returns the script extracting the archive of name into target
+func extractNodeScript(target, name string, into bool, opts CopyOptions) string {
+	owner := "--no-same-owner"
+	if opts.Archive {
+		owner = "--same-owner --numeric-owner"
+	}
+	// the archive is read to the end, so that the padding written after the last file doesn't fail the copy
+	extract := fmt.Sprintf("tar %s -xf - -C", owner)
+	drain := "cat >/dev/null"
+
+	t := shellquote.Join(path.Clean(target))
+	if into {
+		return fmt.Sprintf("set -e; mkdir -p %s; %s %s; %s", t, extract, t, drain)
+	}
+
+	dir := shellquote.Join(path.Dir(path.Clean(target)))
+	return fmt.Sprintf(`set -e; if [ -d %s ]; then %s %s; else mkdir -p %s; tmp=$(mktemp -d %s/.minikube-cp.XXXXXX); trap 'rm -rf "$tmp"' EXIT; %s "$tmp"; mv -fT "$tmp"/%s %s; fi; %s`,
+		t, extract, t, dir, dir, extract, shellquote.Join(name), t, drain)
+}
+
+// extractNodeArchive extracts the archive of name into target in the node
+func extractNodeArchive(r command.Runner, rd io.Reader, target, name string, into bool, opts CopyOptions) error {
+	c := exec.Command("sudo", "/bin/bash", "-c", extractNodeScript(target, name, into, opts))
+	c.Stdin = rd
+	if _, err := r.RunCmd(c); err != nil {
+		return err
+	}
+	return nil
+}
+
+// extractHostArchive extracts the archive of name into target on the host
+func extractHostArchive(rd io.Reader, target, name string, into bool, opts CopyOptions) error {
+	if st, err := os.Stat(target); err == nil && st.IsDir() {
+		into = true
+	}
+
+	root := target
+	if !into {
+		root = filepath.Dir(target)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return err
+	}
+
+	// dest returns the path of a file of the archive, which must not leave the target
+	dest := func(n string) (string, error) {
+		n = path.Clean(strings.TrimPrefix(n, "./"))
+		if path.IsAbs(n) || n == ".." || strings.HasPrefix(n, "../") {
+			return "", errors.Errorf("invalid file name %q in archive", n)
+		}
+		if into {
+			return filepath.Join(target, filepath.FromSlash(n)), nil
+		}
+
+		first, rest, _ := strings.Cut(n, "/")
+		if first != name {
+			return "", errors.Errorf("unexpected file %q in archive of %s", n, name)
+		}
+		return filepath.Join(target, filepath.FromSlash(rest)), nil
+	}
+
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return err
+	}
+	// links are the symlinks created by the extraction, no file of the archive is written through them
+	links := map[string]bool{}
+	// checkDir checks that the files written into dir do not leave the target through a symlink
+	checkDir := func(dir string) error {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return err
+		}
+		p := root
+		for _, c := range strings.Split(rel, string(filepath.Separator)) {
+			if c == "." {
+				continue
+			}
+			p = filepath.Join(p, c)
+			st, err := os.Lstat(p)
+			if os.IsNotExist(err) {
+				// the remaining directories are created by the extraction
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if st.Mode()&os.ModeSymlink == 0 {
+				continue
+			}
+			if links[p] {
+				return errors.Errorf("invalid file in archive, %q is a symlink of the archive", p)
+			}
+			real, err := filepath.EvalSymlinks(p)
+			if err != nil {
+				return err
+			}
+			if r, err := filepath.Rel(realRoot, real); err != nil || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+				return errors.Errorf("invalid file in archive, %q leaves %s", p, root)
+			}
+		}
+		return nil
+	}
+
+	type dirTimes struct {
+		path  string
+		mode  os.FileMode
+		mtime time.Time
+	}
+	dirs := []dirTimes{}
+
+	tr := tar.NewReader(rd)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		p, err := dest(hdr.Name)
+		if err != nil {
+			return err
+		}
+		mode := os.FileMode(hdr.Mode).Perm()
+		dir := filepath.Dir(p)
+		if hdr.Typeflag == tar.TypeDir {
+			dir = p
+		}
+		if err := checkDir(dir); err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			// the directories are made writable until their files are extracted
+			if err := os.MkdirAll(p, 0o755); err != nil {
+				return err
+			}
+			dirs = append(dirs, dirTimes{p, mode, hdr.ModTime})
+		case tar.TypeReg:
+			if err := extractHostFile(tr, p, mode); err != nil {
+				return err
+			}
+			if err := os.Chtimes(p, hdr.ModTime, hdr.ModTime); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.RemoveAll(p); err != nil {
+				return err
+			}
+			if err := os.Symlink(filepath.FromSlash(hdr.Linkname), p); err != nil {
+				return err
+			}
+			links[p] = true
+		case tar.TypeLink:
+			old, err := dest(hdr.Linkname)
+			if err != nil {
+				return err
+			}
+			if err := checkDir(filepath.Dir(old)); err != nil {
+				return err
+			}
+			if err := os.RemoveAll(p); err != nil {
+				return err
+			}
+			if err := os.Link(old, p); err != nil {
+				return err
+			}
+		default:
+			klog.Warningf("skipping %s, unsupported file type %c", hdr.Name, hdr.Typeflag)
+			continue
+		}
+
+		if opts.Archive && runtime.GOOS != "windows" {
+			if err := os.Lchown(p, hdr.Uid, hdr.Gid); err != nil {
+				return err
+			}
+		}
+	}
+
+	// set the modes of the directories once their files are extracted, children first
+	sort.Slice(dirs, func(i, j int) bool { return len(dirs[i].path) > len(dirs[j].path) })
+	for _, d := range dirs {
+		// skip the directories replaced by other files of the archive, not to follow symlinks
+		if st, err := os.Lstat(d.path); err != nil || !st.IsDir() {
+			continue
+		}
+		if err := os.Chmod(d.path, d.mode); err != nil {
+			return err
+		}
+		if err := os.Chtimes(d.path, d.mtime, d.mtime); err != nil {
+			return err
+		}
+	}
+
+	// read the padding after the end of the archive
+	_, err = io.Copy(io.Discard, rd)
+	return err
+}
+
+// extractHostFile writes a regular file of the archive
+func extractHostFile(r io.Reader, p string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	// replace the file instead of writing through a symlink
+	if st, err := os.Lstat(p); err == nil && !st.Mode().IsRegular() {
+		if err := os.RemoveAll(p); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Chmod(p, mode)
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readFile(t *testing.T, p string) string {
+	t.Helper()
+	b, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatalf("read copied file: %v", err)
+	}
+	return string(b)
+}
+
+func TestCopyPathsHost(t *testing.T) {
+	src := t.TempDir()
+	writeFiles(t, src, map[string]string{
+		"a.txt":              "a",
+		"fixtures/b.yaml":    "b",
+		"fixtures/sub/c.sh":  "c",
+		"fixtures/sub/d.txt": "d",
+	})
+	if err := os.Chmod(filepath.Join(src, "fixtures/sub/c.sh"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" {
+		if err := os.Symlink("b.yaml", filepath.Join(src, "fixtures/link")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("file", func(t *testing.T) {
+		dst := t.TempDir()
+		if err := CopyPaths(nil, []string{filepath.Join(src, "a.txt")}, nil, filepath.Join(dst, "renamed.txt"), CopyOptions{}); err != nil {
+			t.Fatalf("CopyPaths: %v", err)
+		}
+		if got := readFile(t, filepath.Join(dst, "renamed.txt")); got != "a" {
+			t.Errorf("copied file = %q, want a", got)
+		}
+	})
+
+	t.Run("file into directory", func(t *testing.T) {
+		dst := t.TempDir()
+		if err := CopyPaths(nil, []string{filepath.Join(src, "a.txt")}, nil, dst, CopyOptions{}); err != nil {
+			t.Fatalf("CopyPaths: %v", err)
+		}
+		if got := readFile(t, filepath.Join(dst, "a.txt")); got != "a" {
+			t.Errorf("copied file = %q, want a", got)
+		}
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "new")
+		if err := CopyPaths(nil, []string{filepath.Join(src, "fixtures")}, nil, dst, CopyOptions{}); err != nil {
+			t.Fatalf("CopyPaths: %v", err)
+		}
+		if got := readFile(t, filepath.Join(dst, "sub", "d.txt")); got != "d" {
+			t.Errorf("copied file = %q, want d", got)
+		}
+		if runtime.GOOS == "windows" {
+			return
+		}
+		st, err := os.Stat(filepath.Join(dst, "sub", "c.sh"))
+		if err != nil || st.Mode().Perm() != 0o750 {
+			t.Errorf("copied file mode = %v, %v, want 0750", st.Mode(), err)
+		}
+		if link, err := os.Readlink(filepath.Join(dst, "link")); err != nil || link != "b.yaml" {
+			t.Errorf("copied link = %q, %v, want b.yaml", link, err)
+		}
+	})
+
+	t.Run("glob", func(t *testing.T) {
+		dst := t.TempDir()
+		srcs, err := ExpandCopySource(nil, filepath.Join(src, "fixtures", "sub", "*.txt"))
+		if err != nil {
+			t.Fatalf("ExpandCopySource: %v", err)
+		}
+		srcs = append(srcs, filepath.Join(src, "a.txt"))
+		if err := CopyPaths(nil, srcs, nil, filepath.Join(dst, "out"), CopyOptions{}); err != nil {
+			t.Fatalf("CopyPaths: %v", err)
+		}
+		if got := readFile(t, filepath.Join(dst, "out", "d.txt")); got != "d" {
+			t.Errorf("copied file = %q, want d", got)
+		}
+		if got := readFile(t, filepath.Join(dst, "out", "a.txt")); got != "a" {
+			t.Errorf("copied file = %q, want a", got)
+		}
+		if _, err := os.Stat(filepath.Join(dst, "out", "c.sh")); !os.IsNotExist(err) {
+			t.Errorf("copied a file not matching the glob: %v", err)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		if _, err := ExpandCopySource(nil, filepath.Join(src, "*.missing")); !os.IsNotExist(err) {
+			t.Errorf("ExpandCopySource() of a glob matching nothing = %v, want not exist", err)
+		}
+		if err := CopyPaths(nil, []string{filepath.Join(src, "missing")}, nil, t.TempDir(), CopyOptions{}); err == nil {
+			t.Errorf("CopyPaths() of a missing file succeeded")
+		}
+	})
+}
+
+func TestExtractHostArchiveSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks need privileges on windows")
+	}
+
+	tests := []struct {
+		name    string
+		entries func(outside string) []tar.Header
+	}{
+		{
+			name: "through a symlink",
+			entries: func(outside string) []tar.Header {
+				return []tar.Header{
+					{Name: "link", Typeflag: tar.TypeSymlink, Linkname: outside},
+					{Name: "link/evil", Typeflag: tar.TypeReg, Mode: 0o644},
+				}
+			},
+		},
+		{
+			name: "through a nested symlink",
+			entries: func(outside string) []tar.Header {
+				return []tar.Header{
+					{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0o755},
+					{Name: "dir/link", Typeflag: tar.TypeSymlink, Linkname: "../../" + filepath.Base(outside)},
+					{Name: "dir/link/sub/evil", Typeflag: tar.TypeReg, Mode: 0o644},
+				}
+			},
+		},
+		{
+			name: "directory replaced by a symlink",
+			entries: func(outside string) []tar.Header {
+				return []tar.Header{
+					{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0o700},
+					{Name: "dir", Typeflag: tar.TypeSymlink, Linkname: outside},
+					{Name: "dir/evil", Typeflag: tar.TypeReg, Mode: 0o644},
+				}
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			base := t.TempDir()
+			outside := filepath.Join(base, "outside")
+			target := filepath.Join(base, "target")
+			for _, d := range []string{outside, target} {
+				if err := os.Mkdir(d, 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			for _, hdr := range tc.entries(outside) {
+				hdr := hdr
+				var content []byte
+				if hdr.Typeflag == tar.TypeReg {
+					content = []byte("evil")
+					hdr.Size = int64(len(content))
+				}
+				if err := tw.WriteHeader(&hdr); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := tw.Write(content); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := tw.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := extractHostArchive(&buf, target, "", true, CopyOptions{}); err == nil {
+				t.Errorf("extractHostArchive() of a malicious archive succeeded")
+			}
+			entries, err := os.ReadDir(outside)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) > 0 {
+				t.Errorf("extractHostArchive() wrote %s outside of the target", entries[0].Name())
+			}
+			st, err := os.Stat(outside)
+			if err != nil || st.Mode().Perm() != 0o755 {
+				t.Errorf("extractHostArchive() changed the mode of the outside directory: %v, %v", st.Mode(), err)
+			}
+		})
+	}
+}
+
+func TestExtractNodeScript(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		into   bool
+		opts   CopyOptions
+		want   string
+	}{
+		{
+			name:   "into",
+			target: "/data/",
+			into:   true,
+			want:   "set -e; mkdir -p /data; tar --no-same-owner -xf - -C /data; cat >/dev/null",
+		},
+		{
+			name:   "archive",
+			target: "/data dir",
+			into:   true,
+			opts:   CopyOptions{Archive: true},
+			want:   `set -e; mkdir -p '/data dir'; tar --same-owner --numeric-owner -xf - -C '/data dir'; cat >/dev/null`,
+		},
+		{
+			name:   "rename",
+			target: "/home/docker/b.txt",
+			want: `set -e; if [ -d /home/docker/b.txt ]; then tar --no-same-owner -xf - -C /home/docker/b.txt; ` +
+				`else mkdir -p /home/docker; tmp=$(mktemp -d /home/docker/.minikube-cp.XXXXXX); trap 'rm -rf "$tmp"' EXIT; ` +
+				`tar --no-same-owner -xf - -C "$tmp"; mv -fT "$tmp"/a.txt /home/docker/b.txt; fi; cat >/dev/null`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := extractNodeScript(tc.target, "a.txt", tc.into, tc.opts); got != tc.want {
+				t.Errorf("extractNodeScript() =\n%s\nwant\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEscapeGlob(t *testing.T) {
+	if got, want := escapeGlob("/var/log/my pods/*.log"), `/var/log/my\ pods/*.log`; got != want {
+		t.Errorf("escapeGlob() = %q, want %q", got, want)
+	}
+	if got, want := escapeGlob("/data/$(id)/[ab]?"), `/data/\$\(id\)/[ab]?`; got != want {
+		t.Errorf("escapeGlob() = %q, want %q", got, want)
+	}
+}
@@ -67,6 +67,11 @@ func fixHost(api libmachine.API, cc *config.ClusterConfig, n *config.Node) (*hos
 	// check if need to re-run docker-env
 	maybeWarnAboutEvalEnv(driverName, cc.Name)
 
+	grown, err := resizeIfNeeded(api, cc, h)
+	if err != nil {
+		return h, errors.Wrap(err, "resize")
+	}
+
 	h, err = recreateIfNeeded(api, cc, n, h)
 	if err != nil {
 		return h, err
@@ -90,6 +95,12 @@ func fixHost(api libmachine.API, cc *config.ClusterConfig, n *config.Node) (*hos
 		return h, errors.Wrap(err, "post-start")
 	}
 
+	if grown {
+		if err := growDataFilesystem(h); err != nil {
+			out.WarningT("Unable to grow the filesystem of {{.name}} to its new disk size: {{.error}}", out.V{"name": h.Name, "error": err})
+		}
+	}
+
 	// on vm node restart and for ha (multi-control plane) topology only (for now),
 	// we deliberately aim to restore backed up machine config early,
 	// so that remaining code logic can amend files as needed,
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+// imageArchSuffixes are the suffixes of the names of the images built for an architecture, e.g. kube-apiserver-amd64
+var imageArchSuffixes = []string{"-amd64", "-arm64", "-arm", "-ppc64le", "-s390x"}
+
+// LocalKubernetesImages returns the image tarballs in dir, the images built locally for --kubernetes-images-dir,
+// and the images of want which they provide, by the tags of the images in the tarballs
+func LocalKubernetesImages(dir string, want []string) ([]string, map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "reading Kubernetes images")
+	}
+	tarballs := []string{}
+	provided := map[string]string{}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".tar" {
+			continue
+		}
+		p := filepath.Join(dir, e.Name())
+		tags, err := imageTarballTags(p)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "image tarball %s", p)
+		}
+		tarballs = append(tarballs, p)
+		for _, tag := range tags {
+			if img := providedImage(tag, want); img != "" {
+				provided[tag] = img
+			}
+		}
+	}
+	if len(tarballs) == 0 {
+		return nil, nil, errors.Errorf("no image tarball in %s", dir)
+	}
+	return tarballs, provided, nil
+}
+
+// LoadLocalKubernetesImages loads the image tarballs in dir into the container runtime, and tags their images as the images of want which they provide
+func LoadLocalKubernetesImages(cc *config.ClusterConfig, runner command.Runner, dir string, want []string) error {
+	tarballs, provided, err := LocalKubernetesImages(dir, want)
+	if err != nil {
+		return err
+	}
+	if err := LoadLocalImages(cc, runner, tarballs); err != nil {
+		return err
+	}
+
+	r, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: runner})
+	if err != nil {
+		return errors.Wrap(err, "runtime")
+	}
+	tags := []string{}
+	for tag := range provided {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		img := provided[tag]
+		if img == tag {
+			continue
+		}
+		// the image of a previous start may hold the tag
+		if err := r.RemoveImage(img); err != nil {
+			klog.Infof("unable to remove %s: %v", img, err)
+		}
+		if err := r.TagImage(tag, img); err != nil {
+			return errors.Wrapf(err, "tagging %s as %s", tag, img)
+		}
+	}
+	return nil
+}
+
+// providedImage returns the image of want which the image tag provides, the image with the same name without the registry and the architecture suffix
+func providedImage(tag string, want []string) string {
+	name := imageName(tag)
+	for _, suffix := range imageArchSuffixes {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	for _, img := range want {
+		if imageName(img) == name {
+			return img
+		}
+	}
+	return ""
+}
+
+// imageName returns the name of an image, without its registry, repository and tag
+func imageName(img string) string {
+	if i := strings.LastIndex(img, "@"); i >= 0 {
+		img = img[:i]
+	}
+	name := path.Base(img)
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// imageTarballTags returns the tags of the images in a tarball saved by 'docker save', or in an OCI layout tarball
+func imageTarballTags(p string) ([]string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tags := []string{}
+	tr := tar.NewReader(f)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading tarball")
+		}
+		switch strings.TrimPrefix(h.Name, "./") {
+		case "manifest.json":
+			var manifest []struct {
+				RepoTags []string
+			}
+			if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+				return nil, errors.Wrap(err, "parsing manifest.json")
+			}
+			tags = tags[:0]
+			for _, m := range manifest {
+				tags = append(tags, m.RepoTags...)
+			}
+			// the tags of 'docker save' are authoritative
+			return tags, nil
+		case "index.json":
+			var index struct {
+				Manifests []struct {
+					Annotations map[string]string
+				}
+			}
+			if err := json.NewDecoder(tr).Decode(&index); err != nil {
+				return nil, errors.Wrap(err, "parsing index.json")
+			}
+			for _, m := range index.Manifests {
+				if name := m.Annotations["io.containerd.image.name"]; name != "" {
+					tags = append(tags, name)
+				}
+			}
+		}
+	}
+	if len(tags) == 0 {
+		return nil, errors.New("no tagged image")
+	}
+	return tags, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"archive/tar"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// writeImageTarball writes a tarball with the files
+func writeImageTarball(t *testing.T, p string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	tw := tar.NewWriter(f)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLocalKubernetesImages(t *testing.T) {
+	want := []string{
+		"registry.k8s.io/kube-apiserver:v1.35.0-alpha.1.42_0123456789abcd",
+		"registry.k8s.io/kube-scheduler:v1.35.0-alpha.1.42_0123456789abcd",
+		"registry.k8s.io/kube-proxy:v1.35.0-alpha.1.42_0123456789abcd",
+		"registry.k8s.io/pause:3.10",
+	}
+	dir := t.TempDir()
+	writeImageTarball(t, filepath.Join(dir, "kube-apiserver.tar"), map[string]string{
+		"manifest.json": `[{"Config":"abc.json","RepoTags":["registry.k8s.io/kube-apiserver-amd64:v1.35.0-alpha.1.42_0123456789abcd"],"Layers":[]}]`,
+	})
+	writeImageTarball(t, filepath.Join(dir, "kube-proxy.tar"), map[string]string{
+		"index.json": `{"manifests":[{"annotations":{"io.containerd.image.name":"localhost/kube-proxy:dev"}}]}`,
+	})
+	writeImageTarball(t, filepath.Join(dir, "kube-scheduler.tar"), map[string]string{
+		"manifest.json": `[{"RepoTags":["registry.k8s.io/kube-scheduler:v1.35.0-alpha.1.42_0123456789abcd"]}]`,
+	})
+	writeImageTarball(t, filepath.Join(dir, "debug.tar"), map[string]string{
+		"manifest.json": `[{"RepoTags":["example.com/debug:latest"]}]`,
+	})
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tarballs, provided, err := LocalKubernetesImages(dir, want)
+	if err != nil {
+		t.Fatalf("LocalKubernetesImages() = %v", err)
+	}
+	if len(tarballs) != 4 {
+		t.Errorf("LocalKubernetesImages() tarballs = %v, want the 4 tarballs", tarballs)
+	}
+	wantProvided := map[string]string{
+		"registry.k8s.io/kube-apiserver-amd64:v1.35.0-alpha.1.42_0123456789abcd": "registry.k8s.io/kube-apiserver:v1.35.0-alpha.1.42_0123456789abcd",
+		"localhost/kube-proxy:dev": "registry.k8s.io/kube-proxy:v1.35.0-alpha.1.42_0123456789abcd",
+		"registry.k8s.io/kube-scheduler:v1.35.0-alpha.1.42_0123456789abcd": "registry.k8s.io/kube-scheduler:v1.35.0-alpha.1.42_0123456789abcd",
+	}
+	if diff := cmp.Diff(wantProvided, provided); diff != "" {
+		t.Errorf("LocalKubernetesImages() provided mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, _, err := LocalKubernetesImages(t.TempDir(), want); err == nil {
+		t.Errorf("LocalKubernetesImages() of an empty directory succeeded")
+	}
+
+	writeImageTarball(t, filepath.Join(dir, "untagged.tar"), map[string]string{"layer.tar": ""})
+	if _, _, err := LocalKubernetesImages(dir, want); err == nil {
+		t.Errorf("LocalKubernetesImages() with an untagged image succeeded")
+	}
+}
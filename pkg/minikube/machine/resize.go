@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"os/exec"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/host"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/drivers/common"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+// growDataScript grows the partition holding the data of the VM, made by the automount script of the ISO, and its filesystem to the size of its disk
+const growDataScript = `DATA=$(blkid -o device -l -t LABEL=boot2docker-data) && DISK=/dev/$(lsblk -no pkname "$DATA") && parted --script --fix "$DISK" resizepart 1 100% && resize2fs "$DATA"`
+
+// resizedResources returns the resources of the config to apply to the machine, and whether they differ from its own.
+// The disk size is left as is if the machine does not limit it.
+func resizedResources(cc config.ClusterConfig, current common.Resources) (common.Resources, bool) {
+	want := common.Resources{CPUs: cc.CPUs, Memory: cc.Memory, DiskSize: cc.DiskSize}
+	if current.DiskSize == 0 {
+		want.DiskSize = 0
+	}
+	return want, want != current
+}
+
+// resizeIfNeeded applies the CPUs, memory and disk size of the config to an existing machine, stopping it first if it is a VM.
+// It returns whether the disk of the machine was grown, so that its filesystem is grown once started.
+func resizeIfNeeded(api libmachine.API, cc *config.ClusterConfig, h *host.Host) (bool, error) {
+	r, ok := h.Driver.(common.Resizer)
+	if !ok {
+		return false, nil
+	}
+	current := r.Resources()
+	want, changed := resizedResources(*cc, current)
+	if !changed {
+		return false, nil
+	}
+
+	out.Step(style.Improvement, `Resizing {{.name}} from {{.old_cpus}} CPUs, {{.old_memory}}MB of memory and {{.old_disk}}MB of disk to {{.cpus}} CPUs, {{.memory}}MB of memory and {{.disk}}MB of disk ...`,
+		out.V{"name": h.Name, "old_cpus": current.CPUs, "old_memory": current.Memory, "old_disk": current.DiskSize, "cpus": want.CPUs, "memory": want.Memory, "disk": want.DiskSize})
+	if driver.IsVM(h.DriverName) {
+		if s, err := h.Driver.GetState(); err == nil && s == state.Running {
+			out.Step(style.Stopping, `Stopping node "{{.name}}" to resize it ...`, out.V{"name": h.Name})
+			if err := stop(h); err != nil {
+				return false, err
+			}
+		}
+	}
+	if err := r.Resize(want); err != nil {
+		return false, err
+	}
+	if err := api.Save(h); err != nil {
+		return false, errors.Wrap(err, "save")
+	}
+	return want.DiskSize > current.DiskSize, nil
+}
+
+// growDataFilesystem grows the data partition and filesystem of a VM to the size of its grown disk
+func growDataFilesystem(h *host.Host) error {
+	r, err := CommandRunner(h)
+	if err != nil {
+		return err
+	}
+	if _, err := r.RunCmd(exec.Command("sudo", "/bin/bash", "-c", growDataScript)); err != nil {
+		return errors.Wrap(err, "grow data filesystem")
+	}
+	klog.Infof("grew the data filesystem of %s", h.Name)
+	return nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"k8s.io/minikube/pkg/drivers/common"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestResizedResources(t *testing.T) {
+	cc := config.ClusterConfig{CPUs: 4, Memory: 8192, DiskSize: 40000}
+	tests := []struct {
+		description string
+		current     common.Resources
+		want        common.Resources
+		wantChanged bool
+	}{
+		{"unchanged VM", common.Resources{CPUs: 4, Memory: 8192, DiskSize: 40000}, common.Resources{CPUs: 4, Memory: 8192, DiskSize: 40000}, false},
+		{"resized VM", common.Resources{CPUs: 2, Memory: 4096, DiskSize: 20000}, common.Resources{CPUs: 4, Memory: 8192, DiskSize: 40000}, true},
+		{"unchanged container", common.Resources{CPUs: 4, Memory: 8192}, common.Resources{CPUs: 4, Memory: 8192}, false},
+		{"resized container", common.Resources{CPUs: 2, Memory: 8192}, common.Resources{CPUs: 4, Memory: 8192}, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got, changed := resizedResources(cc, tc.current)
+			if got != tc.want || changed != tc.wantChanged {
+				t.Errorf("resizedResources(%+v) = %+v, %t, want %+v, %t", tc.current, got, changed, tc.want, tc.wantChanged)
+			}
+		})
+	}
+}
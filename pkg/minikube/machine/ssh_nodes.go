@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"bytes"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/docker/machine/libmachine"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+// NodeCommandResult is the result of a command run on a node
+type NodeCommandResult struct {
+	Node     string `json:"node"`
+	ExitCode int    `json:"exitCode"`
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Failed returns whether the command failed, or could not be run on the node
+func (r NodeCommandResult) Failed() bool {
+	return r.Error != ""
+}
+
+// RunCommandOnNodes runs the command concurrently on the nodes, in SSH sessions as the user of the
+// node, as 'minikube ssh' does, whatever the driver. The output of the command is written to stdout and stderr as it
+// comes, each line prefixed by the name of the node, unless they are nil. The results are
+// in the order of the nodes.
+func RunCommandOnNodes(api libmachine.API, cc config.ClusterConfig, nodes []config.Node, args []string, stdout, stderr io.Writer) []NodeCommandResult {
+	names := []string{}
+	runners := []command.Runner{}
+	errs := []error{}
+	for _, n := range nodes {
+		names = append(names, config.MachineName(cc, n))
+		h, err := GetHost(api, cc, n)
+		if err != nil {
+			runners = append(runners, nil)
+			errs = append(errs, err)
+			continue
+		}
+
+		// not CommandRunner, which may run the commands as root
+		runners = append(runners, command.NewSSHRunner(h.Driver))
+		errs = append(errs, nil)
+	}
+
+	return runCommandOnRunners(names, runners, errs, args, stdout, stderr)
+}
+
+// runCommandOnRunners runs the command concurrently with the runners, the ones with an error are skipped
+func runCommandOnRunners(names []string, runners []command.Runner, errs []error, args []string, stdout, stderr io.Writer) []NodeCommandResult {
+	results := make([]NodeCommandResult, len(names))
+	width := 0
+	for _, name := range names {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := range names {
+		results[i].Node = names[i]
+		if errs[i] != nil {
+			results[i].ExitCode = -1
+			results[i].Error = errs[i].Error()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			prefix := names[i] + strings.Repeat(" ", width-len(names[i])) + " | "
+			c := exec.Command("/bin/bash", "-c", strings.Join(args, " "))
+			var outw, errw *prefixWriter
+			if stdout != nil {
+				outw = &prefixWriter{mu: &mu, w: stdout, prefix: prefix}
+				c.Stdout = outw
+			}
+			if stderr != nil {
+				errw = &prefixWriter{mu: &mu, w: stderr, prefix: prefix}
+				c.Stderr = errw
+			}
+
+			rr, err := runners[i].RunCmd(c)
+			outw.Flush()
+			errw.Flush()
+			if rr != nil {
+				results[i].ExitCode = rr.ExitCode
+				results[i].Stdout = rr.Stdout.String()
+				results[i].Stderr = rr.Stderr.String()
+			}
+			if err != nil {
+				if results[i].ExitCode == 0 {
+					results[i].ExitCode = -1
+				}
+				// the output is already in the result, keep the reason only
+				results[i].Error = strings.SplitN(err.Error(), "\n", 2)[0]
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// prefixWriter writes the complete lines written to it to w, prefixed, with the other
+// prefixWriters sharing its mutex
+type prefixWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf = append(p.buf, b...)
+	i := bytes.LastIndexByte(p.buf, '\n')
+	if i < 0 {
+		return len(b), nil
+	}
+
+	lines := p.buf[:i+1]
+	if err := p.writeLines(lines); err != nil {
+		return 0, err
+	}
+	p.buf = append(p.buf[:0], p.buf[i+1:]...)
+	return len(b), nil
+}
+
+// Flush writes the last line, if it doesn't end with a newline
+func (p *prefixWriter) Flush() {
+	if p == nil || len(p.buf) == 0 {
+		return
+	}
+	_ = p.writeLines(append(p.buf, '\n'))
+	p.buf = nil
+}
+
+func (p *prefixWriter) writeLines(lines []byte) error {
+	var sb bytes.Buffer
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		sb.WriteString(p.prefix)
+		sb.Write(line)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err := p.w.Write(sb.Bytes())
+	return err
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/command"
+)
+
+// nodeRunner writes the output of the commands to their writers, as the runners of the nodes do
+type nodeRunner struct {
+	*command.FakeCommandRunner
+	stdout   string
+	stderr   string
+	exitCode int
+}
+
+func (r *nodeRunner) RunCmd(cmd *exec.Cmd) (*command.RunResult, error) {
+	rr := &command.RunResult{Args: cmd.Args, ExitCode: r.exitCode}
+	rr.Stdout.WriteString(r.stdout)
+	rr.Stderr.WriteString(r.stderr)
+	if cmd.Stdout != nil {
+		fmt.Fprint(cmd.Stdout, r.stdout)
+	}
+	if cmd.Stderr != nil {
+		fmt.Fprint(cmd.Stderr, r.stderr)
+	}
+	if r.exitCode != 0 {
+		return rr, fmt.Errorf("%s: Process exited with status %d\nstdout:\n%s", rr.Command(), r.exitCode, r.stdout)
+	}
+	return rr, nil
+}
+
+func TestRunCommandOnRunners(t *testing.T) {
+	names := []string{"minikube", "minikube-m02", "minikube-m03"}
+	runners := []command.Runner{
+		&nodeRunner{stdout: "1\n"},
+		&nodeRunner{stdout: "0\nfailed", stderr: "sysctl: permission denied\n", exitCode: 2},
+		nil,
+	}
+	errs := []error{nil, nil, errors.New(`"minikube-m03" is not running`)}
+
+	var stdout, stderr bytes.Buffer
+	results := runCommandOnRunners(names, runners, errs, []string{"sysctl", "-n", "net.ipv4.ip_forward"}, &stdout, &stderr)
+
+	want := []NodeCommandResult{
+		{Node: "minikube", Stdout: "1\n"},
+		{Node: "minikube-m02", ExitCode: 2, Stdout: "0\nfailed", Stderr: "sysctl: permission denied\n", Error: "/bin/bash -c \"sysctl -n net.ipv4.ip_forward\": Process exited with status 2"},
+		{Node: "minikube-m03", ExitCode: -1, Error: `"minikube-m03" is not running`},
+	}
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Errorf("results diff (-want +got):\n%s", diff)
+	}
+	if results[0].Failed() || !results[1].Failed() || !results[2].Failed() {
+		t.Errorf("unexpected failures: %+v", results)
+	}
+
+	// the nodes run concurrently, their lines come in any order
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	sort.Strings(lines)
+	wantLines := []string{"minikube     | 1", "minikube-m02 | 0", "minikube-m02 | failed"}
+	if diff := cmp.Diff(wantLines, lines); diff != "" {
+		t.Errorf("stdout diff (-want +got):\n%s", diff)
+	}
+	if got, want := stderr.String(), "minikube-m02 | sysctl: permission denied\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/drivers/common"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+const (
+	// memoryEvictionThreshold is the memory.available hard eviction threshold of the kubelet, below which it reports MemoryPressure
+	memoryEvictionThreshold = 100 << 20
+	// nearPressurePercent is the usage of the memory, the disk or the PIDs of a node from which it is flagged as near pressure
+	nearPressurePercent = 90
+)
+
+// usageScript prints the CPU times of the node twice, over common.UsageInterval, then its CPUs, its memory in KiB,
+// the size and usage of the disk of /var in bytes, its processes and their limit
+var usageScript = fmt.Sprintf(`head -n1 /proc/stat; sleep %d; head -n1 /proc/stat; nproc; grep -E '^(MemTotal|MemAvailable):' /proc/meminfo; df -B1 --output=size,used /var | tail -n1; ls -d /proc/[0-9]* | wc -l; cat /proc/sys/kernel/pid_max`,
+	int(common.UsageInterval.Seconds()))
+
+// NodeUsage is the usage of the resources of a node
+type NodeUsage struct {
+	Name        string
+	CPUs        int
+	CPUPercent  float64 // of all the CPUs of the node
+	MemoryUsed  int64   // bytes
+	MemoryTotal int64   // bytes
+	DiskUsed    int64   // bytes
+	DiskTotal   int64   // bytes
+	PIDs        int
+	PIDsLimit   int
+	Source      string // the driver when the CPU and memory are measured from the host, "node" when they are measured in the node
+}
+
+// ReadNodeUsage returns the usage of the resources of a running node. The CPU and the memory are measured from the host,
+// when the driver supports it, as the host sees the overhead of the machine, and the node cannot see its limits.
+func ReadNodeUsage(api libmachine.API, cc config.ClusterConfig, n config.Node) (*NodeUsage, error) {
+	name := config.MachineName(cc, n)
+	h, err := LoadHost(api, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "load host")
+	}
+	r, err := CommandRunner(h)
+	if err != nil {
+		return nil, errors.Wrap(err, "command runner")
+	}
+
+	type hostUsage struct {
+		usage *common.Usage
+		err   error
+	}
+	fromHost := make(chan hostUsage, 1)
+	go func() {
+		reporter, ok := h.Driver.(common.UsageReporter)
+		if !ok {
+			fromHost <- hostUsage{err: errors.Errorf("the %s driver does not report usage", cc.Driver)}
+			return
+		}
+		u, err := reporter.Usage()
+		fromHost <- hostUsage{usage: u, err: err}
+	}()
+
+	rr, err := r.RunCmd(exec.Command("/bin/bash", "-c", usageScript))
+	if err != nil {
+		return nil, errors.Wrap(err, "read usage in node")
+	}
+	u, err := parseNodeUsage(rr.Stdout.String())
+	if err != nil {
+		return nil, err
+	}
+	u.Name = name
+	u.Source = "node"
+
+	hu := <-fromHost
+	if hu.err != nil {
+		klog.Infof("unable to measure the usage of %s from the host: %v", name, hu.err)
+		return u, nil
+	}
+	u.applyHostUsage(cc.Driver, hu.usage)
+	return u, nil
+}
+
+// applyHostUsage replaces the CPU and memory measured in the node by those measured from the host.
+// The CPUs seen in the node are those of the host when the machine is a container, limited to fewer CPUs.
+func (u *NodeUsage) applyHostUsage(drv string, hu *common.Usage) {
+	if hu.CPUs > 0 {
+		u.CPUs = hu.CPUs
+	}
+	if u.CPUs > 0 {
+		u.CPUPercent = hu.CPUPercent / float64(u.CPUs)
+	}
+	u.MemoryUsed = hu.MemoryUsed
+	if hu.MemoryLimit > 0 {
+		u.MemoryTotal = hu.MemoryLimit
+	}
+	if hu.PIDs > 0 {
+		u.PIDs = hu.PIDs
+	}
+	u.Source = drv
+}
+
+// parseNodeUsage parses the output of usageScript
+func parseNodeUsage(s string) (*NodeUsage, error) {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) != 8 {
+		return nil, errors.Errorf("unexpected usage %q", s)
+	}
+	busy1, total1, err := parseCPUTimes(lines[0])
+	if err != nil {
+		return nil, err
+	}
+	busy2, total2, err := parseCPUTimes(lines[1])
+	if err != nil {
+		return nil, err
+	}
+
+	u := &NodeUsage{}
+	if total2 > total1 {
+		u.CPUPercent = 100 * float64(busy2-busy1) / float64(total2-total1)
+	}
+	if u.CPUs, err = strconv.Atoi(strings.TrimSpace(lines[2])); err != nil {
+		return nil, errors.Wrapf(err, "parse CPUs %q", lines[2])
+	}
+	memTotal, err := parseMeminfo(lines[3])
+	if err != nil {
+		return nil, err
+	}
+	memAvailable, err := parseMeminfo(lines[4])
+	if err != nil {
+		return nil, err
+	}
+	u.MemoryTotal, u.MemoryUsed = memTotal, memTotal-memAvailable
+
+	disk := strings.Fields(lines[5])
+	if len(disk) != 2 {
+		return nil, errors.Errorf("unexpected disk usage %q", lines[5])
+	}
+	if u.DiskTotal, err = strconv.ParseInt(disk[0], 10, 64); err != nil {
+		return nil, errors.Wrapf(err, "parse disk size %q", disk[0])
+	}
+	if u.DiskUsed, err = strconv.ParseInt(disk[1], 10, 64); err != nil {
+		return nil, errors.Wrapf(err, "parse disk usage %q", disk[1])
+	}
+	if u.PIDs, err = strconv.Atoi(strings.TrimSpace(lines[6])); err != nil {
+		return nil, errors.Wrapf(err, "parse PIDs %q", lines[6])
+	}
+	if u.PIDsLimit, err = strconv.Atoi(strings.TrimSpace(lines[7])); err != nil {
+		return nil, errors.Wrapf(err, "parse PIDs limit %q", lines[7])
+	}
+	return u, nil
+}
+
+// parseCPUTimes returns the busy and total times of the CPUs, from the cpu line of /proc/stat:
+// cpu user nice system idle iowait irq softirq steal guest guest_nice, where guest times are included in user times
+func parseCPUTimes(line string) (int64, int64, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 9 || fields[0] != "cpu" {
+		return 0, 0, errors.Errorf("unexpected CPU times %q", line)
+	}
+	var total, idle int64
+	for i, f := range fields[1:9] {
+		v, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "parse CPU times %q", line)
+		}
+		total += v
+		// idle and iowait
+		if i == 3 || i == 4 {
+			idle += v
+		}
+	}
+	return total - idle, total, nil
+}
+
+// parseMeminfo returns the bytes of a line of /proc/meminfo, such as "MemTotal: 8039732 kB"
+func parseMeminfo(line string) (int64, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 3 || fields[2] != "kB" {
+		return 0, errors.Errorf("unexpected memory info %q", line)
+	}
+	v, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parse memory info %q", line)
+	}
+	return v << 10, nil
+}
+
+// Pressure returns the resources of the node near the thresholds at which the kubelet reports the MemoryPressure, DiskPressure
+// and PIDPressure conditions
+func (u *NodeUsage) Pressure() []string {
+	near := func(used, total int64) bool {
+		return total > 0 && used*100 >= total*nearPressurePercent
+	}
+	var resources []string
+	if near(u.MemoryUsed, u.MemoryTotal) || (u.MemoryTotal > 0 && u.MemoryTotal-u.MemoryUsed < 2*memoryEvictionThreshold) {
+		resources = append(resources, "memory")
+	}
+	if near(u.DiskUsed, u.DiskTotal) {
+		resources = append(resources, "disk")
+	}
+	if near(int64(u.PIDs), int64(u.PIDsLimit)) {
+		resources = append(resources, "pids")
+	}
+	return resources
+}
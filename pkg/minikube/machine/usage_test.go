@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/minikube/pkg/drivers/common"
+)
+
+func TestParseNodeUsage(t *testing.T) {
+	out := `cpu  1000 0 500 8000 500 0 0 0 0 0
+cpu  1300 0 600 8300 600 0 0 0 0 0
+4
+MemTotal:        4194304 kB
+MemAvailable:    1048576 kB
+ 20000000000 5000000000
+312
+4194304
+`
+	got, err := parseNodeUsage(out)
+	if err != nil {
+		t.Fatalf("parseNodeUsage returned error: %v", err)
+	}
+	want := &NodeUsage{
+		CPUs:        4,
+		CPUPercent:  50,
+		MemoryUsed:  3 << 30,
+		MemoryTotal: 4 << 30,
+		DiskUsed:    5000000000,
+		DiskTotal:   20000000000,
+		PIDs:        312,
+		PIDsLimit:   4194304,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("parseNodeUsage mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := parseNodeUsage("cpu  1000 0 500 8000 500 0 0 0 0 0\n"); err == nil {
+		t.Error("expected an error for a truncated usage")
+	}
+}
+
+func TestApplyHostUsage(t *testing.T) {
+	u := &NodeUsage{CPUs: 4, CPUPercent: 10, MemoryUsed: 1 << 30, MemoryTotal: 8 << 30, PIDs: 300, Source: "node"}
+	u.applyHostUsage("docker", &common.Usage{CPUPercent: 200, MemoryUsed: 2 << 30, MemoryLimit: 4 << 30, PIDs: 350})
+	want := &NodeUsage{CPUs: 4, CPUPercent: 50, MemoryUsed: 2 << 30, MemoryTotal: 4 << 30, PIDs: 350, Source: "docker"}
+	if diff := cmp.Diff(want, u); diff != "" {
+		t.Errorf("applyHostUsage mismatch (-want +got):\n%s", diff)
+	}
+
+	// a container limited to 2 of the 8 CPUs of the host
+	u = &NodeUsage{CPUs: 8, CPUPercent: 10, Source: "node"}
+	u.applyHostUsage("docker", &common.Usage{CPUPercent: 150, CPUs: 2})
+	want = &NodeUsage{CPUs: 2, CPUPercent: 75, Source: "docker"}
+	if diff := cmp.Diff(want, u); diff != "" {
+		t.Errorf("applyHostUsage with limited CPUs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPressure(t *testing.T) {
+	tests := []struct {
+		description string
+		usage       NodeUsage
+		want        []string
+	}{
+		{"idle", NodeUsage{MemoryUsed: 1 << 30, MemoryTotal: 4 << 30, DiskUsed: 1, DiskTotal: 10, PIDs: 300, PIDsLimit: 4194304}, nil},
+		{"memory near eviction", NodeUsage{MemoryUsed: 3900 << 20, MemoryTotal: 4000 << 20}, []string{"memory"}},
+		{"small memory near eviction", NodeUsage{MemoryUsed: 400 << 20, MemoryTotal: 512 << 20}, []string{"memory"}},
+		{"disk and pids", NodeUsage{DiskUsed: 95, DiskTotal: 100, PIDs: 950, PIDsLimit: 1000}, []string{"disk", "pids"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.usage.Pressure()); diff != "" {
+				t.Errorf("Pressure mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/spf13/viper"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/cruntime"
@@ -96,6 +97,26 @@ func configureMounts(wg *sync.WaitGroup, cc config.ClusterConfig) {
 	}
 }
 
+// configureManagedMounts starts again the background mounts of the node created by 'minikube mount --daemon',
+// the caller adds it to wg
+func configureManagedMounts(wg *sync.WaitGroup, cc config.ClusterConfig, n config.Node) {
+	defer wg.Done()
+
+	for _, m := range cc.ManagedMounts {
+		if m.Node == "" && !config.IsPrimaryControlPlane(cc, n) || m.Node != "" && m.Node != n.Name {
+			continue
+		}
+		if st := cluster.ReadMountDaemonState(cc.Name, m.Name); st.Health != cluster.MountStopped {
+			klog.Infof("mount %s is already running as pid %d", m.Name, st.Pid)
+			continue
+		}
+		out.Step(style.Mounting, "Starting mount {{.name}} of {{.source}} into {{.target}} ...", out.V{"name": m.Name, "source": m.Source, "target": m.Target})
+		if _, err := cluster.StartMountDaemon(cc.Name, m); err != nil {
+			out.FailureT("Unable to start mount {{.name}}: {{.error}}", out.V{"name": m.Name, "error": err})
+		}
+	}
+}
+
 func generateMountArgs(profile string, cc config.ClusterConfig) []string {
 	mountDebugVal := 0
 	if klog.V(8).Enabled() {
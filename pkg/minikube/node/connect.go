@@ -0,0 +1,439 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
+	"k8s.io/minikube/pkg/minikube/cni"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/out"
+	kconst "k8s.io/minikube/third_party/kubeadm/app/constants"
+)
+
+// connectedNode is a node of a cluster attached to the network shared with another cluster
+type connectedNode struct {
+	name     string
+	ip       string // on the shared network
+	podCIDRs []string
+	runner   command.Runner
+}
+
+// ConnectProfiles connects the clusters of two profiles using the docker or podman driver: it attaches their nodes to a shared network,
+// and routes the pod and service CIDRs of each cluster through the nodes of the other. With dns, the CoreDNS of each cluster also forwards
+// the DNS domain of the other cluster to its CoreDNS. The profiles remember the connection, which is restored on next start.
+func ConnectProfiles(a, b *config.ClusterConfig, dns bool) error {
+	if err := validateConnection(*a, *b, dns); err != nil {
+		return err
+	}
+	if err := connect(*a, *b); err != nil {
+		return err
+	}
+	if dns {
+		if err := forwardDNSDomain(*a, *b); err != nil {
+			return errors.Wrapf(err, "forward DNS domain of %s", b.Name)
+		}
+		if err := forwardDNSDomain(*b, *a); err != nil {
+			return errors.Wrapf(err, "forward DNS domain of %s", a.Name)
+		}
+	}
+
+	for _, p := range []struct{ cc, peer *config.ClusterConfig }{{a, b}, {b, a}} {
+		if !slices.Contains(p.cc.ConnectedProfiles, p.peer.Name) {
+			p.cc.ConnectedProfiles = append(p.cc.ConnectedProfiles, p.peer.Name)
+			sort.Strings(p.cc.ConnectedProfiles)
+		}
+		if err := config.SaveProfile(p.cc.Name, p.cc); err != nil {
+			return errors.Wrapf(err, "save profile %s", p.cc.Name)
+		}
+	}
+	return nil
+}
+
+// DisconnectProfiles removes the routes, the DNS domains and the shared network added by ConnectProfiles
+func DisconnectProfiles(a, b *config.ClusterConfig) error {
+	network := oci.SharedNetworkName(a.Name, b.Name)
+	for _, p := range []struct{ cc, peer *config.ClusterConfig }{{a, b}, {b, a}} {
+		if err := removePeerRoutes(*p.cc, *p.peer); err != nil {
+			klog.Warningf("unable to remove the routes to %s from %s: %v", p.peer.Name, p.cc.Name, err)
+		}
+		if err := removeForwardedDNSDomain(*p.cc, DNSDomain(*p.peer)); err != nil {
+			klog.Warningf("unable to remove the DNS domain of %s from %s: %v", p.peer.Name, p.cc.Name, err)
+		}
+		for _, n := range p.cc.Nodes {
+			if err := oci.DisconnectNetwork(p.cc.Driver, network, config.MachineName(*p.cc, n)); err != nil {
+				return err
+			}
+		}
+		p.cc.ConnectedProfiles = slices.DeleteFunc(p.cc.ConnectedProfiles, func(name string) bool { return name == p.peer.Name })
+		if err := config.SaveProfile(p.cc.Name, p.cc); err != nil {
+			return errors.Wrapf(err, "save profile %s", p.cc.Name)
+		}
+	}
+	if err := oci.RemoveNetwork(a.Driver, network); err != nil {
+		klog.Warningf("unable to remove network %s: %v", network, err)
+	}
+	return nil
+}
+
+// ConfigureConnections restores the connections of a started cluster to the running clusters it was connected to, as the routes do not survive restarts
+// (intentionally non-fatal)
+func ConfigureConnections(cc config.ClusterConfig) {
+	for _, peer := range cc.ConnectedProfiles {
+		pc, err := config.Load(peer)
+		if err != nil {
+			klog.Warningf("unable to load connected profile %s: %v", peer, err)
+			continue
+		}
+		cp, err := config.ControlPlane(*pc)
+		if err != nil {
+			klog.Warningf("connected profile %s has no control plane: %v", peer, err)
+			continue
+		}
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			klog.Warningf("unable to get api client: %v", err)
+			return
+		}
+		if st, err := machine.Status(api, config.MachineName(*pc, cp)); err != nil || st != state.Running.String() {
+			klog.Infof("connected profile %s is not running (%s): %v", peer, st, err)
+			continue
+		}
+		out.Infof("Connecting to cluster {{.peer}} ...", out.V{"peer": peer})
+		if err := connect(cc, *pc); err != nil {
+			out.FailureT("Unable to connect to cluster {{.peer}}: {{.error}}", out.V{"peer": peer, "error": err})
+		}
+	}
+}
+
+// DisconnectAll disconnects a cluster from all the clusters it is connected to, before it is deleted (intentionally non-fatal)
+func DisconnectAll(cc config.ClusterConfig) {
+	for _, peer := range slices.Clone(cc.ConnectedProfiles) {
+		pc, err := config.Load(peer)
+		if err != nil {
+			klog.Warningf("unable to load connected profile %s: %v", peer, err)
+			continue
+		}
+		if err := DisconnectProfiles(&cc, pc); err != nil {
+			klog.Warningf("unable to disconnect %s from %s: %v", cc.Name, peer, err)
+		}
+	}
+}
+
+// validateConnection returns why the clusters cannot be connected, if they cannot
+func validateConnection(a, b config.ClusterConfig, dns bool) error {
+	if a.Name == b.Name {
+		return errors.New("a cluster cannot be connected to itself")
+	}
+	if !driver.IsKIC(a.Driver) || a.Driver != b.Driver {
+		return errors.Errorf("only clusters using the same docker or podman driver can be connected, %s uses %s and %s uses %s", a.Name, a.Driver, b.Name, b.Driver)
+	}
+	cidrsA, err := clusterCIDRs(a)
+	if err != nil {
+		return err
+	}
+	cidrsB, err := clusterCIDRs(b)
+	if err != nil {
+		return err
+	}
+	if ca, cb, ok := overlappingCIDRs(cidrsA, cidrsB); ok {
+		return errors.Errorf("the subnet %s of %s overlaps the subnet %s of %s, start one of them with different --service-cluster-ip-range and --extra-config=kubeadm.pod-network-cidr", ca, a.Name, cb, b.Name)
+	}
+	if dns && DNSDomain(a) == DNSDomain(b) {
+		return errors.Errorf("both clusters use the DNS domain %s, start one of them with a different --dns-domain", DNSDomain(a))
+	}
+	return nil
+}
+
+// clusterCIDRs returns the IPv4 service and pod CIDRs of a cluster
+func clusterCIDRs(cc config.ClusterConfig) ([]string, error) {
+	cnm, err := cni.New(&cc)
+	if err != nil {
+		return nil, errors.Wrap(err, "cni")
+	}
+	podCIDR := cnm.CIDR()
+	if override := cc.KubernetesConfig.ExtraOptions.Get("pod-network-cidr", bsutil.Kubeadm); override != "" {
+		podCIDR = override
+	}
+	cidrs := []string{}
+	for _, cidr := range append(config.ServiceCIDRs(cc.KubernetesConfig), cni.PodCIDRs(cc.KubernetesConfig, podCIDR)...) {
+		if ip, _, err := net.ParseCIDR(cidr); err == nil && ip.To4() != nil {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs, nil
+}
+
+// overlappingCIDRs returns the first pair of overlapping CIDRs of a and b
+func overlappingCIDRs(a, b []string) (string, string, bool) {
+	for _, ca := range a {
+		_, na, err := net.ParseCIDR(ca)
+		if err != nil {
+			continue
+		}
+		for _, cb := range b {
+			_, nb, err := net.ParseCIDR(cb)
+			if err != nil {
+				continue
+			}
+			if na.Contains(nb.IP) || nb.Contains(na.IP) {
+				return ca, cb, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// connect attaches the nodes of both clusters to their shared network, and routes the CIDRs of each cluster through the nodes of the other
+func connect(a, b config.ClusterConfig) error {
+	network := oci.SharedNetworkName(a.Name, b.Name)
+	if _, err := oci.CreateNetwork(a.Driver, network, "", "", false); err != nil {
+		return errors.Wrapf(err, "create network %s", network)
+	}
+	nodesA, err := attachNodes(a, network)
+	if err != nil {
+		return err
+	}
+	nodesB, err := attachNodes(b, network)
+	if err != nil {
+		return err
+	}
+	if err := addPeerRoutes(a, nodesA, b, nodesB); err != nil {
+		return errors.Wrapf(err, "route %s to %s", a.Name, b.Name)
+	}
+	if err := addPeerRoutes(b, nodesB, a, nodesA); err != nil {
+		return errors.Wrapf(err, "route %s to %s", b.Name, a.Name)
+	}
+	return nil
+}
+
+// attachNodes attaches the nodes of a cluster to the network, and returns them with their IP on the network and their pod CIDRs
+func attachNodes(cc config.ClusterConfig, network string) ([]connectedNode, error) {
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "get api client")
+	}
+	podCIDRs, err := nodePodCIDRs(cc)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []connectedNode{}
+	for _, n := range cc.Nodes {
+		name := config.MachineName(cc, n)
+		if err := oci.ConnectNetwork(cc.Driver, network, name); err != nil {
+			return nil, err
+		}
+		ip, err := oci.ContainerNetworkIP(cc.Driver, name, network)
+		if err != nil {
+			return nil, err
+		}
+		h, err := machine.LoadHost(api, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "load host %s", name)
+		}
+		r, err := machine.CommandRunner(h)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get command runner %s", name)
+		}
+		nodes = append(nodes, connectedNode{name: name, ip: ip, podCIDRs: podCIDRs[name], runner: r})
+	}
+	return nodes, nil
+}
+
+// nodePodCIDRs returns the pod CIDRs allocated to the nodes of a cluster, by node name
+func nodePodCIDRs(cc config.ClusterConfig) (map[string][]string, error) {
+	client, err := kapi.Client(cc.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "client for %s", cc.Name)
+	}
+	nodes, err := client.CoreV1().Nodes().List(context.Background(), meta.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list nodes of %s", cc.Name)
+	}
+	cidrs := map[string][]string{}
+	for _, n := range nodes.Items {
+		cidrs[n.Name] = n.Spec.PodCIDRs
+	}
+	return cidrs, nil
+}
+
+// peerRoutes returns the gateways on the shared network of the IPv4 pod and service CIDRs of a peer cluster, by CIDR:
+// the services are reached through its first node, and the pods through the node they run on, or through its first node
+// when the nodes have no pod CIDR allocated
+func peerRoutes(cidrs []string, nodes []connectedNode) map[string]string {
+	routes := map[string]string{}
+	if len(nodes) == 0 {
+		return routes
+	}
+	for _, cidr := range cidrs {
+		routes[cidr] = nodes[0].ip
+	}
+	for _, n := range nodes {
+		for _, cidr := range n.podCIDRs {
+			if ip, _, err := net.ParseCIDR(cidr); err == nil && ip.To4() != nil {
+				routes[cidr] = n.ip
+			}
+		}
+	}
+	return routes
+}
+
+// addPeerRoutes routes the CIDRs of the peer cluster through its nodes on every node of the cluster
+func addPeerRoutes(cc config.ClusterConfig, nodes []connectedNode, peer config.ClusterConfig, peerNodes []connectedNode) error {
+	cidrs, err := clusterCIDRs(peer)
+	if err != nil {
+		return err
+	}
+	routes := peerRoutes(cidrs, peerNodes)
+	for _, n := range nodes {
+		for cidr, gw := range routes {
+			klog.Infof("routing %s of %s through %s on node %s", cidr, peer.Name, gw, n.name)
+			if _, err := n.runner.RunCmd(exec.Command("sudo", "ip", "route", "replace", cidr, "via", gw)); err != nil {
+				return errors.Wrapf(err, "route %s on node %s", cidr, n.name)
+			}
+		}
+	}
+	return nil
+}
+
+// removePeerRoutes removes the routes to the CIDRs of the peer cluster from the nodes of the cluster
+func removePeerRoutes(cc, peer config.ClusterConfig) error {
+	cidrs, err := clusterCIDRs(peer)
+	if err != nil {
+		return err
+	}
+	podCIDRs, err := nodePodCIDRs(peer)
+	if err != nil {
+		klog.Warningf("unable to get the pod CIDRs of the nodes of %s: %v", peer.Name, err)
+	}
+	for _, nodeCIDRs := range podCIDRs {
+		cidrs = append(cidrs, nodeCIDRs...)
+	}
+
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		return errors.Wrap(err, "get api client")
+	}
+	for _, n := range cc.Nodes {
+		h, err := machine.LoadHost(api, config.MachineName(cc, n))
+		if err != nil {
+			return errors.Wrap(err, "load host")
+		}
+		r, err := machine.CommandRunner(h)
+		if err != nil {
+			return errors.Wrap(err, "get command runner")
+		}
+		for _, cidr := range cidrs {
+			if _, err := r.RunCmd(exec.Command("sudo", "ip", "route", "del", cidr)); err != nil {
+				klog.Infof("no route to %s on node %s: %v", cidr, n.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// DNSDomain returns the DNS domain of a cluster
+func DNSDomain(cc config.ClusterConfig) string {
+	if cc.KubernetesConfig.DNSDomain == "" {
+		return constants.ClusterDNSDomain
+	}
+	return cc.KubernetesConfig.DNSDomain
+}
+
+// forwardDNSDomain forwards the DNS domain of the peer cluster to its CoreDNS, from the CoreDNS of the cluster
+func forwardDNSDomain(cc, peer config.ClusterConfig) error {
+	peerClient, err := kapi.Client(peer.Name)
+	if err != nil {
+		return errors.Wrapf(err, "client for %s", peer.Name)
+	}
+	svc, err := peerClient.CoreV1().Services(meta.NamespaceSystem).Get(context.Background(), "kube-dns", meta.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "get DNS service of %s", peer.Name)
+	}
+	return updateCorefile(cc, func(corefile string) string {
+		return stubDomain(corefile, DNSDomain(peer), svc.Spec.ClusterIP)
+	})
+}
+
+// removeForwardedDNSDomain stops forwarding the DNS domain from the CoreDNS of the cluster
+func removeForwardedDNSDomain(cc config.ClusterConfig, domain string) error {
+	return updateCorefile(cc, func(corefile string) string {
+		return removeStubDomain(corefile, domain)
+	})
+}
+
+// updateCorefile updates the Corefile of the CoreDNS of the cluster, which CoreDNS reloads by itself
+func updateCorefile(cc config.ClusterConfig, update func(string) string) error {
+	client, err := kapi.Client(cc.Name)
+	if err != nil {
+		return errors.Wrapf(err, "client for %s", cc.Name)
+	}
+	cms := client.CoreV1().ConfigMaps(meta.NamespaceSystem)
+	cm, err := cms.Get(context.Background(), kconst.CoreDNSConfigMap, meta.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "get CoreDNS ConfigMap")
+	}
+	corefile := update(cm.Data["Corefile"])
+	if corefile == cm.Data["Corefile"] {
+		return nil
+	}
+	cm.Data["Corefile"] = corefile
+	if _, err := cms.Update(context.Background(), cm, meta.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "update CoreDNS ConfigMap")
+	}
+	return nil
+}
+
+// stubDomainBlock matches the server block of a DNS domain in a Corefile
+func stubDomainBlock(domain string) *regexp.Regexp {
+	return regexp.MustCompile(`(?ms)^` + regexp.QuoteMeta(domain) + `:53 \{\n.*?^\}\n?`)
+}
+
+// stubDomain returns the Corefile with a server block forwarding the DNS domain to ip, replacing the previous one if any
+// ref: https://kubernetes.io/docs/tasks/administer-cluster/dns-custom-nameservers/#configuration-of-stub-domain-and-upstream-nameserver-using-coredns
+func stubDomain(corefile, domain, ip string) string {
+	corefile = removeStubDomain(corefile, domain)
+	if !strings.HasSuffix(corefile, "\n") {
+		corefile += "\n"
+	}
+	return corefile + fmt.Sprintf("%s:53 {\n    errors\n    cache 30\n    forward . %s\n}\n", domain, ip)
+}
+
+// removeStubDomain returns the Corefile without the server block of the DNS domain
+func removeStubDomain(corefile, domain string) string {
+	return stubDomainBlock(domain).ReplaceAllString(corefile, "")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOverlappingCIDRs(t *testing.T) {
+	tests := []struct {
+		description string
+		a, b        []string
+		want        bool
+	}{
+		{"default service CIDRs", []string{"10.96.0.0/12"}, []string{"10.96.0.0/12"}, true},
+		{"nested CIDRs", []string{"10.244.0.0/16"}, []string{"10.244.1.0/24"}, true},
+		{"disjoint CIDRs", []string{"10.96.0.0/12", "10.244.0.0/16"}, []string{"10.112.0.0/12", "10.245.0.0/16"}, false},
+		{"invalid CIDR", []string{"10.96.0.0"}, []string{"10.96.0.0/12"}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if _, _, got := overlappingCIDRs(tc.a, tc.b); got != tc.want {
+				t.Errorf("overlappingCIDRs(%v, %v) = %t, want %t", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPeerRoutes(t *testing.T) {
+	cidrs := []string{"10.112.0.0/12", "10.245.0.0/16"}
+	tests := []struct {
+		description string
+		nodes       []connectedNode
+		want        map[string]string
+	}{
+		{"no nodes", nil, map[string]string{}},
+		{
+			"nodes without pod CIDRs",
+			[]connectedNode{{ip: "192.168.58.2"}, {ip: "192.168.58.3"}},
+			map[string]string{"10.112.0.0/12": "192.168.58.2", "10.245.0.0/16": "192.168.58.2"},
+		},
+		{
+			"nodes with pod CIDRs",
+			[]connectedNode{{ip: "192.168.58.2", podCIDRs: []string{"10.245.0.0/24"}}, {ip: "192.168.58.3", podCIDRs: []string{"10.245.1.0/24", "fd00::/64"}}},
+			map[string]string{"10.112.0.0/12": "192.168.58.2", "10.245.0.0/16": "192.168.58.2", "10.245.0.0/24": "192.168.58.2", "10.245.1.0/24": "192.168.58.3"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, peerRoutes(cidrs, tc.nodes)); diff != "" {
+				t.Errorf("peerRoutes mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStubDomain(t *testing.T) {
+	corefile := `.:53 {
+    errors
+    kubernetes cluster.local in-addr.arpa ip6.arpa
+    forward . /etc/resolv.conf
+}
+`
+	stub := `cluster-b.local:53 {
+    errors
+    cache 30
+    forward . 10.112.0.10
+}
+`
+	got := stubDomain(corefile, "cluster-b.local", "10.112.0.10")
+	if diff := cmp.Diff(corefile+stub, got); diff != "" {
+		t.Errorf("stubDomain mismatch (-want +got):\n%s", diff)
+	}
+	// connecting again replaces the server block
+	if diff := cmp.Diff(corefile+stub, stubDomain(got, "cluster-b.local", "10.112.0.10")); diff != "" {
+		t.Errorf("stubDomain mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(corefile, removeStubDomain(got, "cluster-b.local")); diff != "" {
+		t.Errorf("removeStubDomain mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(corefile, removeStubDomain(corefile, "cluster.local")); diff != "" {
+		t.Errorf("removeStubDomain mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/cluster/ha/haproxy"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/driver"
+)
+
+// ConfigureLoadBalancer starts the haproxy load balancer of an HA cluster created with --ha-lb=haproxy, or reloads it,
+// with the control-plane nodes of the cluster as backends
+func ConfigureLoadBalancer(cc config.ClusterConfig) error {
+	if !config.IsHAProxy(cc) {
+		return nil
+	}
+	cfg, err := haproxy.Config(cc)
+	if err != nil {
+		return errors.Wrap(err, "haproxy config")
+	}
+	network := cc.Network
+	if network == "" {
+		network = cc.Name
+	}
+	img := cacheLoadBalancerImage(cc.Driver, loadBalancerImage(cc.KubernetesConfig.ImageRepository))
+	return oci.ConfigureLoadBalancer(cc.Driver, cc.Name, network, cc.KubernetesConfig.APIServerHAVIP, img, cc.APIServerPort, cfg)
+}
+
+// loadBalancerImage returns the image of the load balancer, from the image repository when one is set, as for the kic base image
+func loadBalancerImage(repo string) string {
+	if repo == "" {
+		return oci.LoadBalancerImage
+	}
+	return path.Join(repo, strings.TrimPrefix(oci.LoadBalancerImage, "docker.io/library/"))
+}
+
+// cacheLoadBalancerImage loads the image of the load balancer into docker from the cache of minikube, downloading it to the cache first,
+// so that the load balancer can be created offline once it was. It returns the image loaded into docker, or img when docker pulls it itself.
+func cacheLoadBalancerImage(driverName, img string) string {
+	if !driver.IsDocker(driverName) || download.ImageExistsInDaemon(img) {
+		return img
+	}
+	klog.Infof("Downloading %s to local cache", img)
+	if err := download.ImageToCache(img); err != nil {
+		klog.Warningf("unable to cache %s, docker will pull it: %v", img, err)
+		return img
+	}
+	loaded, err := download.CacheToDaemon(img)
+	if err != nil {
+		klog.Warningf("unable to load %s from the cache, docker will pull it: %v", img, err)
+		return img
+	}
+	return loaded
+}
+
+// StopLoadBalancer stops the haproxy load balancer of an HA cluster created with --ha-lb=haproxy, it is started again on next start
+func StopLoadBalancer(cc config.ClusterConfig) {
+	if !config.IsHAProxy(cc) {
+		return
+	}
+	if err := oci.StopLoadBalancer(cc.Driver, cc.Name); err != nil {
+		klog.Warningf("unable to stop the load balancer: %v", err)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/kapi"
@@ -206,9 +207,48 @@ func Delete(cc config.ClusterConfig, name string) (*config.Node, error) {
 	}
 
 	cc.Nodes = append(cc.Nodes[:index], cc.Nodes[index+1:]...)
+	if n.ControlPlane {
+		if err := ConfigureLoadBalancer(cc); err != nil {
+			klog.Warningf("unable to remove node %q from the load balancer: %v", name, err)
+		}
+	}
 	return n, config.SaveProfile(viper.GetString(config.ProfileName), &cc)
 }
 
+// copiedNodeNames returns the names of the Kubernetes nodes in the data copied from another profile,
+// which are named after its machines
+func copiedNodeNames(cc config.ClusterConfig) []string {
+	if cc.CopiedFrom == "" {
+		return nil
+	}
+	from := cc
+	from.Name = cc.CopiedFrom
+	names := []string{}
+	for _, n := range cc.Nodes {
+		if m := config.MachineName(from, n); m != config.MachineName(cc, n) {
+			names = append(names, m)
+		}
+	}
+	return names
+}
+
+// removeCopiedNodes deletes the Kubernetes nodes in the data copied from another profile, once the control plane is up,
+// as the nodes register again under the names of their new machines
+func removeCopiedNodes(cc config.ClusterConfig) error {
+	client, err := kapi.Client(cc.Name)
+	if err != nil {
+		return err
+	}
+	for _, m := range copiedNodeNames(cc) {
+		err := client.CoreV1().Nodes().Delete(context.Background(), m, v1.DeleteOptions{})
+		if err != nil && !apierr.IsNotFound(err) {
+			return errors.Wrapf(err, "delete node %q", m)
+		}
+		klog.Infof("deleted node %q copied from profile %s", m, cc.CopiedFrom)
+	}
+	return nil
+}
+
 // Retrieve finds the node by name in the given cluster
 func Retrieve(cc config.ClusterConfig, name string) (*config.Node, int, error) {
 	for i, n := range cc.Nodes {
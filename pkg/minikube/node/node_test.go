@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestCopiedNodeNames(t *testing.T) {
+	tests := []struct {
+		description string
+		copiedFrom  string
+		nodes       []config.Node
+		want        []string
+	}{
+		{"not copied", "", []config.Node{{Name: ""}, {Name: "m02"}}, nil},
+		{"single node", "minikube", []config.Node{{Name: ""}}, []string{"minikube"}},
+		{"multi node", "minikube", []config.Node{{Name: ""}, {Name: "m02"}, {Name: "m03"}}, []string{"minikube", "minikube-m02", "minikube-m03"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			cc := config.ClusterConfig{Name: "team-a", CopiedFrom: tc.copiedFrom, Nodes: tc.nodes}
+			if diff := cmp.Diff(tc.want, copiedNodeNames(cc)); diff != "" {
+				t.Errorf("copiedNodeNames() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerImage(t *testing.T) {
+	if got := loadBalancerImage(""); got != oci.LoadBalancerImage {
+		t.Errorf("loadBalancerImage() = %q, want %q", got, oci.LoadBalancerImage)
+	}
+	want := "registry.example.com/mirror/" + strings.TrimPrefix(oci.LoadBalancerImage, "docker.io/library/")
+	if got := loadBalancerImage("registry.example.com/mirror"); got != want {
+		t.Errorf("loadBalancerImage() = %q, want %q", got, want)
+	}
+}
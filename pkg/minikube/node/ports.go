@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+// PublishPort publishes a port of the primary control-plane node of a running kic cluster on the host
+func PublishPort(cc config.ClusterConfig, p oci.PortForwarder) error {
+	cp, err := config.ControlPlane(cc)
+	if err != nil {
+		return errors.Wrap(err, "get control-plane node")
+	}
+	network := cc.Network
+	if network == "" {
+		network = cc.Name
+	}
+	return oci.CreatePortForwarder(cc.Driver, cc.Name, network, cc.KicBaseImage, cp.IP, p)
+}
+
+// UnpublishPorts removes the forwarders of all the ports published by 'minikube ports add', they are created again on next start
+func UnpublishPorts(cc config.ClusterConfig) {
+	if !driver.IsKIC(cc.Driver) {
+		return
+	}
+	for _, spec := range cc.PublishedPorts {
+		p, err := oci.ParsePortForwarder(spec)
+		if err != nil {
+			klog.Warningf("skipping invalid published port %q: %v", spec, err)
+			continue
+		}
+		if err := oci.DeletePortForwarder(cc.Driver, cc.Name, p); err != nil {
+			klog.Warningf("unable to remove forwarder of port %s: %v", p, err)
+		}
+	}
+}
+
+// configurePorts publishes again the ports added to the cluster by 'minikube ports add', as the node IP might have changed.
+// The caller adds it to wg before starting it.
+func configurePorts(wg *sync.WaitGroup, cc config.ClusterConfig) {
+	defer wg.Done()
+
+	if !driver.IsKIC(cc.Driver) {
+		return
+	}
+	for _, spec := range cc.PublishedPorts {
+		p, err := oci.ParsePortForwarder(spec)
+		if err != nil {
+			klog.Warningf("skipping invalid published port %q: %v", spec, err)
+			continue
+		}
+		out.Step(style.Connectivity, "Publishing port {{.port}} ...", out.V{"port": p})
+		if err := PublishPort(cc, p); err != nil {
+			out.FailureT("Unable to publish port {{.port}}: {{.error}}", out.V{"port": p, "error": err})
+		}
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
+
+	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/etcd"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+)
+
+// roleChangeTimeout is how long a node which changed its role is waited for, once it joined the cluster again
+const roleChangeTimeout = 6 * time.Minute
+
+// Promote makes the worker node a control-plane node of the HA (multi-control plane) cluster
+func Promote(cc *config.ClusterConfig, name string) (*config.Node, error) {
+	n, _, err := Retrieve(*cc, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieve node")
+	}
+	if err := canPromote(*cc, *n); err != nil {
+		return n, err
+	}
+
+	n.ControlPlane = true
+	if n.Port == 0 {
+		n.Port = cc.APIServerPort
+	}
+	return n, rejoin(cc, *n)
+}
+
+// Demote makes the control-plane node a worker node of the HA (multi-control plane) cluster
+func Demote(cc *config.ClusterConfig, name string) (*config.Node, error) {
+	n, _, err := Retrieve(*cc, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieve node")
+	}
+	if err := canDemote(*cc, *n); err != nil {
+		return n, err
+	}
+
+	n.ControlPlane = false
+	n.Worker = true
+	return n, rejoin(cc, *n)
+}
+
+// canPromote returns why the node cannot be promoted to a control-plane node, if it cannot
+func canPromote(cc config.ClusterConfig, n config.Node) error {
+	if !config.IsHA(cc) {
+		return errors.New("promoting a node is only supported in HA (multi-control plane) clusters, created with 'minikube start --ha'")
+	}
+	if n.ControlPlane {
+		return errors.Errorf("node %s is already a control-plane node", config.MachineName(cc, n))
+	}
+	return nil
+}
+
+// canDemote returns why the control-plane node cannot be demoted to a worker node, if it cannot
+func canDemote(cc config.ClusterConfig, n config.Node) error {
+	if !n.ControlPlane {
+		return errors.Errorf("node %s is not a control-plane node", config.MachineName(cc, n))
+	}
+	if config.IsPrimaryControlPlane(cc, n) {
+		return errors.Errorf("node %s is the primary control-plane node, which cannot be demoted", config.MachineName(cc, n))
+	}
+	// the cluster stays HA, and etcd keeps its quorum when a member fails
+	if len(config.ControlPlanes(cc)) < 3 {
+		return errors.New("demoting a node requires at least 3 control-plane nodes, so that the cluster keeps 2")
+	}
+	return nil
+}
+
+// rejoin resets the node and joins it to the cluster again, in its new role:
+// kubeadm reset removes the etcd member and the static pods of a control-plane node, including kube-vip,
+// and kubeadm join adds them back on a control-plane node, with the certificates shared by the control plane,
+// then the backends of the haproxy load balancer are updated, if any
+func rejoin(cc *config.ClusterConfig, n config.Node) error {
+	if _, err := teardown(*cc, n.Name); err != nil {
+		return errors.Wrap(err, "remove node")
+	}
+	if !n.ControlPlane {
+		removeEtcdMember(*cc, n)
+	}
+
+	if err := config.SaveNode(cc, &n); err != nil {
+		return errors.Wrap(err, "save node")
+	}
+
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		return errors.Wrap(err, "get api client")
+	}
+	h, err := machine.LoadHost(api, config.MachineName(*cc, n))
+	if err != nil {
+		return errors.Wrap(err, "load host")
+	}
+	r, err := machine.CommandRunner(h)
+	if err != nil {
+		return errors.Wrap(err, "get command runner")
+	}
+	cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: r, Socket: cc.KubernetesConfig.CRISocket})
+	if err != nil {
+		return errors.Wrap(err, "runtime")
+	}
+
+	bs, err := cluster.Bootstrapper(api, viper.GetString(cmdcfg.Bootstrapper), *cc, r)
+	if err != nil {
+		return errors.Wrap(err, "get bootstrapper")
+	}
+	cpr := mustload.Running(cc.Name).CP.Runner
+	if err := bs.SetupCerts(*cc, n, cpr); err != nil {
+		return errors.Wrap(err, "setting up certs")
+	}
+	if err := bs.UpdateNode(*cc, n, cr); err != nil {
+		return errors.Wrap(err, "update node")
+	}
+
+	pcpBs, err := cluster.ControlPlaneBootstrapper(api, cc, viper.GetString(cmdcfg.Bootstrapper))
+	if err != nil {
+		return errors.Wrap(err, "get primary control-plane bootstrapper")
+	}
+	starter := Starter{Runner: r, MachineAPI: api, Host: h, Cfg: cc, Node: &n}
+	if err := joinCluster(starter, pcpBs, bs); err != nil {
+		return errors.Wrap(err, "join node to cluster")
+	}
+	if err := ConfigureLoadBalancer(*cc); err != nil {
+		return errors.Wrap(err, "configure load balancer")
+	}
+	return bs.WaitForNode(*cc, n, roleChangeTimeout)
+}
+
+// removeEtcdMember removes the etcd member of the demoted node, in case kubeadm reset could not (intentionally non-fatal)
+func removeEtcdMember(cc config.ClusterConfig, n config.Node) {
+	cp := mustload.Running(cc.Name).CP
+	cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: cp.Runner, Socket: cc.KubernetesConfig.CRISocket})
+	if err != nil {
+		klog.Warningf("unable to get the runtime of the primary control-plane node: %v", err)
+		return
+	}
+	m, err := etcd.ReadMember(cp.Runner)
+	if err != nil {
+		klog.Warningf("unable to read the etcd member of the primary control-plane node: %v", err)
+		return
+	}
+	if err := etcd.RemoveMember(cp.Runner, cr, m, config.MachineName(cc, n)); err != nil {
+		klog.Warningf("unable to remove the etcd member of node %q: %v", n.Name, err)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestCanChangeRole(t *testing.T) {
+	cp := func(name string) config.Node { return config.Node{Name: name, ControlPlane: true, Worker: true} }
+	worker := func(name string) config.Node { return config.Node{Name: name, Worker: true} }
+
+	tests := []struct {
+		description string
+		nodes       []config.Node
+		node        int
+		promote     bool
+		wantErr     bool
+	}{
+		{"promote worker in HA cluster", []config.Node{cp(""), cp("m02"), worker("m03")}, 2, true, false},
+		{"promote worker in non-HA cluster", []config.Node{cp(""), worker("m02")}, 1, true, true},
+		{"promote control-plane node", []config.Node{cp(""), cp("m02"), worker("m03")}, 1, true, true},
+		{"demote control-plane node", []config.Node{cp(""), cp("m02"), cp("m03")}, 2, false, false},
+		{"demote primary control-plane node", []config.Node{cp(""), cp("m02"), cp("m03")}, 0, false, true},
+		{"demote worker", []config.Node{cp(""), cp("m02"), cp("m03"), worker("m04")}, 3, false, true},
+		{"demote one of 2 control-plane nodes", []config.Node{cp(""), cp("m02"), worker("m03")}, 1, false, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			cc := config.ClusterConfig{Name: "minikube", Nodes: tc.nodes}
+			check := canDemote
+			if tc.promote {
+				check = canPromote
+			}
+			err := check(cc, tc.nodes[tc.node])
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -105,6 +105,8 @@ func Start(starter Starter) (*kubeconfig.Settings, error) { // nolint:gocyclo
 		showNoK8sVersionInfo(cr)
 
 		configureMounts(&wg, *starter.Cfg)
+		wg.Add(1)
+		configureManagedMounts(&wg, *starter.Cfg, *starter.Node)
 		return nil, config.Write(viper.GetString(config.ProfileName), starter.Cfg)
 	}
 
@@ -142,6 +144,14 @@ func Start(starter Starter) (*kubeconfig.Settings, error) { // nolint:gocyclo
 		if err != nil {
 			return nil, err
 		}
+		// the nodes of a copied cluster are replaced by the nodes of its new machines
+		if starter.Cfg.CopiedFrom != "" {
+			if err := removeCopiedNodes(*starter.Cfg); err != nil {
+				klog.Warningf("unable to remove the nodes copied from profile %s: %v", starter.Cfg.CopiedFrom, err)
+			} else {
+				starter.Cfg.CopiedFrom = ""
+			}
+		}
 		// configure CoreDNS concurrently from primary control-plane node only and only on first node start
 		if !starter.PreExists {
 			wg.Add(1)
@@ -190,9 +200,22 @@ func Start(starter Starter) (*kubeconfig.Settings, error) { // nolint:gocyclo
 				return nil, errors.Wrap(err, "join node to cluster")
 			}
 		}
+
+		if starter.Node.ControlPlane {
+			if err := ConfigureLoadBalancer(*starter.Cfg); err != nil {
+				return nil, errors.Wrap(err, "configure load balancer")
+			}
+		}
 	}
 
 	go configureMounts(&wg, *starter.Cfg)
+	wg.Add(1)
+	go configureManagedMounts(&wg, *starter.Cfg, *starter.Node)
+
+	if config.IsPrimaryControlPlane(*starter.Cfg, *starter.Node) {
+		wg.Add(1)
+		go configurePorts(&wg, *starter.Cfg)
+	}
 
 	wg.Add(1)
 	go func() {
@@ -286,6 +309,11 @@ func startPrimaryControlPlane(starter Starter, cr cruntime.Manager) (*kubeconfig
 		}
 		// update cluster config
 		starter.Cfg.KubernetesConfig.APIServerHAVIP = n.ClientMax // last available ip from node's subnet, should've been reserved already
+
+		// kubeadm reaches the API server through the load balancer, which must be running before kubeadm init
+		if err := ConfigureLoadBalancer(*starter.Cfg); err != nil {
+			return nil, nil, errors.Wrap(err, "configure load balancer")
+		}
 	}
 
 	// must be written before bootstrap, otherwise health checks may flake due to stale IP
@@ -649,7 +677,7 @@ func setupKubeconfig(h host.Host, cc config.ClusterConfig, n config.Node, cluste
 		EmbedCerts:           cc.EmbedCerts,
 	}
 
-	kcs.SetPath(kubeconfig.PathFromEnv())
+	kcs.SetPath(config.KubeconfigPath(&cc))
 	return kcs
 }
 
@@ -89,6 +89,8 @@ var (
 	InternalAddonEnablePaused = Kind{ID: "MK_ADDON_ENABLE_PAUSED", ExitCode: ExProgramConflict}
 	// minikube could not disable an addon on a paused cluster
 	InternalAddonDisablePaused = Kind{ID: "MK_ADDON_DISABLE_PAUSED", ExitCode: ExProgramConflict}
+	// minikube could not list the images of an addon, e.g. its chart failed to render
+	InternalAddonImages = Kind{ID: "MK_ADDON_IMAGES", ExitCode: ExProgramError}
 
 	// minikube failed to update internal configuration, such as the cached images config map
 	InternalAddConfig = Kind{ID: "MK_ADD_CONFIG", ExitCode: ExProgramError}
@@ -276,6 +278,12 @@ var (
 	HostKubeconfigUpdate = Kind{ID: "HOST_KUBECONFIG_UPDATE", ExitCode: ExHostConfig}
 	// minikube failed to delete Kubernetes config from context for a given profile
 	HostKubeconfigDeleteCtx = Kind{ID: "HOST_KUBECONFIG_DELETE_CTX", ExitCode: ExHostConfig}
+	// minikube failed to export the kubeconfig of the cluster
+	HostKubeconfigExport = Kind{ID: "HOST_KUBECONFIG_EXPORT", ExitCode: ExHostConfig}
+	// minikube failed to sign, read or delete the client certificate of a user
+	HostUserCert = Kind{ID: "HOST_USER_CERT", ExitCode: ExHostConfig}
+	// minikube failed to create or delete the role bindings of a user
+	HostUserBind = Kind{ID: "HOST_USER_BIND", ExitCode: ExControlPlaneError}
 	// minikube failed to launch a kubectl proxy
 	HostKubectlProxy = Kind{ID: "HOST_KUBECTL_PROXY", ExitCode: ExHostError}
 	// minikube failed to write mount pid
@@ -333,12 +341,20 @@ var (
 
 	// minikube failed to load cached images
 	GuestCacheLoad = Kind{ID: "GUEST_CACHE_LOAD", ExitCode: ExGuestError}
+	// minikube failed to read the audit log of the apiserver
+	GuestAPIServerAuditLog = Kind{ID: "GUEST_APISERVER_AUDIT_LOG", ExitCode: ExGuestError}
 	// minikube failed to setup certificates
 	GuestCert = Kind{ID: "GUEST_CERT", ExitCode: ExGuestError}
+	// minikube failed to change the CNI of an existing cluster
+	GuestCNI = Kind{ID: "GUEST_CNI", ExitCode: ExGuestError}
 	// minikube failed to access the control plane
 	GuestCpConfig = Kind{ID: "GUEST_CP_CONFIG", ExitCode: ExGuestConfig}
 	// minikube failed to properly delete a resource, such as a profile
 	GuestDeletion = Kind{ID: "GUEST_DELETION", ExitCode: ExGuestError}
+	// minikube failed to save a snapshot of etcd
+	GuestEtcdSnapshot = Kind{ID: "GUEST_ETCD_SNAPSHOT", ExitCode: ExGuestError}
+	// minikube failed to restore a snapshot of etcd
+	GuestEtcdRestore = Kind{ID: "GUEST_ETCD_RESTORE", ExitCode: ExGuestError}
 	// minikube failed to list images on the machine
 	GuestImageList = Kind{ID: "GUEST_IMAGE_LIST", ExitCode: ExGuestError}
 	// minikube failed to pull or load an image
@@ -359,6 +375,8 @@ var (
 	GuestLoadHost = Kind{ID: "GUEST_LOAD_HOST", ExitCode: ExGuestError}
 	// minkube failed to create a mount
 	GuestMount = Kind{ID: "GUEST_MOUNT", ExitCode: ExGuestError}
+	// minikube failed to publish or unpublish a port of the node
+	GuestPortsPublish = Kind{ID: "GUEST_PORTS_PUBLISH", ExitCode: ExGuestError}
 	// mount on guest was unable to connect to host mount server
 	GuestMountCouldNotConnect = Kind{
 		ID:       "GUEST_MOUNT_COULD_NOT_CONNECT",
@@ -370,10 +388,18 @@ var (
 	}
 	// minkube failed to update a mount
 	GuestMountConflict = Kind{ID: "GUEST_MOUNT_CONFLICT", ExitCode: ExGuestConflict}
+	// minikube failed to connect the clusters
+	GuestNetworkConnect = Kind{ID: "GUEST_NETWORK_CONNECT", ExitCode: ExGuestError}
+	// minikube failed to disconnect the clusters
+	GuestNetworkDisconnect = Kind{ID: "GUEST_NETWORK_DISCONNECT", ExitCode: ExGuestError}
 	// minikube failed to add a node to the cluster
 	GuestNodeAdd = Kind{ID: "GUEST_NODE_ADD", ExitCode: ExGuestError}
 	// minikube failed to remove a node from the cluster
 	GuestNodeDelete = Kind{ID: "GUEST_NODE_DELETE", ExitCode: ExGuestError}
+	// minikube failed to demote a control-plane node to a worker node
+	GuestNodeDemote = Kind{ID: "GUEST_NODE_DEMOTE", ExitCode: ExGuestError}
+	// minikube failed to promote a worker node to a control-plane node
+	GuestNodePromote = Kind{ID: "GUEST_NODE_PROMOTE", ExitCode: ExGuestError}
 	// minikube failed to provision a node
 	GuestNodeProvision = Kind{ID: "GUEST_NODE_PROVISION", ExitCode: ExGuestError}
 	// minikube failed to retrieve information for a cluster node
@@ -384,6 +410,10 @@ var (
 	GuestPause = Kind{ID: "GUEST_PAUSE", ExitCode: ExGuestError}
 	// minikube failed to delete a machine profile directory
 	GuestProfileDeletion = Kind{ID: "GUEST_PROFILE_DELETION", ExitCode: ExGuestError}
+	// minikube failed to rename the profile
+	GuestProfileRename = Kind{ID: "GUEST_PROFILE_RENAME", ExitCode: ExGuestError}
+	// minikube failed to clone the profile
+	GuestProfileClone = Kind{ID: "GUEST_PROFILE_CLONE", ExitCode: ExGuestError}
 	// minikube failed while attempting to provision the guest
 	GuestProvision = Kind{ID: "GUEST_PROVISION", ExitCode: ExGuestError}
 	// docker container exited prematurely during provisioning
@@ -413,6 +443,8 @@ var (
 	IfMountPort = Kind{ID: "IF_MOUNT_PORT", ExitCode: ExLocalNetworkError}
 	// minikube failed to access an ssh client on the host machine
 	IfSSHClient = Kind{ID: "IF_SSH_CLIENT", ExitCode: ExLocalNetworkError}
+	// minikube failed to forward the sockets of the node to the host
+	IfSocketForward = Kind{ID: "IF_SOCKET_FORWARD", ExitCode: ExLocalNetworkError}
 	// minikube failed to create a dedicated network
 	IfDedicatedNetwork = Kind{ID: "IF_DEDICATED_NETWORK", ExitCode: ExLocalNetworkError}
 	// minikube failed to populate dchpd_leases file due to bootpd being blocked by firewall
@@ -460,6 +492,8 @@ var (
 	SvcTunnelStart = Kind{ID: "SVC_TUNNEL_START", ExitCode: ExSvcError}
 	// minikube could not stop an active tunnel
 	SvcTunnelStop = Kind{ID: "SVC_TUNNEL_STOP", ExitCode: ExSvcError}
+	// minikube was unable to get the status of the tunnel running in the background
+	SvcTunnelStatus = Kind{ID: "SVC_TUNNEL_STATUS", ExitCode: ExSvcError}
 	// another instance of tunnel already running
 	SvcTunnelAlreadyRunning = Kind{ID: "TUNNEL_ALREADY_RUNNING", ExitCode: ExSvcConflict, Style: style.Usage}
 	// minikube was unable to access the service url
@@ -473,6 +507,8 @@ var (
 	EnvMultiConflict = Kind{ID: "ENV_MULTINODE_CONFLICT", ExitCode: ExGuestConflict}
 	// the podman service was unavailable to the cluster
 	EnvPodmanUnavailable = Kind{ID: "ENV_PODMAN_UNAVAILABLE", ExitCode: ExRuntimeUnavailable}
+	// the containerd service was unavailable to the cluster
+	EnvNerdctlUnavailable = Kind{ID: "ENV_NERDCTL_UNAVAILABLE", ExitCode: ExRuntimeUnavailable}
 
 	// user attempted to use an addon that is not supported
 	AddonUnsupported = Kind{ID: "SVC_ADDON_UNSUPPORTED", ExitCode: ExSvcUnsupported}
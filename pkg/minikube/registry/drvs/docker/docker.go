@@ -90,6 +90,7 @@ func configure(cc config.ClusterConfig, n config.Node) (interface{}, error) {
 		Network:           cc.Network,
 		Subnet:            cc.Subnet,
 		StaticIP:          cc.StaticIP,
+		IPv6:              config.IPv6Enabled(cc.KubernetesConfig),
 		ListenAddress:     cc.ListenAddress,
 		GPUs:              cc.GPUs,
 	}), nil
@@ -29,6 +29,9 @@ import (
 	"k8s.io/minikube/pkg/util"
 )
 
+// IngressDNSDomain is the domain of the ingress hosts resolved by the ingress-dns addon
+const IngressDNSDomain = "test"
+
 type clusterInspector struct {
 	machineAPI   libmachine.API
 	configLoader config.Loader
@@ -86,7 +89,17 @@ func getRoute(hostInfo *host.Host, clusterConfig config.ClusterConfig) (*Route,
 		return nil, errors.Wrapf(err, "error getting host IP for %s", hostInfo.Name)
 	}
 
-	_, ipNet, err := net.ParseCIDR(clusterConfig.KubernetesConfig.ServiceCIDR)
+	// routes are only set up for the primary IP family, dual-stack services always have a ClusterIP of that family too
+	serviceCIDR := clusterConfig.KubernetesConfig.ServiceCIDR
+	if !config.IPv4Enabled(clusterConfig.KubernetesConfig) {
+		serviceCIDR = config.ServiceCIDRs(clusterConfig.KubernetesConfig)[0]
+		cp, err := config.ControlPlane(clusterConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting control-plane node")
+		}
+		hostDriverIP = cp.IPv6
+	}
+	_, ipNet, err := net.ParseCIDR(serviceCIDR)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing service CIDR: %s", err)
 	}
@@ -98,10 +111,14 @@ func getRoute(hostInfo *host.Host, clusterConfig config.ClusterConfig) (*Route,
 	if err != nil {
 		return nil, err
 	}
-	return &Route{
+	route := &Route{
 		Gateway:       ip,
 		DestCIDR:      ipNet,
 		ClusterDomain: clusterConfig.KubernetesConfig.DNSDomain,
 		ClusterDNSIP:  dnsIP,
-	}, nil
+	}
+	if clusterConfig.Addons["ingress-dns"] {
+		route.IngressDNSDomains = []string{IngressDNSDomain}
+	}
+	return route, nil
 }
@@ -152,3 +152,34 @@ func TestRouteIPDetection(t *testing.T) {
 	}
 
 }
+
+func TestRouteIngressDNSDomains(t *testing.T) {
+	cfg := config.ClusterConfig{
+		KubernetesConfig: config.KubernetesConfig{
+			ServiceCIDR: "10.96.0.0/12",
+			DNSDomain:   "cluster.local",
+		},
+	}
+	h := &host.Host{
+		Driver: &tests.MockDriver{
+			IP: "192.168.1.1",
+		},
+	}
+
+	route, err := getRoute(h, cfg)
+	if err != nil {
+		t.Fatalf("expected no errors but got: %s", err)
+	}
+	if len(route.IngressDNSDomains) != 0 {
+		t.Errorf("expected no ingress-dns domains without the addon, got %v", route.IngressDNSDomains)
+	}
+
+	cfg.Addons = map[string]bool{"ingress-dns": true}
+	route, err = getRoute(h, cfg)
+	if err != nil {
+		t.Fatalf("expected no errors but got: %s", err)
+	}
+	if !reflect.DeepEqual(route.IngressDNSDomains, []string{"test"}) {
+		t.Errorf("expected the ingress-dns domains to be [test], got %v", route.IngressDNSDomains)
+	}
+}
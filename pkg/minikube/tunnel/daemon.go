@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+const (
+	// RouteMode tunnels add a route to the service subnet of the cluster
+	RouteMode = "route"
+	// SSHMode tunnels forward the ports of the services over ssh, for the drivers that need port forwarding
+	SSHMode = "ssh"
+)
+
+// DaemonState is the state of a tunnel running in the background, as reported by 'minikube tunnel status'
+type DaemonState struct {
+	Profile                   string    `json:"profile"`
+	Pid                       int       `json:"pid"`
+	Running                   bool      `json:"running"`
+	Mode                      string    `json:"mode,omitempty"`
+	LogFile                   string    `json:"logFile"`
+	StartTime                 time.Time `json:"startTime"`
+	UpdateTime                time.Time `json:"updateTime"`
+	Route                     string    `json:"route,omitempty"`
+	MinikubeState             string    `json:"minikubeState,omitempty"`
+	PatchedServices           []string  `json:"patchedServices"`
+	MinikubeError             string    `json:"minikubeError,omitempty"`
+	RouteError                string    `json:"routeError,omitempty"`
+	LoadBalancerEmulatorError string    `json:"loadBalancerEmulatorError,omitempty"`
+}
+
+// NewDaemonState returns the initial state of the tunnel of profile running in this process
+func NewDaemonState(profile, mode string) *DaemonState {
+	return &DaemonState{
+		Profile:         profile,
+		Pid:             getPid(),
+		Running:         true,
+		Mode:            mode,
+		LogFile:         localpath.TunnelLog(profile),
+		StartTime:       time.Now(),
+		PatchedServices: []string{},
+	}
+}
+
+// update records the status of the tunnel managed by a Manager
+func (st *DaemonState) update(s *Status) {
+	if s.TunnelID.Route != nil {
+		st.Route = s.TunnelID.Route.String()
+	}
+	st.MinikubeState = s.MinikubeState.String()
+	st.PatchedServices = append([]string{}, s.PatchedServices...)
+	st.MinikubeError = errString(s.MinikubeError)
+	st.RouteError = errString(s.RouteError)
+	st.LoadBalancerEmulatorError = errString(s.LoadBalancerEmulatorError)
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// WriteDaemonState saves the state of the tunnel running in the background
+func WriteDaemonState(st *DaemonState) error {
+	st.UpdateTime = time.Now()
+	b, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal tunnel state")
+	}
+	// write then rename, so that 'minikube tunnel status' never reads a partial state
+	path := localpath.TunnelState(st.Profile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return errors.Wrapf(err, "write %s", tmp)
+	}
+	return os.Rename(tmp, path)
+}
+
+// ReadDaemonState returns the state of the tunnel started in the background for profile, or nil if there is none
+func ReadDaemonState(profile string) (*DaemonState, error) {
+	pid, err := readPID(profile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	st := &DaemonState{Profile: profile, LogFile: localpath.TunnelLog(profile), PatchedServices: []string{}}
+	b, err := os.ReadFile(localpath.TunnelState(profile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "read tunnel state")
+	}
+	if err == nil {
+		if err := json.Unmarshal(b, st); err != nil {
+			return nil, errors.Wrap(err, "unmarshal tunnel state")
+		}
+	}
+	// the pid file is the reference, the state might be left over by a previous tunnel
+	if st.Pid != pid {
+		st = &DaemonState{Profile: profile, LogFile: localpath.TunnelLog(profile), PatchedServices: []string{}}
+	}
+	st.Pid = pid
+	if st.Running, err = checkIfRunning(pid); err != nil {
+		return nil, errors.Wrapf(err, "check tunnel process %d", pid)
+	}
+	return st, nil
+}
+
+func readPID(profile string) (int, error) {
+	b, err := os.ReadFile(localpath.TunnelPID(profile))
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return 0, errors.Wrapf(err, "converting %s to int", b)
+	}
+	return pid, nil
+}
+
+// StopDaemon stops the tunnel running in the background for profile, and returns its last state.
+// The tunnel is given the timeout to clean up its routes and services before being killed.
+func StopDaemon(profile string, timeout time.Duration) (*DaemonState, error) {
+	st, err := ReadDaemonState(profile)
+	if err != nil || st == nil {
+		return st, err
+	}
+	if st.Running {
+		p, err := os.FindProcess(st.Pid)
+		if err != nil {
+			return st, errors.Wrapf(err, "finding tunnel process %d", st.Pid)
+		}
+		klog.Infof("terminating tunnel process %d of profile %s", st.Pid, profile)
+		if err := terminate(p); err != nil {
+			klog.Warningf("unable to terminate tunnel process %d: %v", st.Pid, err)
+		}
+		if !waitForExit(st.Pid, timeout) {
+			klog.Warningf("tunnel process %d did not exit after %s, killing it", st.Pid, timeout)
+			if err := p.Kill(); err != nil {
+				return st, errors.Wrapf(err, "killing %d", st.Pid)
+			}
+		}
+	}
+	RemoveDaemonFiles(profile)
+	return st, nil
+}
+
+func waitForExit(pid int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if running, err := checkIfRunning(pid); err == nil && !running {
+			return true
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return false
+}
+
+// RemoveDaemonFiles removes the pid and state files of the tunnel running in the background for profile
+func RemoveDaemonFiles(profile string) {
+	for _, path := range []string{localpath.TunnelPID(profile), localpath.TunnelState(profile)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			klog.Warningf("unable to remove %s: %v", path, err)
+		}
+	}
+}
+
+// RemoveOwnDaemonFiles removes the pid and state files of the profile only if they were written by the current process,
+// so that the tunnel running in the background cleans up after itself before exiting on an error
+func RemoveOwnDaemonFiles(profile string) {
+	if pid, err := readPID(profile); err == nil && pid == getPid() {
+		RemoveDaemonFiles(profile)
+	}
+}
+
+// daemonized sends the output of the daemon to the tunnel log and records its pid
+func daemonized(profile string) error {
+	f, err := os.OpenFile(localpath.TunnelLog(profile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "open tunnel log")
+	}
+	os.Stdout = f
+	os.Stderr = f
+	out.SetOutFile(f)
+	out.SetErrFile(f)
+	return os.WriteFile(localpath.TunnelPID(profile), []byte(fmt.Sprintf("%v", getPid())), 0600)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func setupDaemonTest(t *testing.T, running map[int]bool) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	if err := os.MkdirAll(localpath.Profile("p1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	origCheck, origPid := checkIfRunning, getPid
+	t.Cleanup(func() {
+		checkIfRunning, getPid = origCheck, origPid
+	})
+	checkIfRunning = func(pid int) (bool, error) {
+		return running[pid], nil
+	}
+	getPid = func() int {
+		return 1234
+	}
+}
+
+func TestStateReporter(t *testing.T) {
+	setupDaemonTest(t, map[int]bool{1234: true})
+	if err := os.WriteFile(localpath.TunnelPID("p1"), []byte("1234"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	next := &recordingReporter{}
+	r := &stateReporter{state: NewDaemonState("p1", RouteMode), next: next}
+	r.Report(&Status{
+		TunnelID: ID{
+			Route:       unsafeParseRoute("1.2.3.4", "10.96.0.0/12"),
+			MachineName: "p1",
+			Pid:         1234,
+		},
+		MinikubeState:             Running,
+		PatchedServices:           []string{"svc1", "svc2"},
+		LoadBalancerEmulatorError: errors.New("lb error"),
+	})
+	if len(next.statesRecorded) != 1 {
+		t.Errorf("expected the report to be passed on, got %d reports", len(next.statesRecorded))
+	}
+
+	st, err := ReadDaemonState("p1")
+	if err != nil {
+		t.Fatalf("ReadDaemonState: %v", err)
+	}
+	if !st.Running || st.Pid != 1234 || st.Mode != RouteMode {
+		t.Errorf("unexpected state: %+v", st)
+	}
+	if st.Route != "10.96.0.0/12 -> 1.2.3.4" || st.MinikubeState != "Running" || st.LoadBalancerEmulatorError != "lb error" {
+		t.Errorf("unexpected state: %+v", st)
+	}
+	if !reflect.DeepEqual(st.PatchedServices, []string{"svc1", "svc2"}) {
+		t.Errorf("unexpected services: %v", st.PatchedServices)
+	}
+}
+
+func TestReadDaemonState(t *testing.T) {
+	setupDaemonTest(t, map[int]bool{1234: true})
+
+	st, err := ReadDaemonState("p1")
+	if err != nil || st != nil {
+		t.Fatalf("expected no state without a pid file, got %+v, %v", st, err)
+	}
+
+	// a state left over by a previous tunnel is ignored
+	if err := WriteDaemonState(NewDaemonState("p1", SSHMode)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(localpath.TunnelPID("p1"), []byte("5678\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	st, err = ReadDaemonState("p1")
+	if err != nil {
+		t.Fatalf("ReadDaemonState: %v", err)
+	}
+	if st.Pid != 5678 || st.Running || st.Mode != "" {
+		t.Errorf("unexpected state: %+v", st)
+	}
+
+	// stopping a tunnel that is not running anymore removes its files
+	if _, err := StopDaemon("p1", 0); err != nil {
+		t.Fatalf("StopDaemon: %v", err)
+	}
+	for _, path := range []string{localpath.TunnelPID("p1"), localpath.TunnelState("p1")} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed: %v", path, err)
+		}
+	}
+}
+
+func TestRemoveOwnDaemonFiles(t *testing.T) {
+	setupDaemonTest(t, map[int]bool{1234: true, 5678: true})
+
+	// the files of another tunnel are left alone
+	if err := os.WriteFile(localpath.TunnelPID("p1"), []byte("5678\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	RemoveOwnDaemonFiles("p1")
+	if _, err := os.Stat(localpath.TunnelPID("p1")); err != nil {
+		t.Errorf("expected the pid file of another tunnel to be kept: %v", err)
+	}
+
+	if err := os.WriteFile(localpath.TunnelPID("p1"), []byte("1234\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	RemoveOwnDaemonFiles("p1")
+	if _, err := os.Stat(localpath.TunnelPID("p1")); !os.IsNotExist(err) {
+		t.Errorf("expected the pid file of the tunnel to be removed: %v", err)
+	}
+}
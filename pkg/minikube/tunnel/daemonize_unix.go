@@ -0,0 +1,41 @@
+//go:build !windows
+
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/VividCortex/godaemon"
+)
+
+// Daemonize runs the tunnel of profile in the background: the process is started again as a daemon,
+// with its output in the tunnel log, and only the daemon returns from this function
+func Daemonize(profile string) error {
+	if _, _, err := godaemon.MakeDaemon(&godaemon.DaemonAttr{}); err != nil {
+		return err
+	}
+	// now that this process has daemonized, it has a new PID
+	return daemonized(profile)
+}
+
+// terminate asks the tunnel process to exit, so that it cleans up its routes and services
+func terminate(p *os.Process) error {
+	return p.Signal(syscall.SIGTERM)
+}
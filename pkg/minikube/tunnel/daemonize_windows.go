@@ -0,0 +1,71 @@
+//go:build windows
+
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+// daemonEnv is set in the environment of the background tunnel process
+const daemonEnv = "MINIKUBE_TUNNEL_DAEMON"
+
+// detachedProcess is the DETACHED_PROCESS creation flag, the process has no console
+const detachedProcess = 0x00000008
+
+// Daemonize runs the tunnel of profile in the background: there is no fork on windows,
+// so minikube starts itself again as a detached process and only that process returns from this function
+func Daemonize(profile string) error {
+	if os.Getenv(daemonEnv) != "" {
+		return daemonized(profile)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "get minikube executable")
+	}
+	f, err := os.OpenFile(localpath.TunnelLog(profile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "open tunnel log")
+	}
+	defer f.Close()
+
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Env = append(os.Environ(), daemonEnv+"=1")
+	cmd.Stdout = f
+	cmd.Stderr = f
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: detachedProcess | syscall.CREATE_NEW_PROCESS_GROUP}
+	if err := cmd.Start(); err != nil {
+		return errors.Wrap(err, "start tunnel process")
+	}
+	if err := cmd.Process.Release(); err != nil {
+		return errors.Wrap(err, "release tunnel process")
+	}
+	os.Exit(0)
+	return nil
+}
+
+// terminate stops the tunnel process, windows processes cannot be signaled so the routes and services
+// are cleaned up by 'minikube tunnel stop'
+func terminate(p *os.Process) error {
+	return p.Kill()
+}
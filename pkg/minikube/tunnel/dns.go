@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"k8s.io/klog/v2"
+)
+
+// dnsDomain is a DNS domain resolved by a DNS server of the cluster
+type dnsDomain struct {
+	name   string
+	server net.IP
+}
+
+// hostResolver registers DNS domains with the resolver of the host, implementations are OS specific
+type hostResolver interface {
+	// Register makes the host resolve the names of the domains with their servers, reachable through the gateway
+	Register(gateway net.IP, domains []dnsDomain) error
+
+	// Unregister is an idempotent way to remove the domains registered for the gateway
+	Unregister(gateway net.IP, domains []dnsDomain) error
+}
+
+// routeDomains returns the DNS domains resolved through a route: the cluster domain, by the cluster DNS,
+// and the ingress-dns domains, by the ingress-dns addon listening on the gateway
+func routeDomains(route *Route) []dnsDomain {
+	domains := []dnsDomain{}
+	if route.ClusterDomain != "" && route.ClusterDNSIP != nil {
+		domains = append(domains, dnsDomain{name: route.ClusterDomain, server: route.ClusterDNSIP})
+	}
+	for _, d := range route.IngressDNSDomains {
+		domains = append(domains, dnsDomain{name: d, server: route.Gateway})
+	}
+	return domains
+}
+
+// domainsByServer groups the domains by server, in the order of their first domain
+func domainsByServer(domains []dnsDomain) [][]dnsDomain {
+	groups := [][]dnsDomain{}
+	for _, d := range domains {
+		found := false
+		for i := range groups {
+			if groups[i][0].server.Equal(d.server) {
+				groups[i] = append(groups[i], d)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, []dnsDomain{d})
+		}
+	}
+	return groups
+}
+
+// registerDNS registers the domains of the route with the host resolver
+func registerDNS(r hostResolver, route *Route) error {
+	domains := routeDomains(route)
+	if len(domains) == 0 {
+		return nil
+	}
+	if r == nil {
+		return errors.New("no supported resolver found on the host")
+	}
+	if err := r.Register(route.Gateway, domains); err != nil {
+		return err
+	}
+	klog.Infof("DNS forwarding now configured for %v", domains)
+	return nil
+}
+
+// ensureDNS registers the domains of the route unless the router already registered them,
+// so that they are registered on every ensure when they change, not only when the route is added.
+// DNS forwarding is optional so the route is kept if it fails, and the registration is retried on the next ensure.
+func (router *osRouter) ensureDNS(newResolver func() hostResolver, route *Route) {
+	domains := fmt.Sprint(route.Gateway, routeDomains(route))
+	if domains == router.dnsDomains {
+		return
+	}
+	if err := registerDNS(newResolver(), route); err != nil {
+		klog.Errorf("DNS forwarding unavailable: %v", err)
+		return
+	}
+	router.dnsDomains = domains
+}
+
+// unregisterDNS removes the domains of the route from the host resolver
+func unregisterDNS(r hostResolver, route *Route) {
+	domains := routeDomains(route)
+	if r == nil || len(domains) == 0 {
+		return
+	}
+	if err := r.Unregister(route.Gateway, domains); err != nil {
+		klog.Errorf("error removing DNS forwarding for %v: %v", domains, err)
+	}
+}
+
+func (d dnsDomain) String() string {
+	return d.name + " -> " + d.server.String()
+}
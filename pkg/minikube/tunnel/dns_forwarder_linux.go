@@ -0,0 +1,242 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// dnsForwarderTimeout is how long the forwarder waits for the server of a domain to answer
+const dnsForwarderTimeout = 5 * time.Second
+
+// dnsForwarder forwards the DNS queries it receives to the server of the domain of their question,
+// for the host resolvers which send all the domains of a link to a single server
+type dnsForwarder struct {
+	domains []dnsDomain
+	udp     net.PacketConn
+	tcp     net.Listener
+}
+
+// startDNSForwarder listens for DNS queries over UDP and TCP on the same free port of ip
+func startDNSForwarder(ip net.IP, domains []dnsDomain) (*dnsForwarder, error) {
+	var err error
+	// the UDP port picked by the kernel might be taken for TCP, so try a few
+	for i := 0; i < 5; i++ {
+		f := &dnsForwarder{domains: domains}
+		if f.udp, err = net.ListenPacket("udp", net.JoinHostPort(ip.String(), "0")); err != nil {
+			return nil, err
+		}
+		port := f.udp.LocalAddr().(*net.UDPAddr).Port
+		if f.tcp, err = net.Listen("tcp", net.JoinHostPort(ip.String(), strconv.Itoa(port))); err != nil {
+			f.udp.Close()
+			continue
+		}
+		go f.serveUDP()
+		go f.serveTCP()
+		klog.Infof("forwarding DNS queries received on %s to %v", f.udp.LocalAddr(), domains)
+		return f, nil
+	}
+	return nil, err
+}
+
+// port returns the port the forwarder listens on
+func (f *dnsForwarder) port() int {
+	return f.udp.LocalAddr().(*net.UDPAddr).Port
+}
+
+// Close stops the forwarder
+func (f *dnsForwarder) Close() {
+	f.udp.Close()
+	f.tcp.Close()
+}
+
+func (f *dnsForwarder) serveUDP() {
+	for {
+		buf := make([]byte, 65535)
+		n, client, err := f.udp.ReadFrom(buf)
+		if err != nil {
+			klog.Infof("DNS forwarder stopped: %v", err)
+			return
+		}
+		go func() {
+			resp, err := f.exchangeUDP(buf[:n])
+			if err != nil {
+				klog.Warningf("unable to forward DNS query: %v", err)
+				return
+			}
+			if _, err := f.udp.WriteTo(resp, client); err != nil {
+				klog.Warningf("unable to answer DNS query: %v", err)
+			}
+		}()
+	}
+}
+
+func (f *dnsForwarder) exchangeUDP(query []byte) ([]byte, error) {
+	server, err := f.serverFor(query)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.DialTimeout("udp", net.JoinHostPort(server.String(), "53"), dnsForwarderTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(dnsForwarderTimeout)); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(query); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 65535)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+func (f *dnsForwarder) serveTCP() {
+	for {
+		client, err := f.tcp.Accept()
+		if err != nil {
+			klog.Infof("DNS forwarder stopped: %v", err)
+			return
+		}
+		go func() {
+			defer client.Close()
+			if err := f.forwardTCP(client); err != nil && !errors.Is(err, io.EOF) {
+				klog.Warningf("unable to forward DNS query: %v", err)
+			}
+		}()
+	}
+}
+
+// forwardTCP forwards the queries of a TCP connection, each prefixed with its length, until the client closes it
+func (f *dnsForwarder) forwardTCP(client net.Conn) error {
+	for {
+		if err := client.SetDeadline(time.Now().Add(dnsForwarderTimeout)); err != nil {
+			return err
+		}
+		query, err := readTCPMessage(client)
+		if err != nil {
+			return err
+		}
+		server, err := f.serverFor(query)
+		if err != nil {
+			return err
+		}
+		resp, err := exchangeTCP(server, query)
+		if err != nil {
+			return err
+		}
+		if err := writeTCPMessage(client, resp); err != nil {
+			return err
+		}
+	}
+}
+
+func exchangeTCP(server net.IP, query []byte) ([]byte, error) {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(server.String(), "53"), dnsForwarderTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(dnsForwarderTimeout)); err != nil {
+		return nil, err
+	}
+	if err := writeTCPMessage(conn, query); err != nil {
+		return nil, err
+	}
+	return readTCPMessage(conn)
+}
+
+func readTCPMessage(r io.Reader) ([]byte, error) {
+	var length uint16
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	msg := make([]byte, length)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func writeTCPMessage(w io.Writer, msg []byte) error {
+	b := make([]byte, 2, 2+len(msg))
+	binary.BigEndian.PutUint16(b, uint16(len(msg)))
+	_, err := w.Write(append(b, msg...))
+	return err
+}
+
+// serverFor returns the server of the most specific domain of the question of the query
+func (f *dnsForwarder) serverFor(query []byte) (net.IP, error) {
+	name, err := questionName(query)
+	if err != nil {
+		return nil, err
+	}
+	var server net.IP
+	matched := -1
+	for _, d := range f.domains {
+		if (name == d.name || strings.HasSuffix(name, "."+d.name)) && len(d.name) > matched {
+			server, matched = d.server, len(d.name)
+		}
+	}
+	if server == nil {
+		return nil, errors.New("no server for " + name)
+	}
+	return server, nil
+}
+
+// questionName returns the name of the first question of a DNS message, in lower case and without the trailing dot
+func questionName(msg []byte) (string, error) {
+	if len(msg) < 12 {
+		return "", errors.New("short DNS message")
+	}
+	if binary.BigEndian.Uint16(msg[4:6]) == 0 {
+		return "", errors.New("DNS message without a question")
+	}
+	var labels []string
+	for i := 12; ; {
+		if i >= len(msg) {
+			return "", errors.New("truncated DNS question")
+		}
+		n := int(msg[i])
+		if n == 0 {
+			break
+		}
+		// the name of the first question cannot point to a previous name
+		if n&0xc0 != 0 {
+			return "", errors.New("invalid DNS question name")
+		}
+		i++
+		if i+n > len(msg) {
+			return "", errors.New("truncated DNS question")
+		}
+		labels = append(labels, strings.ToLower(string(msg[i:i+n])))
+		i += n
+	}
+	return strings.Join(labels, "."), nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+)
+
+// dnsQuery returns a DNS query with a single question for name
+func dnsQuery(name string) []byte {
+	msg := []byte{0x12, 0x34, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	for _, label := range strings.Split(name, ".") {
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	// root label, type A, class IN
+	return append(msg, 0x00, 0x00, 0x01, 0x00, 0x01)
+}
+
+func TestDNSForwarderServerFor(t *testing.T) {
+	f := &dnsForwarder{domains: []dnsDomain{
+		{name: "cluster.local", server: net.ParseIP("10.96.0.10")},
+		{name: "local", server: net.ParseIP("192.168.49.2")},
+		{name: "test", server: net.ParseIP("192.168.49.2")},
+	}}
+	tests := []struct {
+		query   []byte
+		server  string
+		wantErr bool
+	}{
+		{query: dnsQuery("kubernetes.default.svc.cluster.local"), server: "10.96.0.10"},
+		{query: dnsQuery("Hello-John.TEST"), server: "192.168.49.2"},
+		{query: dnsQuery("cluster.local"), server: "10.96.0.10"},
+		{query: dnsQuery("other.local"), server: "192.168.49.2"},
+		{query: dnsQuery("example.com"), wantErr: true},
+		{query: dnsQuery("contest"), wantErr: true},
+		{query: []byte{0x12, 0x34}, wantErr: true},
+		{query: dnsQuery("hello.test")[:16], wantErr: true},
+	}
+	for _, tc := range tests {
+		server, err := f.serverFor(tc.query)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("serverFor(%q) = %s, want an error", tc.query, server)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("serverFor(%q): %v", tc.query, err)
+			continue
+		}
+		if server.String() != tc.server {
+			t.Errorf("serverFor(%q) = %s, want %s", tc.query, server, tc.server)
+		}
+	}
+}
+
+func TestDNSTCPMessage(t *testing.T) {
+	var b bytes.Buffer
+	query := dnsQuery("hello.test")
+	if err := writeTCPMessage(&b, query); err != nil {
+		t.Fatalf("writeTCPMessage: %v", err)
+	}
+	if got := binary.BigEndian.Uint16(b.Bytes()[:2]); int(got) != len(query) {
+		t.Errorf("length prefix = %d, want %d", got, len(query))
+	}
+	got, err := readTCPMessage(&b)
+	if err != nil {
+		t.Fatalf("readTCPMessage: %v", err)
+	}
+	if !bytes.Equal(got, query) {
+		t.Errorf("readTCPMessage() = %v, want %v", got, query)
+	}
+}
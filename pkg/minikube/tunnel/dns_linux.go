@@ -0,0 +1,301 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	resolvedBusName    = "org.freedesktop.resolve1"
+	resolvedObjectPath = "/org/freedesktop/resolve1"
+	resolvedManager    = "org.freedesktop.resolve1.Manager"
+
+	// nmDnsmasqDir is read by the dnsmasq instance of NetworkManager, when configured with dns=dnsmasq
+	nmDnsmasqDir = "/etc/NetworkManager/dnsmasq.d"
+)
+
+var (
+	forwardersMu sync.Mutex
+	// forwarders are the DNS forwarders started by this process, by gateway
+	forwarders = map[string]*dnsForwarder{}
+)
+
+// newHostResolver returns the resolver backend matching the configuration of the host, or nil if none is supported
+func newHostResolver() hostResolver {
+	resolvConf, err := os.ReadFile("/etc/resolv.conf")
+	if err != nil {
+		klog.Warningf("unable to read /etc/resolv.conf: %v", err)
+		return nil
+	}
+	switch {
+	case isResolvedStub(string(resolvConf)):
+		conn, err := dbus.SystemBus()
+		if err != nil {
+			klog.Warningf("unable to connect to the system bus: %v", err)
+			return nil
+		}
+		return &resolvedResolver{
+			manager:     conn.Object(resolvedBusName, resolvedObjectPath),
+			linkAddress: linkAddress,
+			forward:     startDNSForwarder,
+			resolvectl:  sudoResolvectl,
+		}
+	case isNetworkManagerDnsmasq(string(resolvConf)):
+		return &dnsmasqResolver{dir: nmDnsmasqDir}
+	}
+	klog.Infof("unsupported resolver configuration in /etc/resolv.conf")
+	return nil
+}
+
+// isResolvedStub returns whether resolv.conf points to the stub resolver of systemd-resolved
+func isResolvedStub(resolvConf string) bool {
+	return strings.Contains(resolvConf, "systemd-resolved") || strings.Contains(resolvConf, "nameserver 127.0.0.53")
+}
+
+// isNetworkManagerDnsmasq returns whether resolv.conf points to the dnsmasq instance of NetworkManager
+func isNetworkManagerDnsmasq(resolvConf string) bool {
+	return strings.Contains(resolvConf, "Generated by NetworkManager") && strings.Contains(resolvConf, "nameserver 127.0.")
+}
+
+// linkAddress returns the index of the network interface the gateway is directly reachable on, and the address of the host on it
+func linkAddress(gateway net.IP) (int, net.IP, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "list network interfaces")
+	}
+	for _, iface := range ifaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.Contains(gateway) && !ipNet.IP.Equal(gateway) {
+				return iface.Index, ipNet.IP, nil
+			}
+		}
+	}
+	return 0, nil, fmt.Errorf("no network interface found for %s", gateway)
+}
+
+// busObject is the part of dbus.BusObject used to call systemd-resolved
+type busObject interface {
+	Call(method string, flags dbus.Flags, args ...interface{}) *dbus.Call
+}
+
+// resolvedLinkAddress is a DNS server of a link, the (iay) D-Bus structure of systemd-resolved
+type resolvedLinkAddress struct {
+	Family  int32
+	Address []byte
+}
+
+// resolvedLinkAddressEx is a DNS server of a link with its port and name, the (iayqs) D-Bus structure of systemd-resolved
+type resolvedLinkAddressEx struct {
+	Family  int32
+	Address []byte
+	Port    uint16
+	Name    string
+}
+
+// resolvedLinkDomain is a search or routing-only domain of a link, the (sb) D-Bus structure of systemd-resolved
+type resolvedLinkDomain struct {
+	Domain      string
+	RoutingOnly bool
+}
+
+// resolvedResolver registers the domains as routing-only domains of the link of the gateway in systemd-resolved
+type resolvedResolver struct {
+	manager     busObject
+	linkAddress func(gateway net.IP) (int, net.IP, error)
+	forward     func(ip net.IP, domains []dnsDomain) (*dnsForwarder, error)
+	resolvectl  func(args ...string) error
+}
+
+// Register sets the DNS server and domains of the link over D-Bus. systemd-resolved uses a single server
+// for all the domains of a link, so the domains of several servers go through a forwarder listening on the link.
+func (r *resolvedResolver) Register(gateway net.IP, domains []dnsDomain) error {
+	link, local, err := r.linkAddress(gateway)
+	if err != nil {
+		return err
+	}
+	linkDomains := []resolvedLinkDomain{}
+	for _, d := range domains {
+		linkDomains = append(linkDomains, resolvedLinkDomain{Domain: d.name, RoutingOnly: true})
+	}
+	stopForwarder(gateway)
+
+	var server string
+	if servers := domainsByServer(domains); len(servers) == 1 {
+		server = servers[0][0].server.String()
+		err = r.call("SetLinkDNS", int32(link), []resolvedLinkAddress{resolvedAddress(servers[0][0].server)})
+	} else {
+		f, ferr := r.forward(local, domains)
+		if ferr != nil {
+			return errors.Wrap(ferr, "start DNS forwarder")
+		}
+		setForwarder(gateway, f)
+		server = net.JoinHostPort(local.String(), strconv.Itoa(f.port()))
+		addr := resolvedAddress(local)
+		err = r.call("SetLinkDNSEx", int32(link), []resolvedLinkAddressEx{{Family: addr.Family, Address: addr.Address, Port: uint16(f.port())}})
+	}
+	if err == nil {
+		err = r.call("SetLinkDomains", int32(link), linkDomains)
+	}
+	if !isAccessDenied(err) {
+		return err
+	}
+	// without a polkit agent to authorize the call, fall back to resolvectl with sudo like the routes
+	klog.Infof("systemd-resolved denied access over D-Bus, using resolvectl: %v", err)
+	if err := r.resolvectl("dns", strconv.Itoa(link), server); err != nil {
+		return err
+	}
+	args := []string{"domain", strconv.Itoa(link)}
+	for _, d := range linkDomains {
+		args = append(args, "~"+d.Domain)
+	}
+	return r.resolvectl(args...)
+}
+
+// Unregister reverts the DNS settings of the link of the gateway
+func (r *resolvedResolver) Unregister(gateway net.IP, _ []dnsDomain) error {
+	stopForwarder(gateway)
+	link, _, err := r.linkAddress(gateway)
+	if err != nil {
+		// the link is gone, and its settings with it
+		klog.Infof("not reverting DNS settings: %v", err)
+		return nil
+	}
+	err = r.call("RevertLink", int32(link))
+	if !isAccessDenied(err) {
+		return err
+	}
+	klog.Infof("systemd-resolved denied access over D-Bus, using resolvectl: %v", err)
+	return r.resolvectl("revert", strconv.Itoa(link))
+}
+
+func (r *resolvedResolver) call(method string, args ...interface{}) error {
+	klog.Infof("calling %s.%s %v", resolvedManager, method, args)
+	return r.manager.Call(resolvedManager+"."+method, dbus.FlagAllowInteractiveAuthorization, args...).Err
+}
+
+// setForwarder records the DNS forwarder of the gateway, to stop it when the domains are registered again or unregistered
+func setForwarder(gateway net.IP, f *dnsForwarder) {
+	forwardersMu.Lock()
+	defer forwardersMu.Unlock()
+	forwarders[gateway.String()] = f
+}
+
+// stopForwarder stops the DNS forwarder of the gateway started by this process, if any
+func stopForwarder(gateway net.IP) {
+	forwardersMu.Lock()
+	defer forwardersMu.Unlock()
+	if f, ok := forwarders[gateway.String()]; ok {
+		f.Close()
+		delete(forwarders, gateway.String())
+	}
+}
+
+func resolvedAddress(ip net.IP) resolvedLinkAddress {
+	if ip4 := ip.To4(); ip4 != nil {
+		return resolvedLinkAddress{Family: syscall.AF_INET, Address: ip4}
+	}
+	return resolvedLinkAddress{Family: syscall.AF_INET6, Address: ip.To16()}
+}
+
+func isAccessDenied(err error) bool {
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) {
+		return false
+	}
+	return dbusErr.Name == "org.freedesktop.DBus.Error.AccessDenied" || dbusErr.Name == "org.freedesktop.DBus.Error.InteractiveAuthorizationRequired"
+}
+
+func sudoResolvectl(args ...string) error {
+	return sudo(append([]string{"resolvectl"}, args...)...)
+}
+
+// dnsmasqResolver registers the domains as servers of the dnsmasq instance of NetworkManager
+type dnsmasqResolver struct {
+	dir string
+}
+
+// Register writes a dnsmasq configuration file for the gateway and reloads the DNS configuration of NetworkManager
+func (r *dnsmasqResolver) Register(gateway net.IP, domains []dnsDomain) error {
+	tf, err := os.CreateTemp("", "minikube-tunnel-dnsmasq-")
+	if err != nil {
+		return errors.Wrap(err, "tempfile")
+	}
+	defer os.Remove(tf.Name())
+	if _, err := tf.WriteString(dnsmasqConfig(domains)); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	if err := tf.Close(); err != nil {
+		return errors.Wrap(err, "close")
+	}
+	if err := os.Chmod(tf.Name(), 0644); err != nil {
+		return errors.Wrap(err, "chmod")
+	}
+	if err := sudo("mkdir", "-p", r.dir); err != nil {
+		return err
+	}
+	if err := sudo("cp", "-fp", tf.Name(), r.configPath(gateway)); err != nil {
+		return err
+	}
+	return sudo("nmcli", "general", "reload", "dns-full")
+}
+
+// Unregister removes the dnsmasq configuration file of the gateway
+func (r *dnsmasqResolver) Unregister(gateway net.IP, _ []dnsDomain) error {
+	if err := sudo("rm", "-f", r.configPath(gateway)); err != nil {
+		return err
+	}
+	return sudo("nmcli", "general", "reload", "dns-full")
+}
+
+func (r *dnsmasqResolver) configPath(gateway net.IP) string {
+	return filepath.Join(r.dir, fmt.Sprintf("minikube-%s.conf", strings.ReplaceAll(gateway.String(), ":", "-")))
+}
+
+// dnsmasqConfig returns the dnsmasq configuration forwarding each domain to its server
+func dnsmasqConfig(domains []dnsDomain) string {
+	var b strings.Builder
+	for _, d := range domains {
+		fmt.Fprintf(&b, "server=/%s/%s\n", d.name, d.server)
+	}
+	return b.String()
+}
+
+func sudo(args ...string) error {
+	cmd := exec.Command("sudo", args...)
+	klog.Infof("About to run command: %s", cmd.Args)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%q failed: %v: %s", strings.Join(cmd.Args, " "), err, out)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// fakeResolvedManager records the calls to the D-Bus API of systemd-resolved
+type fakeResolvedManager struct {
+	calls []string
+	args  [][]interface{}
+	err   error
+}
+
+func (m *fakeResolvedManager) Call(method string, _ dbus.Flags, args ...interface{}) *dbus.Call {
+	m.calls = append(m.calls, method)
+	m.args = append(m.args, args)
+	return &dbus.Call{Err: m.err}
+}
+
+func newFakeResolvedResolver(m *fakeResolvedManager, resolvectl *[]string) *resolvedResolver {
+	return &resolvedResolver{
+		manager: m,
+		linkAddress: func(_ net.IP) (int, net.IP, error) {
+			return 7, net.ParseIP("127.0.0.1"), nil
+		},
+		forward: startDNSForwarder,
+		resolvectl: func(args ...string) error {
+			*resolvectl = append(*resolvectl, strings.Join(args, " "))
+			return nil
+		},
+	}
+}
+
+var testDomains = []dnsDomain{
+	{name: "cluster.local", server: net.ParseIP("10.96.0.10")},
+	{name: "test", server: net.ParseIP("192.168.49.2")},
+}
+
+func TestResolvedRegister(t *testing.T) {
+	m := &fakeResolvedManager{}
+	var resolvectl []string
+	r := newFakeResolvedResolver(m, &resolvectl)
+	gateway := net.ParseIP("192.168.49.2")
+
+	if err := r.Register(gateway, testDomains[:1]); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	wantCalls := []string{resolvedManager + ".SetLinkDNS", resolvedManager + ".SetLinkDomains"}
+	if !reflect.DeepEqual(m.calls, wantCalls) {
+		t.Fatalf("calls = %v, want %v", m.calls, wantCalls)
+	}
+	wantDNS := []interface{}{int32(7), []resolvedLinkAddress{{Family: syscall.AF_INET, Address: net.ParseIP("10.96.0.10").To4()}}}
+	if !reflect.DeepEqual(m.args[0], wantDNS) {
+		t.Errorf("SetLinkDNS args = %v, want %v", m.args[0], wantDNS)
+	}
+
+	// the domains of several servers go through a forwarder listening on the link
+	m.calls, m.args = nil, nil
+	if err := r.Register(gateway, testDomains); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	f, ok := forwarders[gateway.String()]
+	if !ok {
+		t.Fatalf("expected a DNS forwarder for %s", gateway)
+	}
+	wantCalls = []string{resolvedManager + ".SetLinkDNSEx", resolvedManager + ".SetLinkDomains"}
+	if !reflect.DeepEqual(m.calls, wantCalls) {
+		t.Fatalf("calls = %v, want %v", m.calls, wantCalls)
+	}
+	wantDNSEx := []interface{}{int32(7), []resolvedLinkAddressEx{{Family: syscall.AF_INET, Address: net.ParseIP("127.0.0.1").To4(), Port: uint16(f.port())}}}
+	if !reflect.DeepEqual(m.args[0], wantDNSEx) {
+		t.Errorf("SetLinkDNSEx args = %v, want %v", m.args[0], wantDNSEx)
+	}
+	wantDomains := []interface{}{int32(7), []resolvedLinkDomain{{Domain: "cluster.local", RoutingOnly: true}, {Domain: "test", RoutingOnly: true}}}
+	if !reflect.DeepEqual(m.args[1], wantDomains) {
+		t.Errorf("SetLinkDomains args = %v, want %v", m.args[1], wantDomains)
+	}
+	if len(resolvectl) != 0 {
+		t.Errorf("expected no resolvectl fallback, got %v", resolvectl)
+	}
+
+	if err := r.Unregister(gateway, testDomains); err != nil {
+		t.Fatalf("Unregister: %v", err)
+	}
+	if got := m.calls[len(m.calls)-1]; got != resolvedManager+".RevertLink" {
+		t.Errorf("expected RevertLink, got %s", got)
+	}
+	if _, ok := forwarders[gateway.String()]; ok {
+		t.Errorf("expected the DNS forwarder to be stopped")
+	}
+}
+
+func TestResolvedAccessDeniedFallback(t *testing.T) {
+	m := &fakeResolvedManager{err: dbus.Error{Name: "org.freedesktop.DBus.Error.InteractiveAuthorizationRequired"}}
+	var resolvectl []string
+	r := newFakeResolvedResolver(m, &resolvectl)
+
+	if err := r.Register(net.ParseIP("192.168.49.2"), testDomains[1:]); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := r.Unregister(net.ParseIP("192.168.49.2"), testDomains[1:]); err != nil {
+		t.Fatalf("Unregister: %v", err)
+	}
+	want := []string{"dns 7 192.168.49.2", "domain 7 ~test", "revert 7"}
+	if !reflect.DeepEqual(resolvectl, want) {
+		t.Errorf("resolvectl calls = %v, want %v", resolvectl, want)
+	}
+
+	m.err = dbus.Error{Name: "org.freedesktop.resolve1.NoSuchLink"}
+	if err := r.Register(net.ParseIP("192.168.49.2"), testDomains); err == nil {
+		t.Errorf("expected other D-Bus errors to be returned")
+	}
+}
+
+func TestDnsmasqConfig(t *testing.T) {
+	want := "server=/cluster.local/10.96.0.10\nserver=/test/192.168.49.2\n"
+	if got := dnsmasqConfig(testDomains); got != want {
+		t.Errorf("dnsmasqConfig() = %q, want %q", got, want)
+	}
+	r := &dnsmasqResolver{dir: nmDnsmasqDir}
+	if got := r.configPath(net.ParseIP("fd00::2")); got != "/etc/NetworkManager/dnsmasq.d/minikube-fd00--2.conf" {
+		t.Errorf("unexpected config path %s", got)
+	}
+}
+
+func TestResolverDetection(t *testing.T) {
+	tests := []struct {
+		name       string
+		resolvConf string
+		resolved   bool
+		dnsmasq    bool
+	}{
+		{
+			name:       "systemd-resolved",
+			resolvConf: "# This is /run/systemd/resolve/stub-resolv.conf managed by man:systemd-resolved(8).\nnameserver 127.0.0.53\n",
+			resolved:   true,
+		},
+		{
+			name:       "NetworkManager dnsmasq",
+			resolvConf: "# Generated by NetworkManager\nnameserver 127.0.1.1\n",
+			dnsmasq:    true,
+		},
+		{
+			name:       "NetworkManager without dnsmasq",
+			resolvConf: "# Generated by NetworkManager\nnameserver 192.168.1.1\n",
+		},
+		{
+			name:       "static",
+			resolvConf: "nameserver 8.8.8.8\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isResolvedStub(tc.resolvConf); got != tc.resolved {
+				t.Errorf("isResolvedStub() = %t, want %t", got, tc.resolved)
+			}
+			if got := isNetworkManagerDnsmasq(tc.resolvConf); got != tc.dnsmasq {
+				t.Errorf("isNetworkManagerDnsmasq() = %t, want %t", got, tc.dnsmasq)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+)
+
+// fakeResolver records the domains registered for each gateway
+type fakeResolver struct {
+	domains map[string][]dnsDomain
+	err     error
+}
+
+func (r *fakeResolver) Register(gateway net.IP, domains []dnsDomain) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.domains[gateway.String()] = domains
+	return nil
+}
+
+func (r *fakeResolver) Unregister(gateway net.IP, _ []dnsDomain) error {
+	if r.err != nil {
+		return r.err
+	}
+	delete(r.domains, gateway.String())
+	return nil
+}
+
+func TestRouteDomains(t *testing.T) {
+	route := unsafeParseRoute("192.168.49.2", "10.96.0.0/12")
+	if got := routeDomains(route); len(got) != 0 {
+		t.Errorf("expected no domains without a cluster domain, got %v", got)
+	}
+
+	route.ClusterDomain = "cluster.local"
+	route.ClusterDNSIP = net.ParseIP("10.96.0.10")
+	route.IngressDNSDomains = []string{"test"}
+	want := []dnsDomain{
+		{name: "cluster.local", server: net.ParseIP("10.96.0.10")},
+		{name: "test", server: net.ParseIP("192.168.49.2")},
+	}
+	if got := routeDomains(route); !reflect.DeepEqual(got, want) {
+		t.Errorf("routeDomains() = %v, want %v", got, want)
+	}
+}
+
+func TestRegisterDNS(t *testing.T) {
+	route := unsafeParseRoute("192.168.49.2", "10.96.0.0/12")
+	route.ClusterDomain = "cluster.local"
+	route.ClusterDNSIP = net.ParseIP("10.96.0.10")
+
+	r := &fakeResolver{domains: map[string][]dnsDomain{}}
+	if err := registerDNS(r, route); err != nil {
+		t.Fatalf("registerDNS: %v", err)
+	}
+	want := []dnsDomain{{name: "cluster.local", server: net.ParseIP("10.96.0.10")}}
+	if got := r.domains["192.168.49.2"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("registered domains = %v, want %v", got, want)
+	}
+
+	unregisterDNS(r, route)
+	if len(r.domains) != 0 {
+		t.Errorf("expected the domains to be unregistered, got %v", r.domains)
+	}
+
+	// a missing or failing resolver is reported
+	if err := registerDNS(nil, route); err == nil {
+		t.Error("expected an error registering without a resolver")
+	}
+	unregisterDNS(nil, route)
+	failing := &fakeResolver{err: errors.New("no permission")}
+	if err := registerDNS(failing, route); err == nil {
+		t.Error("expected the error of the resolver")
+	}
+	unregisterDNS(failing, route)
+}
+
+func TestDomainsByServer(t *testing.T) {
+	domains := []dnsDomain{
+		{name: "test", server: net.ParseIP("192.168.49.2")},
+		{name: "cluster.local", server: net.ParseIP("10.96.0.10")},
+		{name: "example", server: net.ParseIP("192.168.49.2")},
+	}
+	want := [][]dnsDomain{
+		{domains[0], domains[2]},
+		{domains[1]},
+	}
+	if got := domainsByServer(domains); !reflect.DeepEqual(got, want) {
+		t.Errorf("domainsByServer() = %v, want %v", got, want)
+	}
+}
+
+func TestEnsureDNS(t *testing.T) {
+	route := unsafeParseRoute("192.168.49.2", "10.96.0.0/12")
+	route.ClusterDomain = "cluster.local"
+	route.ClusterDNSIP = net.ParseIP("10.96.0.10")
+
+	r := &fakeResolver{domains: map[string][]dnsDomain{}}
+	registrations := 0
+	newResolver := func() hostResolver {
+		registrations++
+		return r
+	}
+	router := &osRouter{}
+	router.ensureDNS(newResolver, route)
+	router.ensureDNS(newResolver, route)
+	if registrations != 1 {
+		t.Errorf("expected the unchanged domains to be registered once, got %d registrations", registrations)
+	}
+
+	route.IngressDNSDomains = []string{"test"}
+	router.ensureDNS(newResolver, route)
+	if registrations != 2 {
+		t.Errorf("expected the new domains to be registered, got %d registrations", registrations)
+	}
+	if got := r.domains["192.168.49.2"]; len(got) != 2 {
+		t.Errorf("registered domains = %v, want the cluster and ingress-dns domains", got)
+	}
+
+	// a new tunnel registers the domains of the route it finds in place
+	(&osRouter{}).ensureDNS(newResolver, route)
+	if registrations != 3 {
+		t.Errorf("expected a new router to register the domains, got %d registrations", registrations)
+	}
+
+	// a failed registration is retried on the next ensure
+	other := unsafeParseRoute("192.168.58.2", "10.96.0.0/12")
+	other.ClusterDomain = "cluster.local"
+	other.ClusterDNSIP = net.ParseIP("10.96.0.10")
+	r.err = errors.New("resolver not ready")
+	router.ensureDNS(newResolver, other)
+	r.err = nil
+	router.ensureDNS(newResolver, other)
+	if registrations != 5 {
+		t.Errorf("expected the failed registration to be retried, got %d registrations", registrations)
+	}
+	if _, ok := r.domains["192.168.58.2"]; !ok {
+		t.Errorf("expected the domains to be registered after the retry, got %v", r.domains)
+	}
+}
@@ -0,0 +1,520 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/phayes/freeport"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+const (
+	// minForwardBackoff is the delay before reconnecting a broken forward, doubled after each failure
+	minForwardBackoff = time.Second
+	// maxForwardBackoff caps the delay between reconnections
+	maxForwardBackoff = 30 * time.Second
+	// stableForward is how long a connection must last for the backoff to be reset
+	stableForward = 30 * time.Second
+)
+
+// ForwardSpec is a service to forward to stable local ports
+type ForwardSpec struct {
+	Namespace string
+	Name      string
+	// LocalPorts maps service ports to local ports, the other ports of the service are forwarded to free local ports
+	LocalPorts map[int32]int
+}
+
+// forwardConfig is the file format of 'minikube service --forward --forward-config'
+type forwardConfig struct {
+	Namespace string                 `json:"namespace,omitempty"`
+	Services  []forwardConfigService `json:"services"`
+}
+
+type forwardConfigService struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Ports are LOCAL_PORT:SERVICE_PORT pairs, like kubectl port-forward
+	Ports []string `json:"ports,omitempty"`
+}
+
+// LoadForwardConfig reads the services to forward from a YAML file, the namespace defaults to defaultNamespace
+func LoadForwardConfig(path, defaultNamespace string) ([]ForwardSpec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read %s", path)
+	}
+	return parseForwardConfig(b, defaultNamespace)
+}
+
+func parseForwardConfig(b []byte, defaultNamespace string) ([]ForwardSpec, error) {
+	var cfg forwardConfig
+	if err := yaml.UnmarshalStrict(b, &cfg); err != nil {
+		return nil, errors.Wrap(err, "parse forward config")
+	}
+	if cfg.Namespace != "" {
+		defaultNamespace = cfg.Namespace
+	}
+	if len(cfg.Services) == 0 {
+		return nil, fmt.Errorf("no services to forward")
+	}
+	specs := []ForwardSpec{}
+	for _, s := range cfg.Services {
+		if s.Name == "" {
+			return nil, fmt.Errorf("service without a name")
+		}
+		spec := ForwardSpec{Namespace: s.Namespace, Name: s.Name, LocalPorts: map[int32]int{}}
+		if spec.Namespace == "" {
+			spec.Namespace = defaultNamespace
+		}
+		for _, p := range s.Ports {
+			local, remote, err := parsePortPair(p)
+			if err != nil {
+				return nil, errors.Wrapf(err, "service %s", s.Name)
+			}
+			spec.LocalPorts[remote] = local
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// ParseLocalPort parses a LOCAL_PORT:SERVICE_PORT pair, or a LOCAL_PORT for which the service port is 0
+func ParseLocalPort(p string) (int, int32, error) {
+	if strings.Contains(p, ":") {
+		return parsePortPair(p)
+	}
+	l, err := strconv.Atoi(p)
+	if err != nil || l < 1 || l > 65535 {
+		return 0, 0, fmt.Errorf("invalid local port %q", p)
+	}
+	return l, 0, nil
+}
+
+// parsePortPair parses a LOCAL_PORT:SERVICE_PORT pair
+func parsePortPair(p string) (int, int32, error) {
+	local, remote, ok := strings.Cut(p, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid port %q, must be LOCAL_PORT:SERVICE_PORT", p)
+	}
+	l, err := strconv.Atoi(local)
+	if err != nil || l < 1 || l > 65535 {
+		return 0, 0, fmt.Errorf("invalid local port in %q", p)
+	}
+	r, err := strconv.ParseInt(remote, 10, 32)
+	if err != nil || r < 1 || r > 65535 {
+		return 0, 0, fmt.Errorf("invalid service port in %q", p)
+	}
+	return l, int32(r), nil
+}
+
+// SSHTarget is the SSH server of the node the services are forwarded through
+type SSHTarget struct {
+	User string
+	Host string
+	Port int
+	Key  string
+}
+
+// portForward relays a local port to a port of the target IP
+type portForward struct {
+	Local  int
+	Remote int32
+}
+
+// forwardTarget is where the local ports of a service are relayed to
+type forwardTarget struct {
+	IP    string
+	Ports []portForward
+}
+
+// forwardConn is a running port-forward connection
+type forwardConn interface {
+	// Wait returns when the connection breaks
+	Wait() error
+	// Close stops the connection
+	Close() error
+}
+
+// dialFunc opens a port-forward connection to the target
+type dialFunc func(target forwardTarget) (forwardConn, error)
+
+// ServiceForwarder keeps a service forwarded to the same local ports: it reconnects with backoff when the SSH
+// connection breaks, and when the endpoints of the service change.
+type ServiceForwarder struct {
+	spec      ForwardSpec
+	clientset kubernetes.Interface
+	dial      dialFunc
+	sleep     func(ctx context.Context, d time.Duration)
+
+	// mu guards localPorts, allocated by Prepare and by Run when the service gets new ports
+	mu         sync.Mutex
+	localPorts map[int32]int
+}
+
+// NewServiceForwarder creates a forwarder of a service through the SSH server of the node
+func NewServiceForwarder(spec ForwardSpec, ssh SSHTarget, clientset kubernetes.Interface) *ServiceForwarder {
+	return newServiceForwarder(spec, clientset, func(target forwardTarget) (forwardConn, error) {
+		return startSSHForward(ssh, spec.Name, target)
+	})
+}
+
+func newServiceForwarder(spec ForwardSpec, clientset kubernetes.Interface, dial dialFunc) *ServiceForwarder {
+	localPorts := map[int32]int{}
+	for remote, local := range spec.LocalPorts {
+		localPorts[remote] = local
+	}
+	return &ServiceForwarder{
+		spec:       spec,
+		clientset:  clientset,
+		dial:       dial,
+		localPorts: localPorts,
+		sleep:      sleepContext,
+	}
+}
+
+// Prepare checks that the service exists and chooses the local ports, it returns the service ports by local port
+func (f *ServiceForwarder) Prepare(ctx context.Context) (map[int]int32, error) {
+	target, err := f.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ports := map[int]int32{}
+	for _, p := range target.Ports {
+		ports[p.Local] = f.servicePort(p)
+	}
+	return ports, nil
+}
+
+// servicePort returns the service port of a forward, whose remote port might be a target port for headless services
+func (f *ServiceForwarder) servicePort(p portForward) int32 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for remote, local := range f.localPorts {
+		if local == p.Local {
+			return remote
+		}
+	}
+	return p.Remote
+}
+
+// Run forwards the service until the context is done
+func (f *ServiceForwarder) Run(ctx context.Context) {
+	name := f.spec.Namespace + "/" + f.spec.Name
+	backoff := minForwardBackoff
+	for ctx.Err() == nil {
+		target, err := f.resolve(ctx)
+		if err != nil {
+			klog.Warningf("unable to resolve %s: %v", name, err)
+			f.sleep(ctx, backoff)
+			backoff = nextBackoff(backoff)
+			continue
+		}
+		conn, err := f.dial(target)
+		if err != nil {
+			out.Styled(style.Waiting, "Unable to forward {{.service}}, retrying in {{.backoff}}: {{.error}}", out.V{"service": name, "backoff": backoff, "error": err})
+			f.sleep(ctx, backoff)
+			backoff = nextBackoff(backoff)
+			continue
+		}
+		klog.Infof("forwarding %s to %+v", name, target)
+
+		started := time.Now()
+		broken := make(chan error, 1)
+		go func() {
+			broken <- conn.Wait()
+		}()
+		watchCtx, stopWatch := context.WithCancel(ctx)
+		changed := f.watchTarget(watchCtx, target)
+
+		select {
+		case <-ctx.Done():
+			stopWatch()
+			if err := conn.Close(); err != nil {
+				klog.Warningf("error closing forward of %s: %v", name, err)
+			}
+			return
+		case err := <-broken:
+			stopWatch()
+			if time.Since(started) > stableForward {
+				backoff = minForwardBackoff
+			}
+			out.Styled(style.Waiting, "Connection to {{.service}} lost, reconnecting in {{.backoff}}: {{.error}}", out.V{"service": name, "backoff": backoff, "error": err})
+			f.sleep(ctx, backoff)
+			backoff = nextBackoff(backoff)
+		case <-changed:
+			stopWatch()
+			out.Styled(style.Connectivity, "Endpoints of {{.service}} changed, reconnecting", out.V{"service": name})
+			if err := conn.Close(); err != nil {
+				klog.Warningf("error closing forward of %s: %v", name, err)
+			}
+			<-broken
+			backoff = minForwardBackoff
+		}
+	}
+}
+
+// resolve returns where the service is forwarded to, allocating local ports for the new service ports
+func (f *ServiceForwarder) resolve(ctx context.Context) (forwardTarget, error) {
+	svc, err := f.clientset.CoreV1().Services(f.spec.Namespace).Get(ctx, f.spec.Name, metav1.GetOptions{})
+	if err != nil {
+		return forwardTarget{}, errors.Wrapf(err, "get service %s/%s", f.spec.Namespace, f.spec.Name)
+	}
+	var slices []discoveryv1.EndpointSlice
+	if svc.Spec.ClusterIP == v1.ClusterIPNone {
+		list, err := f.clientset.DiscoveryV1().EndpointSlices(f.spec.Namespace).List(ctx, metav1.ListOptions{LabelSelector: discoveryv1.LabelServiceName + "=" + f.spec.Name})
+		if err != nil {
+			return forwardTarget{}, errors.Wrapf(err, "list endpoints of %s/%s", f.spec.Namespace, f.spec.Name)
+		}
+		slices = list.Items
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, p := range svc.Spec.Ports {
+		if _, ok := f.localPorts[p.Port]; ok {
+			continue
+		}
+		local, err := freeport.GetFreePort()
+		if err != nil {
+			return forwardTarget{}, errors.Wrap(err, "get free port")
+		}
+		f.localPorts[p.Port] = local
+	}
+	return forwardTargetOf(svc, slices, f.localPorts)
+}
+
+// forwardTargetOf returns the target of the service: its cluster IP, or a ready endpoint for headless services
+func forwardTargetOf(svc *v1.Service, slices []discoveryv1.EndpointSlice, localPorts map[int32]int) (forwardTarget, error) {
+	var ports []portForward
+	for _, p := range svc.Spec.Ports {
+		if p.Protocol != "" && p.Protocol != v1.ProtocolTCP {
+			continue
+		}
+		ports = append(ports, portForward{Local: localPorts[p.Port], Remote: p.Port})
+	}
+	if len(ports) == 0 {
+		return forwardTarget{}, fmt.Errorf("service %s/%s has no TCP ports", svc.Namespace, svc.Name)
+	}
+	if svc.Spec.ClusterIP != v1.ClusterIPNone && svc.Spec.ClusterIP != "" {
+		return forwardTarget{IP: svc.Spec.ClusterIP, Ports: ports}, nil
+	}
+
+	// headless services are forwarded to a ready endpoint, on the target ports of the endpoint slice
+	for _, slice := range slices {
+		for _, ep := range slice.Endpoints {
+			if ep.Conditions.Ready != nil && !*ep.Conditions.Ready || len(ep.Addresses) == 0 {
+				continue
+			}
+			target := forwardTarget{IP: ep.Addresses[0]}
+			for _, p := range svc.Spec.Ports {
+				if p.Protocol != "" && p.Protocol != v1.ProtocolTCP {
+					continue
+				}
+				target.Ports = append(target.Ports, portForward{Local: localPorts[p.Port], Remote: endpointPort(p, slice.Ports)})
+			}
+			return target, nil
+		}
+	}
+	return forwardTarget{}, fmt.Errorf("headless service %s/%s has no ready endpoints", svc.Namespace, svc.Name)
+}
+
+// endpointPort returns the port of the endpoints the service port is relayed to
+func endpointPort(p v1.ServicePort, ports []discoveryv1.EndpointPort) int32 {
+	for _, ep := range ports {
+		if ep.Port != nil && (ep.Name == nil && p.Name == "" || ep.Name != nil && *ep.Name == p.Name) {
+			return *ep.Port
+		}
+	}
+	if p.TargetPort.IntValue() != 0 {
+		return int32(p.TargetPort.IntValue())
+	}
+	return p.Port
+}
+
+// watchTarget returns a channel closed when the service or its endpoints change so that the target is not the same anymore
+func (f *ServiceForwarder) watchTarget(ctx context.Context, target forwardTarget) <-chan struct{} {
+	changed := make(chan struct{})
+	go func() {
+		defer close(changed)
+		for ctx.Err() == nil {
+			svcWatch, err := f.clientset.CoreV1().Services(f.spec.Namespace).Watch(ctx, metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", f.spec.Name).String()})
+			if err != nil {
+				klog.Warningf("unable to watch service %s: %v", f.spec.Name, err)
+				sleepContext(ctx, minForwardBackoff)
+				continue
+			}
+			epWatch, err := f.clientset.DiscoveryV1().EndpointSlices(f.spec.Namespace).Watch(ctx, metav1.ListOptions{LabelSelector: discoveryv1.LabelServiceName + "=" + f.spec.Name})
+			if err != nil {
+				svcWatch.Stop()
+				klog.Warningf("unable to watch endpoints of %s: %v", f.spec.Name, err)
+				sleepContext(ctx, minForwardBackoff)
+				continue
+			}
+			done := f.waitForChange(ctx, target, svcWatch, epWatch)
+			svcWatch.Stop()
+			epWatch.Stop()
+			if done {
+				return
+			}
+		}
+	}()
+	return changed
+}
+
+// waitForChange returns true when the target changed, and false if the watches ended and must be restarted
+func (f *ServiceForwarder) waitForChange(ctx context.Context, target forwardTarget, watches ...watch.Interface) bool {
+	events := make(chan watch.Event)
+	for _, w := range watches {
+		go func(w watch.Interface) {
+			for ev := range w.ResultChan() {
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case events <- watch.Event{Type: watch.Error}:
+			case <-ctx.Done():
+			}
+		}(w)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case ev := <-events:
+			if ev.Type == watch.Error {
+				return false
+			}
+			current, err := f.resolve(ctx)
+			if err != nil {
+				klog.Infof("service %s changed and cannot be resolved: %v", f.spec.Name, err)
+				return true
+			}
+			if !reflect.DeepEqual(current, target) {
+				klog.Infof("service %s changed: %+v -> %+v", f.spec.Name, target, current)
+				return true
+			}
+		}
+	}
+}
+
+func nextBackoff(d time.Duration) time.Duration {
+	d *= 2
+	if d > maxForwardBackoff {
+		return maxForwardBackoff
+	}
+	return d
+}
+
+func sleepContext(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
+
+// sshForward is a port-forward over an ssh process
+type sshForward struct {
+	cmd  *exec.Cmd
+	done chan error
+}
+
+// startSSHForward relays the local ports to the target through the ssh server of the node. The keepalives make
+// ssh exit when the connection is broken, for example after the host slept.
+func startSSHForward(ssh SSHTarget, service string, target forwardTarget) (forwardConn, error) {
+	cmd := exec.Command("ssh", sshForwardArgs(ssh, target)...)
+	r, w := io.Pipe()
+	cmd.Stdout = w
+	cmd.Stderr = w
+	klog.Infof("About to run command: %s", cmd.Args)
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrap(err, "start ssh")
+	}
+	go logOutput(r, service)
+	f := &sshForward{cmd: cmd, done: make(chan error, 1)}
+	go func() {
+		err := cmd.Wait()
+		w.Close()
+		if err == nil {
+			err = fmt.Errorf("ssh exited")
+		}
+		f.done <- err
+	}()
+	return f, nil
+}
+
+func sshForwardArgs(ssh SSHTarget, target forwardTarget) []string {
+	args := []string{
+		"-o", "UserKnownHostsFile=/dev/null",
+		"-o", "StrictHostKeyChecking=no",
+		"-o", "IdentitiesOnly=yes",
+		// exit instead of running without the forwards, and when the server stops answering
+		"-o", "ExitOnForwardFailure=yes",
+		"-o", "ServerAliveInterval=5",
+		"-o", "ServerAliveCountMax=3",
+		"-N",
+		fmt.Sprintf("%s@%s", ssh.User, ssh.Host),
+		"-p", strconv.Itoa(ssh.Port),
+		"-i", ssh.Key,
+	}
+	ports := append([]portForward{}, target.Ports...)
+	sort.Slice(ports, func(i, j int) bool { return ports[i].Local < ports[j].Local })
+	for _, p := range ports {
+		args = append(args, "-L", fmt.Sprintf("127.0.0.1:%d:%s", p.Local, net.JoinHostPort(target.IP, strconv.Itoa(int(p.Remote)))))
+	}
+	return args
+}
+
+// Wait returns when ssh exits
+func (f *sshForward) Wait() error {
+	err := <-f.done
+	f.done <- err
+	return err
+}
+
+// Close kills ssh
+func (f *sshForward) Close() error {
+	if err := f.cmd.Process.Kill(); err != nil && err != os.ErrProcessDone {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kic
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func TestParseForwardConfig(t *testing.T) {
+	specs, err := parseForwardConfig([]byte(`
+namespace: dev
+services:
+- name: web
+  ports: ["8080:80", "8443:443"]
+- name: db
+  namespace: data
+`), "default")
+	if err != nil {
+		t.Fatalf("parseForwardConfig: %v", err)
+	}
+	want := []ForwardSpec{
+		{Namespace: "dev", Name: "web", LocalPorts: map[int32]int{80: 8080, 443: 8443}},
+		{Namespace: "data", Name: "db", LocalPorts: map[int32]int{}},
+	}
+	if !reflect.DeepEqual(specs, want) {
+		t.Errorf("got %+v, want %+v", specs, want)
+	}
+
+	for _, invalid := range []string{
+		"services: []",
+		"services: [{ports: [\"80:80\"]}]",
+		"services: [{name: web, ports: [\"80\"]}]",
+		"services: [{name: web, ports: [\"80:http\"]}]",
+		"services: [{name: web, port: 80}]",
+	} {
+		if _, err := parseForwardConfig([]byte(invalid), "default"); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestParseLocalPort(t *testing.T) {
+	tests := []struct {
+		in     string
+		local  int
+		remote int32
+		err    bool
+	}{
+		{in: "8080", local: 8080},
+		{in: "8080:80", local: 8080, remote: 80},
+		{in: "0", err: true},
+		{in: "8080:", err: true},
+		{in: "web", err: true},
+	}
+	for _, tc := range tests {
+		local, remote, err := ParseLocalPort(tc.in)
+		if (err != nil) != tc.err || local != tc.local || remote != tc.remote {
+			t.Errorf("ParseLocalPort(%q) = %d, %d, %v", tc.in, local, remote, err)
+		}
+	}
+}
+
+func testService(clusterIP string) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.ServiceSpec{
+			ClusterIP: clusterIP,
+			Ports: []v1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080)},
+				{Name: "dns", Port: 53, Protocol: v1.ProtocolUDP},
+			},
+		},
+	}
+}
+
+func testSlice(ips ...string) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "default", Labels: map[string]string{discoveryv1.LabelServiceName: "web"}},
+		Ports:      []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To[int32](9090)}},
+	}
+	for i, ip := range ips {
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{ip},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(i > 0 || len(ips) == 1)},
+		})
+	}
+	return slice
+}
+
+func TestForwardTargetOf(t *testing.T) {
+	localPorts := map[int32]int{80: 8080, 53: 5353}
+
+	got, err := forwardTargetOf(testService("10.96.0.10"), nil, localPorts)
+	if err != nil {
+		t.Fatalf("forwardTargetOf: %v", err)
+	}
+	want := forwardTarget{IP: "10.96.0.10", Ports: []portForward{{Local: 8080, Remote: 80}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// headless services are forwarded to the first ready endpoint
+	got, err = forwardTargetOf(testService(v1.ClusterIPNone), []discoveryv1.EndpointSlice{*testSlice("10.244.0.5", "10.244.0.6")}, localPorts)
+	if err != nil {
+		t.Fatalf("forwardTargetOf: %v", err)
+	}
+	want = forwardTarget{IP: "10.244.0.6", Ports: []portForward{{Local: 8080, Remote: 9090}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err := forwardTargetOf(testService(v1.ClusterIPNone), nil, localPorts); err == nil {
+		t.Errorf("expected an error for a headless service without endpoints")
+	}
+}
+
+func TestSSHForwardArgs(t *testing.T) {
+	args := sshForwardArgs(SSHTarget{User: "docker", Host: "127.0.0.1", Port: 2222, Key: "/id_rsa"}, forwardTarget{
+		IP:    "fd00::10",
+		Ports: []portForward{{Local: 8443, Remote: 443}, {Local: 8080, Remote: 80}},
+	})
+	got := strings.Join(args, " ")
+	for _, want := range []string{
+		"-o ServerAliveInterval=5",
+		"-o ExitOnForwardFailure=yes",
+		"docker@127.0.0.1 -p 2222 -i /id_rsa",
+		"-L 127.0.0.1:8080:[fd00::10]:80 -L 127.0.0.1:8443:[fd00::10]:443",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %q", want, got)
+		}
+	}
+}
+
+// fakeForward is a connection that breaks when told to, or when closed
+type fakeForward struct {
+	broken chan error
+}
+
+func (f *fakeForward) Wait() error {
+	return <-f.broken
+}
+
+func (f *fakeForward) Close() error {
+	f.broken <- errors.New("closed")
+	return nil
+}
+
+// fakeDialer records the targets of the connections
+type fakeDialer struct {
+	targets chan forwardTarget
+	conns   chan *fakeForward
+}
+
+func newFakeDialer() *fakeDialer {
+	return &fakeDialer{targets: make(chan forwardTarget, 10), conns: make(chan *fakeForward, 10)}
+}
+
+func (d *fakeDialer) dial(target forwardTarget) (forwardConn, error) {
+	c := &fakeForward{broken: make(chan error, 1)}
+	d.targets <- target
+	d.conns <- c
+	return c, nil
+}
+
+func (d *fakeDialer) next(t *testing.T) (forwardTarget, *fakeForward) {
+	t.Helper()
+	select {
+	case target := <-d.targets:
+		return target, <-d.conns
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for a connection")
+	}
+	return forwardTarget{}, nil
+}
+
+func runForwarder(t *testing.T, f *ServiceForwarder) {
+	f.sleep = func(context.Context, time.Duration) {}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		f.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestServiceForwarderReconnects(t *testing.T) {
+	clientset := fake.NewSimpleClientset(testService("10.96.0.10"))
+	d := newFakeDialer()
+	f := newServiceForwarder(ForwardSpec{Namespace: "default", Name: "web"}, clientset, d.dial)
+	ports, err := f.Prepare(context.Background())
+	if err != nil {
+		t.Fatalf("Prepare: %v", err)
+	}
+	if len(ports) != 1 {
+		t.Fatalf("expected a single TCP port, got %v", ports)
+	}
+	runForwarder(t, f)
+
+	first, conn := d.next(t)
+	conn.broken <- errors.New("connection reset")
+	second, _ := d.next(t)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected the same local ports after reconnecting, got %+v then %+v", first, second)
+	}
+	for local, remote := range ports {
+		if first.Ports[0].Local != local || remote != 80 {
+			t.Errorf("unexpected ports %v for %+v", ports, first)
+		}
+	}
+}
+
+func TestServiceForwarderConcurrentPrepare(t *testing.T) {
+	clientset := fake.NewSimpleClientset(testService("10.96.0.10"))
+	d := newFakeDialer()
+	f := newServiceForwarder(ForwardSpec{Namespace: "default", Name: "web"}, clientset, d.dial)
+	runForwarder(t, f)
+
+	// Prepare reads and allocates the local ports while Run resolves the service again on reconnects
+	for i := 0; i < 5; i++ {
+		_, conn := d.next(t)
+		conn.broken <- errors.New("connection reset")
+		if _, err := f.Prepare(context.Background()); err != nil {
+			t.Fatalf("Prepare: %v", err)
+		}
+	}
+	target, _ := d.next(t)
+	ports, err := f.Prepare(context.Background())
+	if err != nil {
+		t.Fatalf("Prepare: %v", err)
+	}
+	if ports[target.Ports[0].Local] != 80 {
+		t.Errorf("expected Prepare and Run to share the local ports, got %v and %+v", ports, target)
+	}
+}
+
+func TestServiceForwarderFollowsEndpoints(t *testing.T) {
+	clientset := fake.NewSimpleClientset(testService(v1.ClusterIPNone), testSlice("10.244.0.5"))
+	sliceWatch := watch.NewFake()
+	watching := make(chan struct{}, 10)
+	clientset.PrependWatchReactor("endpointslices", func(k8stesting.Action) (bool, watch.Interface, error) {
+		watching <- struct{}{}
+		return true, sliceWatch, nil
+	})
+	d := newFakeDialer()
+	f := newServiceForwarder(ForwardSpec{Namespace: "default", Name: "web", LocalPorts: map[int32]int{80: 8080}}, clientset, d.dial)
+	runForwarder(t, f)
+
+	first, _ := d.next(t)
+	if first.IP != "10.244.0.5" || first.Ports[0] != (portForward{Local: 8080, Remote: 9090}) {
+		t.Fatalf("unexpected target %+v", first)
+	}
+
+	<-watching
+	slice := testSlice("10.244.0.7")
+	if _, err := clientset.DiscoveryV1().EndpointSlices("default").Update(context.Background(), slice, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	sliceWatch.Modify(runtime.Object(slice))
+
+	second, _ := d.next(t)
+	if second.IP != "10.244.0.7" || second.Ports[0].Local != 8080 {
+		t.Errorf("expected a connection to the new endpoint on the same local port, got %+v", second)
+	}
+}
@@ -19,6 +19,7 @@ package kic
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -45,6 +46,7 @@ type SSHTunnel struct {
 	LoadBalancerEmulator tunnel.LoadBalancerEmulator
 	conns                map[string]*sshConn
 	connsToStop          map[string]*sshConn
+	state                *tunnel.DaemonState
 }
 
 // NewSSHTunnel creates and returns a new SSHTunnel instance.
@@ -68,6 +70,11 @@ func NewSSHTunnel(ctx context.Context, sshPort, sshKey, bindAddress string, v1Co
 	}
 }
 
+// RecordState makes the tunnel save its state, for 'minikube tunnel status'
+func (t *SSHTunnel) RecordState(st *tunnel.DaemonState) {
+	t.state = st
+}
+
 // Start begins the main reconciliation loop for the SSHTunnel.
 // This loop periodically scans for Kubernetes Services (type LoadBalancer)
 // and Ingresses, creating or tearing down SSH tunnels as necessary.
@@ -110,12 +117,29 @@ func (t *SSHTunnel) Start() error {
 		}
 
 		t.stopMarkedConnections()
+		t.saveState()
 
 		// TODO: which time to use?
 		time.Sleep(1 * time.Second)
 	}
 }
 
+func (t *SSHTunnel) saveState() {
+	if t.state == nil {
+		return
+	}
+	services := []string{}
+	for _, conn := range t.conns {
+		services = append(services, conn.service)
+	}
+	sort.Strings(services)
+	t.state.MinikubeState = tunnel.Running.String()
+	t.state.PatchedServices = services
+	if err := tunnel.WriteDaemonState(t.state); err != nil {
+		klog.Errorf("error saving tunnel state: %v", err)
+	}
+}
+
 func (t *SSHTunnel) markConnectionsToBeStopped() {
 	for _, conn := range t.conns {
 		t.connsToStop[conn.name] = conn
@@ -183,6 +183,34 @@ func (r *persistentRegistry) Remove(route *Route) (rerr error) {
 
 	return nil
 }
+
+// RenameMachine makes the tunnels registered for a machine belong to its new name
+func (r *persistentRegistry) RenameMachine(oldName, newName string) error {
+	klog.V(3).InfoS("renaming machine in tunnel registry", "old", oldName, "new", newName)
+	tunnels, err := r.List()
+	if err != nil {
+		return err
+	}
+	renamed := false
+	for _, t := range tunnels {
+		if t.MachineName == oldName {
+			t.MachineName = newName
+			renamed = true
+		}
+	}
+	if !renamed {
+		return nil
+	}
+	bytes, err := json.Marshal(tunnels)
+	if err != nil {
+		return fmt.Errorf("error marshalling json %s", err)
+	}
+	if err := os.WriteFile(r.path, bytes, 0600); err != nil {
+		return fmt.Errorf("error renaming machine in tunnel registry: %s", err)
+	}
+	return nil
+}
+
 func (r *persistentRegistry) List() ([]*ID, error) {
 	f, err := os.Open(r.path)
 	if err != nil {
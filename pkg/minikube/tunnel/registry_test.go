@@ -176,6 +176,39 @@ func TestRegisterRemoveList(t *testing.T) {
 	}
 }
 
+func TestRenameMachine(t *testing.T) {
+	file := tmpFile(t)
+	reg := &persistentRegistry{
+		path: file,
+	}
+	defer os.Remove(file)
+
+	for _, id := range []*ID{
+		{Route: unsafeParseRoute("192.168.49.2", "10.96.0.0/12"), MachineName: "minikube", Pid: 1234},
+		{Route: unsafeParseRoute("192.168.58.2", "10.96.0.0/12"), MachineName: "other", Pid: 5678},
+	} {
+		if err := reg.Register(id); err != nil {
+			t.Fatalf("failed to register: expected no error, got %s", err)
+		}
+	}
+
+	if err := reg.RenameMachine("minikube", "team-a"); err != nil {
+		t.Fatalf("failed to rename: expected no error, got %s", err)
+	}
+
+	tunnelList, err := reg.List()
+	if err != nil {
+		t.Fatalf("failed to list: expected no error, got %s", err)
+	}
+	expectedList := []*ID{
+		{Route: unsafeParseRoute("192.168.49.2", "10.96.0.0/12"), MachineName: "team-a", Pid: 1234},
+		{Route: unsafeParseRoute("192.168.58.2", "10.96.0.0/12"), MachineName: "other", Pid: 5678},
+	}
+	if len(tunnelList) != 2 || !tunnelList[0].Equal(expectedList[0]) || !tunnelList[1].Equal(expectedList[1]) {
+		t.Errorf("\nexpected %+v,\ngot      %+v", expectedList, tunnelList)
+	}
+}
+
 func TestDuplicateRouteError(t *testing.T) {
 	file := tmpFile(t)
 	reg := &persistentRegistry{
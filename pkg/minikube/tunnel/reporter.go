@@ -86,6 +86,20 @@ func (r *simpleReporter) Report(tunnelState *Status) {
 	}
 }
 
+// stateReporter saves the state of a tunnel running in the background before passing the report on
+type stateReporter struct {
+	state *DaemonState
+	next  reporter
+}
+
+func (r *stateReporter) Report(tunnelState *Status) {
+	r.state.update(tunnelState)
+	if err := WriteDaemonState(r.state); err != nil {
+		klog.Errorf("failed to save tunnel state: %v", err)
+	}
+	r.next.Report(tunnelState)
+}
+
 func newReporter(out io.Writer) reporter {
 	return &simpleReporter{
 		out: out,
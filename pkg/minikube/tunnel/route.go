@@ -39,7 +39,10 @@ type router interface {
 	Cleanup(route *Route) error
 }
 
-type osRouter struct{}
+type osRouter struct {
+	// dnsDomains are the DNS domains the router registered for its route
+	dnsDomains string
+}
 
 type routingTableLine struct {
 	route *Route
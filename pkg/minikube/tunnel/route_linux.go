@@ -31,6 +31,8 @@ func (router *osRouter) EnsureRouteIsAdded(route *Route) error {
 		return err
 	}
 	if exists {
+		// the route survives the tunnel, its DNS domains are registered again by the new one
+		router.ensureDNS(newHostResolver, route)
 		return nil
 	}
 
@@ -50,11 +52,15 @@ func (router *osRouter) EnsureRouteIsAdded(route *Route) error {
 		klog.Errorf("error adding Route: %s, %d", message, len(strings.Split(message, "\n")))
 		return err
 	}
+	router.ensureDNS(newHostResolver, route)
 	return nil
 }
 
 func (router *osRouter) Inspect(route *Route) (exists bool, conflict string, overlaps []string, err error) {
 	cmd := exec.Command("ip", "r")
+	if route.DestCIDR.IP.To4() == nil {
+		cmd = exec.Command("ip", "-6", "r")
+	}
 	cmd.Env = append(cmd.Env, "LC_ALL=C")
 	stdInAndOut, err := cmd.CombinedOutput()
 	if err != nil {
@@ -76,7 +82,7 @@ func (router *osRouter) parseTable(table []byte) routingTable {
 
 		// don't care about the routes that 0.0.0.0
 		if len(fields) == 0 ||
-			len(fields) > 0 && (fields[0] == "default" || fields[0] == "0.0.0.0") {
+			len(fields) > 0 && (fields[0] == "default" || fields[0] == "0.0.0.0" || fields[0] == "::") {
 			continue
 		}
 
@@ -120,6 +126,9 @@ func (router *osRouter) Cleanup(route *Route) error {
 	if err != nil {
 		return err
 	}
+	// idempotent removal of the DNS forwarding, even if the route is already gone
+	unregisterDNS(newHostResolver(), route)
+	router.dnsDomains = ""
 	if !exists {
 		return nil
 	}
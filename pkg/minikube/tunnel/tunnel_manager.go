@@ -37,6 +37,7 @@ type Manager struct {
 	delay    time.Duration
 	registry *persistentRegistry
 	router   router
+	state    *DaemonState
 }
 
 // stateCheckInterval defines how frequently the cluster and route states are checked
@@ -58,12 +59,25 @@ func NewManager() *Manager {
 	}
 }
 
+// RenameMachine makes the tunnels registered for a machine belong to its new name, once its profile was renamed
+func (mgr *Manager) RenameMachine(oldName, newName string) error {
+	return mgr.registry.RenameMachine(oldName, newName)
+}
+
+// RecordState makes the manager save the state of its tunnel, for 'minikube tunnel status'
+func (mgr *Manager) RecordState(st *DaemonState) {
+	mgr.state = st
+}
+
 // StartTunnel starts the tunnel
 func (mgr *Manager) StartTunnel(ctx context.Context, machineName string, machineAPI libmachine.API, configLoader config.Loader, v1Core typed_core.CoreV1Interface) (done chan bool, err error) {
 	tunnel, err := newTunnel(machineName, machineAPI, configLoader, v1Core, mgr.registry, mgr.router)
 	if err != nil {
 		return nil, fmt.Errorf("error creating tunnel: %s", err)
 	}
+	if mgr.state != nil {
+		tunnel.reporter = &stateReporter{state: mgr.state, next: tunnel.reporter}
+	}
 	return mgr.startTunnel(ctx, tunnel)
 
 }
@@ -65,6 +65,8 @@ type Route struct {
 	DestCIDR      *net.IPNet
 	ClusterDomain string
 	ClusterDNSIP  net.IP
+	// IngressDNSDomains are resolved by the ingress-dns addon, listening on the gateway
+	IngressDNSDomains []string
 }
 
 func (r *Route) String() string {
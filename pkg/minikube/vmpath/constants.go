@@ -35,4 +35,8 @@ const (
 	GuestCertStoreDir = "/etc/ssl/certs"
 	// GuestGvisorDir is where gvisor bootstraps from
 	GuestGvisorDir = "/tmp/gvisor"
+	// GuestAPIServerAuditDir is where the audit policy of the API server is stored
+	GuestAPIServerAuditDir = "/etc/kubernetes/audit"
+	// GuestAPIServerAuditLogDir is where the API server writes its audit log
+	GuestAPIServerAuditLogDir = "/var/log/kubernetes/audit"
 )
@@ -238,6 +238,24 @@ func ParseAddr(addr string) (net.IP, *net.IPNet, error) {
 	return ip, network, err
 }
 
+// IPv6Subnet returns the IPv6 unique local /64 subnet and gateway paired with the given IPv4 subnet,
+// embedding the IPv4 network address so that every IPv4 subnet maps to a distinct IPv6 one (eg, 192.168.49.0/24 => fd00:c0a8:3100::/64 with gateway fd00:c0a8:3100::1).
+func IPv6Subnet(ipv4Subnet string) (subnet string, gateway string, err error) {
+	ip, _, err := ParseAddr(ipv4Subnet)
+	if err != nil {
+		return "", "", err
+	}
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return "", "", fmt.Errorf("%s is not an IPv4 address", ipv4Subnet)
+	}
+	ula := net.IP{0xfd, 0x00, ip4[0], ip4[1], ip4[2], ip4[3], 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	gw := make(net.IP, net.IPv6len)
+	copy(gw, ula)
+	gw[net.IPv6len-1] = 1
+	return fmt.Sprintf("%s/64", ula), gw.String(), nil
+}
+
 // reserveSubnet returns releaser if subnet was successfully reserved, creating lock for subnet to avoid race condition between multiple minikube instances (especially while testing in parallel).
 var reserveSubnet = func(subnet string) (mutex.Releaser, error) {
 	spec := lock.PathMutexSpec(subnet)
@@ -145,3 +145,32 @@ func TestParseAddr(t *testing.T) {
 		}
 	})
 }
+
+func TestIPv6Subnet(t *testing.T) {
+	tests := []struct {
+		ipv4    string
+		subnet  string
+		gateway string
+		err     bool
+	}{
+		{ipv4: "192.168.49.0", subnet: "fd00:c0a8:3100::/64", gateway: "fd00:c0a8:3100::1"},
+		{ipv4: "192.168.58.0/24", subnet: "fd00:c0a8:3a00::/64", gateway: "fd00:c0a8:3a00::1"},
+		{ipv4: "fd00::1", err: true},
+		{ipv4: "192.168.9", err: true},
+	}
+	for _, tc := range tests {
+		subnet, gateway, err := IPv6Subnet(tc.ipv4)
+		if tc.err {
+			if err == nil {
+				t.Errorf("IPv6Subnet(%q) expected an error", tc.ipv4)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("IPv6Subnet(%q): %v", tc.ipv4, err)
+		}
+		if subnet != tc.subnet || gateway != tc.gateway {
+			t.Errorf("IPv6Subnet(%q) = %s, %s; want %s, %s", tc.ipv4, subnet, gateway, tc.subnet, tc.gateway)
+		}
+	}
+}
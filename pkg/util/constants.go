@@ -41,8 +41,10 @@ func ServiceClusterIP(serviceCIDR string) (net.IP, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "parsing default service cidr")
 	}
-	ip = ip.To4()
-	ip[3]++
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	ip[len(ip)-1]++
 	return ip, nil
 }
 
@@ -52,8 +54,10 @@ func DNSIP(serviceCIDR string) (net.IP, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "parsing default service cidr")
 	}
-	ip = ip.To4()
-	ip[3] = 10
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	ip[len(ip)-1] = 10
 	return ip, nil
 }
 
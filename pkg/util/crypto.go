@@ -66,29 +66,9 @@ func GenerateCACert(certPath, keyPath string, name string) error {
 // GenerateSignedCert generates a signed certificate and key
 func GenerateSignedCert(certPath, keyPath, cn string, ips []net.IP, alternateDNS []string, signerCertPath, signerKeyPath string, expiration time.Duration) error {
 	klog.Infof("Generating cert %s with IP's: %s", certPath, ips)
-	signerCertBytes, err := os.ReadFile(signerCertPath)
-	if err != nil {
-		return errors.Wrap(err, "Error reading file: signerCertPath")
-	}
-	decodedSignerCert, _ := pem.Decode(signerCertBytes)
-	if decodedSignerCert == nil {
-		return errors.New("Unable to decode certificate")
-	}
-	signerCert, err := x509.ParseCertificate(decodedSignerCert.Bytes)
+	signerCert, signerKey, err := loadSigner(signerCertPath, signerKeyPath)
 	if err != nil {
-		return errors.Wrap(err, "Error parsing certificate: decodedSignerCert.Bytes")
-	}
-	signerKeyBytes, err := os.ReadFile(signerKeyPath)
-	if err != nil {
-		return errors.Wrap(err, "Error reading file: signerKeyPath")
-	}
-	decodedSignerKey, _ := pem.Decode(signerKeyBytes)
-	if decodedSignerKey == nil {
-		return errors.New("Unable to decode key")
-	}
-	signerKey, err := x509.ParsePKCS1PrivateKey(decodedSignerKey.Bytes)
-	if err != nil {
-		return errors.Wrap(err, "Error parsing private key: decodedSignerKey.Bytes")
+		return err
 	}
 
 	template := x509.Certificate{
@@ -116,6 +96,70 @@ func GenerateSignedCert(certPath, keyPath, cn string, ips []net.IP, alternateDNS
 	return writeCertsAndKeys(&template, certPath, priv, keyPath, signerCert, signerKey)
 }
 
+// GenerateClientCert generates a client certificate and key for the user cn, member of groups, signed by the signer.
+// The key is generated again, and the serial number is random, so that the certificates of a user can be told apart.
+func GenerateClientCert(certPath, keyPath, cn string, groups []string, signerCertPath, signerKeyPath string, expiration time.Duration) error {
+	klog.Infof("Generating client cert %s for %s in groups %s", certPath, cn, groups)
+	signerCert, signerKey, err := loadSigner(signerCertPath, signerKeyPath)
+	if err != nil {
+		return err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return errors.Wrap(err, "Error generating serial number")
+	}
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   cn,
+			Organization: groups,
+		},
+		NotBefore: time.Now().Add(-time.Hour),
+		NotAfter:  time.Now().Add(expiration),
+
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return errors.Wrap(err, "Error generating RSA key")
+	}
+
+	return writeCertsAndKeys(&template, certPath, priv, keyPath, signerCert, signerKey)
+}
+
+// loadSigner reads the certificate and key of a certificate authority
+func loadSigner(signerCertPath, signerKeyPath string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	signerCertBytes, err := os.ReadFile(signerCertPath)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error reading file: signerCertPath")
+	}
+	decodedSignerCert, _ := pem.Decode(signerCertBytes)
+	if decodedSignerCert == nil {
+		return nil, nil, errors.New("Unable to decode certificate")
+	}
+	signerCert, err := x509.ParseCertificate(decodedSignerCert.Bytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error parsing certificate: decodedSignerCert.Bytes")
+	}
+	signerKeyBytes, err := os.ReadFile(signerKeyPath)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error reading file: signerKeyPath")
+	}
+	decodedSignerKey, _ := pem.Decode(signerKeyBytes)
+	if decodedSignerKey == nil {
+		return nil, nil, errors.New("Unable to decode key")
+	}
+	signerKey, err := x509.ParsePKCS1PrivateKey(decodedSignerKey.Bytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error parsing private key: decodedSignerKey.Bytes")
+	}
+	return signerCert, signerKey, nil
+}
+
 func loadOrGeneratePrivateKey(keyPath string) (*rsa.PrivateKey, error) {
 	keyBytes, err := os.ReadFile(keyPath)
 	if err == nil {
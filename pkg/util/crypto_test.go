@@ -22,7 +22,9 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"k8s.io/minikube/pkg/minikube/constants"
 )
@@ -133,3 +135,48 @@ func TestGenerateSignedCert(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateClientCert(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	signerCertPath := filepath.Join(tmpDir, "ca.crt")
+	signerKeyPath := filepath.Join(tmpDir, "ca.key")
+	if err := GenerateCACert(signerCertPath, signerKeyPath, constants.APIServerName); err != nil {
+		t.Fatalf("Error generating signer cert")
+	}
+
+	certPath := filepath.Join(tmpDir, "users", "alice.crt")
+	keyPath := filepath.Join(tmpDir, "users", "alice.key")
+	if err := GenerateClientCert(certPath, keyPath, "alice", []string{"dev", "viewers"}, signerCertPath, signerKeyPath, time.Hour); err != nil {
+		t.Fatalf("GenerateClientCert() error = %v", err)
+	}
+
+	certBytes, err := os.ReadFile(certPath)
+	if err != nil {
+		t.Fatalf("Error reading cert data: %v", err)
+	}
+	data, _ := pem.Decode(certBytes)
+	cert, err := x509.ParseCertificate(data.Bytes)
+	if err != nil {
+		t.Fatalf("Error parsing certificate: %v", err)
+	}
+	if cert.Subject.CommonName != "alice" || strings.Join(cert.Subject.Organization, ",") != "dev,viewers" {
+		t.Errorf("subject = %s, want CN=alice in the groups dev and viewers", cert.Subject)
+	}
+	if d := time.Until(cert.NotAfter); d > time.Hour || d < 50*time.Minute {
+		t.Errorf("certificate expires in %s, want 1h", d)
+	}
+	if len(cert.ExtKeyUsage) != 1 || cert.ExtKeyUsage[0] != x509.ExtKeyUsageClientAuth {
+		t.Errorf("certificate usages = %v, want client auth only", cert.ExtKeyUsage)
+	}
+
+	signerBytes, _ := os.ReadFile(signerCertPath)
+	signerData, _ := pem.Decode(signerBytes)
+	signer, err := x509.ParseCertificate(signerData.Bytes)
+	if err != nil {
+		t.Fatalf("Error parsing signer certificate: %v", err)
+	}
+	if err := cert.CheckSignatureFrom(signer); err != nil {
+		t.Errorf("certificate is not signed by the CA: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+//go:build integration
+
+/*
+Copyright 2025 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"os/exec"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestProfileRename tests renaming a stopped multi-node cluster keeping its data
+func TestProfileRename(t *testing.T) {
+	if !KicDriver() {
+		t.Skip("skipping: profile rename is only supported with the docker and podman drivers")
+	}
+
+	oldProfile := UniqueProfileName("rename-old")
+	newProfile := UniqueProfileName("rename-new")
+	ctx, cancel := context.WithTimeout(context.Background(), Minutes(30))
+	defer Cleanup(t, oldProfile, cancel)
+	defer Cleanup(t, newProfile, cancel)
+
+	startArgs := append([]string{"start", "-p", oldProfile, "--wait=true", "--memory=3072", "--nodes=2", "-v=5", "--alsologtostderr"}, StartArgs()...)
+	rr, err := Run(t, exec.CommandContext(ctx, Target(), startArgs...))
+	if err != nil {
+		t.Fatalf("failed to start cluster. args %q : %v", rr.Command(), err)
+	}
+
+	// the data of the cluster must be kept by the rename
+	rr, err = Run(t, exec.CommandContext(ctx, "kubectl", "--context", oldProfile, "create", "configmap", "kept", "--from-literal=key=value"))
+	if err != nil {
+		t.Fatalf("failed to create configmap. args %q : %v", rr.Command(), err)
+	}
+
+	rr, err = Run(t, exec.CommandContext(ctx, Target(), "stop", "-p", oldProfile))
+	if err != nil {
+		t.Fatalf("failed to stop cluster. args %q : %v", rr.Command(), err)
+	}
+
+	rr, err = Run(t, exec.CommandContext(ctx, Target(), "profile", "rename", oldProfile, newProfile, "-v=5", "--alsologtostderr"))
+	if err != nil {
+		t.Fatalf("failed to rename profile. args %q : %v", rr.Command(), err)
+	}
+
+	startArgs = append([]string{"start", "-p", newProfile, "--wait=true", "-v=5", "--alsologtostderr"}, StartArgs()...)
+	rr, err = Run(t, exec.CommandContext(ctx, Target(), startArgs...))
+	if err != nil {
+		t.Fatalf("failed to start renamed cluster. args %q : %v", rr.Command(), err)
+	}
+
+	rr, err = Run(t, exec.CommandContext(ctx, "kubectl", "--context", newProfile, "get", "configmap", "kept", "-o", "jsonpath={.data.key}"))
+	if err != nil {
+		t.Fatalf("failed to get configmap. args %q : %v", rr.Command(), err)
+	}
+	if got := strings.TrimSpace(rr.Stdout.String()); got != "value" {
+		t.Errorf("expected the configmap of the cluster to be kept, got %q", got)
+	}
+
+	// the nodes of the old machines are replaced by the nodes of the new ones
+	rr, err = Run(t, exec.CommandContext(ctx, "kubectl", "--context", newProfile, "wait", "--for=condition=Ready", "nodes", "--all", "--timeout=120s"))
+	if err != nil {
+		t.Fatalf("failed to wait for the nodes. args %q : %v", rr.Command(), err)
+	}
+	rr, err = Run(t, exec.CommandContext(ctx, "kubectl", "--context", newProfile, "get", "nodes", "-o", `jsonpath={range .items[*]}{.metadata.name}{"\n"}{end}`))
+	if err != nil {
+		t.Fatalf("failed to get nodes. args %q : %v", rr.Command(), err)
+	}
+	nodes := strings.Fields(rr.Stdout.String())
+	sort.Strings(nodes)
+	want := []string{newProfile, newProfile + "-m02"}
+	if strings.Join(nodes, " ") != strings.Join(want, " ") {
+		t.Errorf("expected the nodes %v after the rename, got %v", want, nodes)
+	}
+
+	// the kubelets must use certificates issued for their new names
+	rr, err = Run(t, exec.CommandContext(ctx, Target(), "-p", newProfile, "ssh", "-n", newProfile+"-m02", "--", "sudo openssl x509 -noout -subject -in /var/lib/kubelet/pki/kubelet-client-current.pem"))
+	if err != nil {
+		t.Fatalf("failed to read the kubelet certificate. args %q : %v", rr.Command(), err)
+	}
+	if !strings.Contains(rr.Stdout.String(), "system:node:"+newProfile+"-m02") {
+		t.Errorf("expected the kubelet certificate to be issued for %s, got %q", newProfile+"-m02", rr.Stdout.String())
+	}
+}
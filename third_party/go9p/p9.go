@@ -177,6 +177,23 @@ type Fcall struct {
 	Ext      string // special file description, 9P2000.u only (used by Tcreate)
 	Unamenum uint32 // user ID, 9P2000.u only (used by Tauth, Tattach)
 
+	/* 9P2000.L extensions */
+	Flags    uint32  // open flags, lock flags or unlinkat flags (used by Tlopen, Tlcreate, Tunlinkat, Txattrcreate)
+	Lmode    uint32  // unix mode of the new file (used by Tlcreate, Tmkdir, Tmknod)
+	Ngid     uint32  // group ID of the new file (used by Tlcreate, Tmkdir, Tmknod, Tsymlink)
+	Major    uint32  // major device number (used by Tmknod)
+	Minor    uint32  // minor device number (used by Tmknod)
+	Dfid     uint32  // directory fid (used by Trename, Trenameat, Tlink)
+	Target   string  // symbolic link target (used by Tsymlink, Rreadlink)
+	Newname  string  // new file name (used by Trenameat)
+	Mask     uint64  // requested attributes (used by Tgetattr)
+	Attr     Lattr   // file attributes (used by Rgetattr)
+	SetAttr  SetAttr // attributes to change (used by Tsetattr)
+	Statfs   Statfs  // file system description (used by Rstatfs)
+	Lock     Lock    // POSIX lock (used by Tlock, Tgetlock, Rgetlock)
+	Status   uint8   // lock status (used by Rlock)
+	Datasync uint32  // only flush the data (used by Tfsync)
+
 	Pkt []uint8 // raw packet data
 	Buf []uint8 // buffer to put the raw data in
 }
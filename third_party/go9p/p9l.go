@@ -0,0 +1,589 @@
+// Copyright 2009 The Go9p Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package go9p
+
+// 9P2000.L message types
+const (
+	Tlerror      = 6
+	Rlerror      = 7
+	Tstatfs      = 8
+	Rstatfs      = 9
+	Tlopen       = 12
+	Rlopen       = 13
+	Tlcreate     = 14
+	Rlcreate     = 15
+	Tsymlink     = 16
+	Rsymlink     = 17
+	Tmknod       = 18
+	Rmknod       = 19
+	Trename      = 20
+	Rrename      = 21
+	Treadlink    = 22
+	Rreadlink    = 23
+	Tgetattr     = 24
+	Rgetattr     = 25
+	Tsetattr     = 26
+	Rsetattr     = 27
+	Txattrwalk   = 30
+	Rxattrwalk   = 31
+	Txattrcreate = 32
+	Rxattrcreate = 33
+	Treaddir     = 40
+	Rreaddir     = 41
+	Tfsync       = 50
+	Rfsync       = 51
+	Tlock        = 52
+	Rlock        = 53
+	Tgetlock     = 54
+	Rgetlock     = 55
+	Tlink        = 70
+	Rlink        = 71
+	Tmkdir       = 72
+	Rmkdir       = 73
+	Trenameat    = 74
+	Rrenameat    = 75
+	Tunlinkat    = 76
+	Runlinkat    = 77
+)
+
+// Flags of Tlopen and Tlcreate, in addition to the access mode, with the values of Linux
+const (
+	LOCREAT  = 0100
+	LOEXCL   = 0200
+	LOTRUNC  = 01000
+	LOAPPEND = 02000
+)
+
+// Bits of the request mask of Tgetattr and the valid mask of Rgetattr
+const (
+	GetattrMode        = 0x00000001
+	GetattrNlink       = 0x00000002
+	GetattrUid         = 0x00000004
+	GetattrGid         = 0x00000008
+	GetattrRdev        = 0x00000010
+	GetattrAtime       = 0x00000020
+	GetattrMtime       = 0x00000040
+	GetattrCtime       = 0x00000080
+	GetattrIno         = 0x00000100
+	GetattrSize        = 0x00000200
+	GetattrBlocks      = 0x00000400
+	GetattrBtime       = 0x00000800
+	GetattrGen         = 0x00001000
+	GetattrDataVersion = 0x00002000
+	GetattrBasic       = 0x000007ff // everything up to GetattrBlocks
+	GetattrAll         = 0x00003fff
+)
+
+// Bits of the valid mask of Tsetattr
+const (
+	SetattrMode     = 0x00000001
+	SetattrUid      = 0x00000002
+	SetattrGid      = 0x00000004
+	SetattrSize     = 0x00000008
+	SetattrAtime    = 0x00000010
+	SetattrMtime    = 0x00000020
+	SetattrCtime    = 0x00000040
+	SetattrAtimeSet = 0x00000080 // use the atime of the message, the current time otherwise
+	SetattrMtimeSet = 0x00000100 // use the mtime of the message, the current time otherwise
+)
+
+// Lock types of Tlock and Tgetlock
+const (
+	LockTypeRdlck = 0
+	LockTypeWrlck = 1
+	LockTypeUnlck = 2
+)
+
+// Lock flags of Tlock
+const (
+	LockFlagsBlock   = 1
+	LockFlagsReclaim = 2
+)
+
+// Lock status of Rlock
+const (
+	LockSuccess = 0
+	LockBlocked = 1
+	LockError   = 2
+	LockGrace   = 3
+)
+
+// Flag of Tunlinkat to remove a directory
+const AtRemovedir = 0x200
+
+// Linux errors returned by the 9P2000.L operations, in addition to the ones above
+const (
+	EAGAIN       = 11
+	EACCES       = 13
+	EDEADLK      = 35
+	ENAMETOOLONG = 36
+	ENOLCK       = 37
+	ENOSYS       = 38
+	ENOTEMPTY    = 39
+	ELOOP        = 40
+	ENODATA      = 61
+	EOPNOTSUPP   = 95
+)
+
+// Ltime is a timestamp of a 9P2000.L message
+type Ltime struct {
+	Sec  uint64
+	Nsec uint64
+}
+
+// Lattr describes a file in a Rgetattr message
+type Lattr struct {
+	Valid       uint64 // mask of the valid fields
+	Qid         Qid
+	Mode        uint32 // unix mode, including the file type
+	Uid         uint32
+	Gid         uint32
+	Nlink       uint64
+	Rdev        uint64
+	Size        uint64
+	Blksize     uint64
+	Blocks      uint64 // number of 512 bytes blocks
+	Atime       Ltime
+	Mtime       Ltime
+	Ctime       Ltime
+	Btime       Ltime
+	Gen         uint64
+	DataVersion uint64
+}
+
+// SetAttr contains the changes of a Tsetattr message
+type SetAttr struct {
+	Valid uint32 // mask of the fields to change
+	Mode  uint32
+	Uid   uint32
+	Gid   uint32
+	Size  uint64
+	Atime Ltime
+	Mtime Ltime
+}
+
+// Statfs describes a file system in a Rstatfs message
+type Statfs struct {
+	Type    uint32
+	Bsize   uint32
+	Blocks  uint64
+	Bfree   uint64
+	Bavail  uint64
+	Files   uint64
+	Ffree   uint64
+	Fsid    uint64
+	Namelen uint32
+}
+
+// Lock describes a POSIX lock in Tlock, Tgetlock and Rgetlock messages
+type Lock struct {
+	Type     uint8
+	Flags    uint32 // only used by Tlock
+	Start    uint64
+	Length   uint64 // 0 means until the end of the file
+	ProcId   uint32
+	ClientId string
+}
+
+// Dirent is an entry of a Rreaddir message
+type Dirent struct {
+	Qid
+	Offset uint64 // offset to read the next entry from
+	Type   uint8  // type of the file, as in the d_type field of struct dirent
+	Name   string
+}
+
+func direntsz(d *Dirent) int { return 13 + 8 + 1 + 2 + len(d.Name) }
+
+// Converts a Dirent value to its on-the-wire representation and appends it
+// to buf. Returns nil if there is not enough space in buf.
+func PackDirent(d *Dirent, buf []byte) []byte {
+	sz := direntsz(d)
+	if cap(buf)-len(buf) < sz {
+		return nil
+	}
+
+	b := buf[len(buf) : len(buf)+sz]
+	p := pqid(&d.Qid, b)
+	p = pint64(d.Offset, p)
+	p = pint8(d.Type, p)
+	pstr(d.Name, p)
+	return buf[0 : len(buf)+sz]
+}
+
+// Converts the data of a Rreaddir message to Dirent values.
+func UnpackDirents(buf []byte) ([]Dirent, error) {
+	var ds []Dirent
+	for len(buf) > 0 {
+		if len(buf) < 13+8+1+2 {
+			return nil, &Error{"short buffer", EINVAL}
+		}
+
+		var d Dirent
+		p := gqid(buf, &d.Qid)
+		d.Offset, p = gint64(p)
+		d.Type, p = gint8(p)
+		d.Name, p = gstr(p)
+		if p == nil {
+			return nil, &Error{"short buffer", EINVAL}
+		}
+
+		ds = append(ds, d)
+		buf = p
+	}
+
+	return ds, nil
+}
+
+func gltime(buf []byte, t *Ltime) []byte {
+	t.Sec, buf = gint64(buf)
+	t.Nsec, buf = gint64(buf)
+	return buf
+}
+
+func pltime(t *Ltime, buf []byte) []byte {
+	buf = pint64(t.Sec, buf)
+	return pint64(t.Nsec, buf)
+}
+
+const lattrsz = 8 + 13 + 4 + 4 + 4 + 8*5 + 16*4 + 8 + 8
+
+func glattr(buf []byte, a *Lattr) []byte {
+	a.Valid, buf = gint64(buf)
+	buf = gqid(buf, &a.Qid)
+	a.Mode, buf = gint32(buf)
+	a.Uid, buf = gint32(buf)
+	a.Gid, buf = gint32(buf)
+	a.Nlink, buf = gint64(buf)
+	a.Rdev, buf = gint64(buf)
+	a.Size, buf = gint64(buf)
+	a.Blksize, buf = gint64(buf)
+	a.Blocks, buf = gint64(buf)
+	buf = gltime(buf, &a.Atime)
+	buf = gltime(buf, &a.Mtime)
+	buf = gltime(buf, &a.Ctime)
+	buf = gltime(buf, &a.Btime)
+	a.Gen, buf = gint64(buf)
+	a.DataVersion, buf = gint64(buf)
+	return buf
+}
+
+func plattr(a *Lattr, buf []byte) []byte {
+	buf = pint64(a.Valid, buf)
+	buf = pqid(&a.Qid, buf)
+	buf = pint32(a.Mode, buf)
+	buf = pint32(a.Uid, buf)
+	buf = pint32(a.Gid, buf)
+	buf = pint64(a.Nlink, buf)
+	buf = pint64(a.Rdev, buf)
+	buf = pint64(a.Size, buf)
+	buf = pint64(a.Blksize, buf)
+	buf = pint64(a.Blocks, buf)
+	buf = pltime(&a.Atime, buf)
+	buf = pltime(&a.Mtime, buf)
+	buf = pltime(&a.Ctime, buf)
+	buf = pltime(&a.Btime, buf)
+	buf = pint64(a.Gen, buf)
+	return pint64(a.DataVersion, buf)
+}
+
+const setattrsz = 4 + 4 + 4 + 4 + 8 + 16 + 16
+
+func gsetattr(buf []byte, a *SetAttr) []byte {
+	a.Valid, buf = gint32(buf)
+	a.Mode, buf = gint32(buf)
+	a.Uid, buf = gint32(buf)
+	a.Gid, buf = gint32(buf)
+	a.Size, buf = gint64(buf)
+	buf = gltime(buf, &a.Atime)
+	return gltime(buf, &a.Mtime)
+}
+
+func psetattr(a *SetAttr, buf []byte) []byte {
+	buf = pint32(a.Valid, buf)
+	buf = pint32(a.Mode, buf)
+	buf = pint32(a.Uid, buf)
+	buf = pint32(a.Gid, buf)
+	buf = pint64(a.Size, buf)
+	buf = pltime(&a.Atime, buf)
+	return pltime(&a.Mtime, buf)
+}
+
+const statfssz = 4 + 4 + 8*6 + 4
+
+func gstatfs(buf []byte, s *Statfs) []byte {
+	s.Type, buf = gint32(buf)
+	s.Bsize, buf = gint32(buf)
+	s.Blocks, buf = gint64(buf)
+	s.Bfree, buf = gint64(buf)
+	s.Bavail, buf = gint64(buf)
+	s.Files, buf = gint64(buf)
+	s.Ffree, buf = gint64(buf)
+	s.Fsid, buf = gint64(buf)
+	s.Namelen, buf = gint32(buf)
+	return buf
+}
+
+func pstatfs(s *Statfs, buf []byte) []byte {
+	buf = pint32(s.Type, buf)
+	buf = pint32(s.Bsize, buf)
+	buf = pint64(s.Blocks, buf)
+	buf = pint64(s.Bfree, buf)
+	buf = pint64(s.Bavail, buf)
+	buf = pint64(s.Files, buf)
+	buf = pint64(s.Ffree, buf)
+	buf = pint64(s.Fsid, buf)
+	return pint32(s.Namelen, buf)
+}
+
+// glock reads a lock, with the flags if withFlags is true (Tlock)
+func glock(buf []byte, l *Lock, withFlags bool) []byte {
+	l.Type, buf = gint8(buf)
+	if withFlags {
+		l.Flags, buf = gint32(buf)
+	}
+	l.Start, buf = gint64(buf)
+	l.Length, buf = gint64(buf)
+	l.ProcId, buf = gint32(buf)
+	l.ClientId, buf = gstr(buf)
+	return buf
+}
+
+func plock(l *Lock, buf []byte, withFlags bool) []byte {
+	buf = pint8(l.Type, buf)
+	if withFlags {
+		buf = pint32(l.Flags, buf)
+	}
+	buf = pint64(l.Start, buf)
+	buf = pint64(l.Length, buf)
+	buf = pint32(l.ProcId, buf)
+	return pstr(l.ClientId, buf)
+}
+
+func locksz(l *Lock, withFlags bool) int {
+	sz := 1 + 8 + 8 + 4 + 2 + len(l.ClientId)
+	if withFlags {
+		sz += 4
+	}
+
+	return sz
+}
+
+// minimum size of a 9P2000.L message for a type, without the header
+var minFclsize = map[uint8]uint32{
+	Rlerror:      4, /* ecode[4] */
+	Tstatfs:      4, /* fid[4] */
+	Rstatfs:      statfssz,
+	Tlopen:       8,  /* fid[4] flags[4] */
+	Rlopen:       17, /* qid[13] iounit[4] */
+	Tlcreate:     18, /* fid[4] name[s] flags[4] mode[4] gid[4] */
+	Rlcreate:     17, /* qid[13] iounit[4] */
+	Tsymlink:     12, /* fid[4] name[s] symtgt[s] gid[4] */
+	Rsymlink:     13, /* qid[13] */
+	Tmknod:       22, /* dfid[4] name[s] mode[4] major[4] minor[4] gid[4] */
+	Rmknod:       13, /* qid[13] */
+	Trename:      10, /* fid[4] dfid[4] name[s] */
+	Rrename:      0,
+	Treadlink:    4,  /* fid[4] */
+	Rreadlink:    2,  /* target[s] */
+	Tgetattr:     12, /* fid[4] request_mask[8] */
+	Rgetattr:     lattrsz,
+	Tsetattr:     4 + setattrsz, /* fid[4] attr */
+	Rsetattr:     0,
+	Txattrwalk:   10, /* fid[4] newfid[4] name[s] */
+	Rxattrwalk:   8,  /* size[8] */
+	Txattrcreate: 18, /* fid[4] name[s] attr_size[8] flags[4] */
+	Rxattrcreate: 0,
+	Treaddir:     16, /* fid[4] offset[8] count[4] */
+	Rreaddir:     4,  /* count[4] */
+	Tfsync:       4,  /* fid[4] (datasync[4]) */
+	Rfsync:       0,
+	Tlock:        4 + 27, /* fid[4] type[1] flags[4] start[8] length[8] proc_id[4] client_id[s] */
+	Rlock:        1,      /* status[1] */
+	Tgetlock:     4 + 23, /* fid[4] type[1] start[8] length[8] proc_id[4] client_id[s] */
+	Rgetlock:     23,
+	Tlink:        10, /* dfid[4] fid[4] name[s] */
+	Rlink:        0,
+	Tmkdir:       14, /* dfid[4] name[s] mode[4] gid[4] */
+	Rmkdir:       13, /* qid[13] */
+	Trenameat:    12, /* olddirfid[4] oldname[s] newdirfid[4] newname[s] */
+	Rrenameat:    0,
+	Tunlinkat:    10, /* dirfid[4] name[s] flags[4] */
+	Runlinkat:    0,
+}
+
+// IsDotl returns whether the message type is a 9P2000.L one
+func IsDotl(typ uint8) bool {
+	_, ok := minFclsize[typ]
+	return ok
+}
+
+// unpackl unpacks the body of a 9P2000.L message
+func unpackl(fc *Fcall, p []byte) ([]byte, error) {
+	if uint32(len(p)) < minFclsize[fc.Type] {
+		return nil, &Error{"invalid size", EINVAL}
+	}
+
+	switch fc.Type {
+	case Rlerror:
+		fc.Errornum, p = gint32(p)
+
+	case Tstatfs, Treadlink:
+		fc.Fid, p = gint32(p)
+
+	case Rstatfs:
+		p = gstatfs(p, &fc.Statfs)
+
+	case Tlopen:
+		fc.Fid, p = gint32(p)
+		fc.Flags, p = gint32(p)
+
+	case Rlopen, Rlcreate:
+		p = gqid(p, &fc.Qid)
+		fc.Iounit, p = gint32(p)
+
+	case Tlcreate:
+		fc.Fid, p = gint32(p)
+		fc.Name, p = gstr(p)
+		if len(p) < 12 {
+			return nil, &Error{"invalid size", EINVAL}
+		}
+		fc.Flags, p = gint32(p)
+		fc.Lmode, p = gint32(p)
+		fc.Ngid, p = gint32(p)
+
+	case Tsymlink:
+		fc.Fid, p = gint32(p)
+		fc.Name, p = gstr(p)
+		fc.Target, p = gstr(p)
+		if len(p) < 4 {
+			return nil, &Error{"invalid size", EINVAL}
+		}
+		fc.Ngid, p = gint32(p)
+
+	case Rsymlink, Rmknod, Rmkdir:
+		p = gqid(p, &fc.Qid)
+
+	case Tmknod:
+		fc.Fid, p = gint32(p)
+		fc.Name, p = gstr(p)
+		if len(p) < 16 {
+			return nil, &Error{"invalid size", EINVAL}
+		}
+		fc.Lmode, p = gint32(p)
+		fc.Major, p = gint32(p)
+		fc.Minor, p = gint32(p)
+		fc.Ngid, p = gint32(p)
+
+	case Trename:
+		fc.Fid, p = gint32(p)
+		fc.Dfid, p = gint32(p)
+		fc.Name, p = gstr(p)
+
+	case Rreadlink:
+		fc.Target, p = gstr(p)
+
+	case Tgetattr:
+		fc.Fid, p = gint32(p)
+		fc.Mask, p = gint64(p)
+
+	case Rgetattr:
+		p = glattr(p, &fc.Attr)
+
+	case Tsetattr:
+		fc.Fid, p = gint32(p)
+		p = gsetattr(p, &fc.SetAttr)
+
+	case Txattrwalk:
+		fc.Fid, p = gint32(p)
+		fc.Newfid, p = gint32(p)
+		fc.Name, p = gstr(p)
+
+	case Rxattrwalk:
+		fc.Offset, p = gint64(p)
+
+	case Txattrcreate:
+		fc.Fid, p = gint32(p)
+		fc.Name, p = gstr(p)
+		if len(p) < 12 {
+			return nil, &Error{"invalid size", EINVAL}
+		}
+		fc.Offset, p = gint64(p)
+		fc.Flags, p = gint32(p)
+
+	case Treaddir:
+		fc.Fid, p = gint32(p)
+		fc.Offset, p = gint64(p)
+		fc.Count, p = gint32(p)
+
+	case Rreaddir:
+		fc.Count, p = gint32(p)
+		if len(p) < int(fc.Count) {
+			return nil, &Error{"invalid size", EINVAL}
+		}
+		fc.Data = p[0:fc.Count]
+		p = p[fc.Count:]
+
+	case Tfsync:
+		fc.Fid, p = gint32(p)
+		// datasync was added later, older clients don't send it
+		if len(p) >= 4 {
+			fc.Datasync, p = gint32(p)
+		}
+
+	case Tlock:
+		fc.Fid, p = gint32(p)
+		p = glock(p, &fc.Lock, true)
+
+	case Rlock:
+		fc.Status, p = gint8(p)
+
+	case Tgetlock:
+		fc.Fid, p = gint32(p)
+		p = glock(p, &fc.Lock, false)
+
+	case Rgetlock:
+		p = glock(p, &fc.Lock, false)
+
+	case Tlink:
+		fc.Dfid, p = gint32(p)
+		fc.Fid, p = gint32(p)
+		fc.Name, p = gstr(p)
+
+	case Tmkdir:
+		fc.Fid, p = gint32(p)
+		fc.Name, p = gstr(p)
+		if len(p) < 8 {
+			return nil, &Error{"invalid size", EINVAL}
+		}
+		fc.Lmode, p = gint32(p)
+		fc.Ngid, p = gint32(p)
+
+	case Trenameat:
+		fc.Fid, p = gint32(p)
+		fc.Name, p = gstr(p)
+		if len(p) < 6 {
+			return nil, &Error{"invalid size", EINVAL}
+		}
+		fc.Dfid, p = gint32(p)
+		fc.Newname, p = gstr(p)
+
+	case Tunlinkat:
+		fc.Fid, p = gint32(p)
+		fc.Name, p = gstr(p)
+		if len(p) < 4 {
+			return nil, &Error{"invalid size", EINVAL}
+		}
+		fc.Flags, p = gint32(p)
+
+	case Rrename, Rsetattr, Rxattrcreate, Rfsync, Rlink, Rrenameat, Runlinkat:
+	}
+
+	if p == nil {
+		return nil, &Error{"invalid size", EINVAL}
+	}
+
+	return p, nil
+}
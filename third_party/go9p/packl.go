@@ -0,0 +1,362 @@
+// Copyright 2009 The Go9p Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package go9p
+
+// Create a Rlerror message in the specified Fcall.
+func PackRlerror(fc *Fcall, errornum uint32) error {
+	p, err := packCommon(fc, 4, Rlerror) /* ecode[4] */
+	if err != nil {
+		return err
+	}
+
+	fc.Errornum = errornum
+	p = pint32(errornum, p)
+	return nil
+}
+
+// Create a Rstatfs message in the specified Fcall.
+func PackRstatfs(fc *Fcall, st *Statfs) error {
+	p, err := packCommon(fc, statfssz, Rstatfs)
+	if err != nil {
+		return err
+	}
+
+	fc.Statfs = *st
+	p = pstatfs(st, p)
+	return nil
+}
+
+// packRqid creates a message with a qid and an iounit (Rlopen, Rlcreate)
+// or only a qid (Rsymlink, Rmknod, Rmkdir).
+func packRqid(fc *Fcall, id uint8, qid *Qid, iounit uint32, withIounit bool) error {
+	size := 13 /* qid[13] */
+	if withIounit {
+		size += 4 /* iounit[4] */
+	}
+
+	p, err := packCommon(fc, size, id)
+	if err != nil {
+		return err
+	}
+
+	fc.Qid = *qid
+	p = pqid(qid, p)
+	if withIounit {
+		fc.Iounit = iounit
+		p = pint32(iounit, p)
+	}
+
+	return nil
+}
+
+// Create a Rlopen message in the specified Fcall.
+func PackRlopen(fc *Fcall, qid *Qid, iounit uint32) error {
+	return packRqid(fc, Rlopen, qid, iounit, true)
+}
+
+// Create a Rlcreate message in the specified Fcall.
+func PackRlcreate(fc *Fcall, qid *Qid, iounit uint32) error {
+	return packRqid(fc, Rlcreate, qid, iounit, true)
+}
+
+// Create a Rsymlink message in the specified Fcall.
+func PackRsymlink(fc *Fcall, qid *Qid) error { return packRqid(fc, Rsymlink, qid, 0, false) }
+
+// Create a Rmknod message in the specified Fcall.
+func PackRmknod(fc *Fcall, qid *Qid) error { return packRqid(fc, Rmknod, qid, 0, false) }
+
+// Create a Rmkdir message in the specified Fcall.
+func PackRmkdir(fc *Fcall, qid *Qid) error { return packRqid(fc, Rmkdir, qid, 0, false) }
+
+// Create a Rreadlink message in the specified Fcall.
+func PackRreadlink(fc *Fcall, target string) error {
+	p, err := packCommon(fc, 2+len(target), Rreadlink) /* target[s] */
+	if err != nil {
+		return err
+	}
+
+	fc.Target = target
+	p = pstr(target, p)
+	return nil
+}
+
+// Create a Rgetattr message in the specified Fcall.
+func PackRgetattr(fc *Fcall, attr *Lattr) error {
+	p, err := packCommon(fc, lattrsz, Rgetattr)
+	if err != nil {
+		return err
+	}
+
+	fc.Attr = *attr
+	p = plattr(attr, p)
+	return nil
+}
+
+// Create a Rxattrwalk message in the specified Fcall.
+func PackRxattrwalk(fc *Fcall, size uint64) error {
+	p, err := packCommon(fc, 8, Rxattrwalk) /* size[8] */
+	if err != nil {
+		return err
+	}
+
+	fc.Offset = size
+	p = pint64(size, p)
+	return nil
+}
+
+// Initializes the specified Fcall value to contain Rreaddir message.
+// The user should copy the entries to the Data field of the Fcall,
+// and call SetRreadCount with their size.
+func InitRreaddir(fc *Fcall, count uint32) error {
+	if err := InitRread(fc, count); err != nil {
+		return err
+	}
+
+	fc.Type = Rreaddir
+	pint8(Rreaddir, fc.Pkt[4:])
+	return nil
+}
+
+// Create a Rlock message in the specified Fcall.
+func PackRlock(fc *Fcall, status uint8) error {
+	p, err := packCommon(fc, 1, Rlock) /* status[1] */
+	if err != nil {
+		return err
+	}
+
+	fc.Status = status
+	p = pint8(status, p)
+	return nil
+}
+
+// Create a Rgetlock message in the specified Fcall.
+func PackRgetlock(fc *Fcall, lock *Lock) error {
+	p, err := packCommon(fc, locksz(lock, false), Rgetlock)
+	if err != nil {
+		return err
+	}
+
+	fc.Lock = *lock
+	p = plock(lock, p, false)
+	return nil
+}
+
+// Create an empty 9P2000.L response (Rrename, Rsetattr, Rxattrcreate,
+// Rfsync, Rlink, Rrenameat, Runlinkat) in the specified Fcall.
+func PackRempty(fc *Fcall, id uint8) error {
+	_, err := packCommon(fc, 0, id)
+	return err
+}
+
+// Create a Tlopen message in the specified Fcall.
+func PackTlopen(fc *Fcall, fid uint32, flags uint32) error {
+	p, err := packCommon(fc, 8, Tlopen) /* fid[4] flags[4] */
+	if err != nil {
+		return err
+	}
+
+	fc.Fid = fid
+	fc.Flags = flags
+	p = pint32(fid, p)
+	p = pint32(flags, p)
+	return nil
+}
+
+// Create a Tlcreate message in the specified Fcall.
+func PackTlcreate(fc *Fcall, fid uint32, name string, flags uint32, mode uint32, gid uint32) error {
+	size := 4 + 2 + len(name) + 4 + 4 + 4 /* fid[4] name[s] flags[4] mode[4] gid[4] */
+	p, err := packCommon(fc, size, Tlcreate)
+	if err != nil {
+		return err
+	}
+
+	fc.Fid = fid
+	fc.Name = name
+	fc.Flags = flags
+	fc.Lmode = mode
+	fc.Ngid = gid
+	p = pint32(fid, p)
+	p = pstr(name, p)
+	p = pint32(flags, p)
+	p = pint32(mode, p)
+	p = pint32(gid, p)
+	return nil
+}
+
+// Create a Tsymlink message in the specified Fcall.
+func PackTsymlink(fc *Fcall, fid uint32, name string, target string, gid uint32) error {
+	size := 4 + 2 + len(name) + 2 + len(target) + 4 /* fid[4] name[s] symtgt[s] gid[4] */
+	p, err := packCommon(fc, size, Tsymlink)
+	if err != nil {
+		return err
+	}
+
+	fc.Fid = fid
+	fc.Name = name
+	fc.Target = target
+	fc.Ngid = gid
+	p = pint32(fid, p)
+	p = pstr(name, p)
+	p = pstr(target, p)
+	p = pint32(gid, p)
+	return nil
+}
+
+// Create a Tmkdir message in the specified Fcall.
+func PackTmkdir(fc *Fcall, dfid uint32, name string, mode uint32, gid uint32) error {
+	size := 4 + 2 + len(name) + 4 + 4 /* dfid[4] name[s] mode[4] gid[4] */
+	p, err := packCommon(fc, size, Tmkdir)
+	if err != nil {
+		return err
+	}
+
+	fc.Fid = dfid
+	fc.Name = name
+	fc.Lmode = mode
+	fc.Ngid = gid
+	p = pint32(dfid, p)
+	p = pstr(name, p)
+	p = pint32(mode, p)
+	p = pint32(gid, p)
+	return nil
+}
+
+// Create a Treadlink, Tstatfs or Tfsync message in the specified Fcall.
+func PackTfid(fc *Fcall, id uint8, fid uint32) error {
+	p, err := packCommon(fc, 4, id) /* fid[4] */
+	if err != nil {
+		return err
+	}
+
+	fc.Fid = fid
+	p = pint32(fid, p)
+	return nil
+}
+
+// Create a Tgetattr message in the specified Fcall.
+func PackTgetattr(fc *Fcall, fid uint32, mask uint64) error {
+	p, err := packCommon(fc, 12, Tgetattr) /* fid[4] request_mask[8] */
+	if err != nil {
+		return err
+	}
+
+	fc.Fid = fid
+	fc.Mask = mask
+	p = pint32(fid, p)
+	p = pint64(mask, p)
+	return nil
+}
+
+// Create a Tsetattr message in the specified Fcall.
+func PackTsetattr(fc *Fcall, fid uint32, attr *SetAttr) error {
+	p, err := packCommon(fc, 4+setattrsz, Tsetattr) /* fid[4] attr */
+	if err != nil {
+		return err
+	}
+
+	fc.Fid = fid
+	fc.SetAttr = *attr
+	p = pint32(fid, p)
+	p = psetattr(attr, p)
+	return nil
+}
+
+// Create a Treaddir message in the specified Fcall.
+func PackTreaddir(fc *Fcall, fid uint32, offset uint64, count uint32) error {
+	p, err := packCommon(fc, 16, Treaddir) /* fid[4] offset[8] count[4] */
+	if err != nil {
+		return err
+	}
+
+	fc.Fid = fid
+	fc.Offset = offset
+	fc.Count = count
+	p = pint32(fid, p)
+	p = pint64(offset, p)
+	p = pint32(count, p)
+	return nil
+}
+
+// Create a Tlock or a Tgetlock message in the specified Fcall.
+func PackTlock(fc *Fcall, id uint8, fid uint32, lock *Lock) error {
+	withFlags := id == Tlock
+	p, err := packCommon(fc, 4+locksz(lock, withFlags), id) /* fid[4] lock */
+	if err != nil {
+		return err
+	}
+
+	fc.Fid = fid
+	fc.Lock = *lock
+	p = pint32(fid, p)
+	p = plock(lock, p, withFlags)
+	return nil
+}
+
+// Create a Trename message in the specified Fcall.
+func PackTrename(fc *Fcall, fid uint32, dfid uint32, name string) error {
+	p, err := packCommon(fc, 4+4+2+len(name), Trename) /* fid[4] dfid[4] name[s] */
+	if err != nil {
+		return err
+	}
+
+	fc.Fid = fid
+	fc.Dfid = dfid
+	fc.Name = name
+	p = pint32(fid, p)
+	p = pint32(dfid, p)
+	p = pstr(name, p)
+	return nil
+}
+
+// Create a Trenameat message in the specified Fcall.
+func PackTrenameat(fc *Fcall, olddirfid uint32, oldname string, newdirfid uint32, newname string) error {
+	size := 4 + 2 + len(oldname) + 4 + 2 + len(newname) /* olddirfid[4] oldname[s] newdirfid[4] newname[s] */
+	p, err := packCommon(fc, size, Trenameat)
+	if err != nil {
+		return err
+	}
+
+	fc.Fid = olddirfid
+	fc.Name = oldname
+	fc.Dfid = newdirfid
+	fc.Newname = newname
+	p = pint32(olddirfid, p)
+	p = pstr(oldname, p)
+	p = pint32(newdirfid, p)
+	p = pstr(newname, p)
+	return nil
+}
+
+// Create a Tunlinkat message in the specified Fcall.
+func PackTunlinkat(fc *Fcall, dirfid uint32, name string, flags uint32) error {
+	p, err := packCommon(fc, 4+2+len(name)+4, Tunlinkat) /* dirfid[4] name[s] flags[4] */
+	if err != nil {
+		return err
+	}
+
+	fc.Fid = dirfid
+	fc.Name = name
+	fc.Flags = flags
+	p = pint32(dirfid, p)
+	p = pstr(name, p)
+	p = pint32(flags, p)
+	return nil
+}
+
+// Create a Tlink message in the specified Fcall.
+func PackTlink(fc *Fcall, dfid uint32, fid uint32, name string) error {
+	p, err := packCommon(fc, 4+4+2+len(name), Tlink) /* dfid[4] fid[4] name[s] */
+	if err != nil {
+		return err
+	}
+
+	fc.Dfid = dfid
+	fc.Fid = fid
+	fc.Name = name
+	p = pint32(dfid, p)
+	p = pint32(fid, p)
+	p = pstr(name, p)
+	return nil
+}
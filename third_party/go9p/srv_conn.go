@@ -5,6 +5,7 @@
 package go9p
 
 import (
+	"bufio"
 	"fmt"
 	"log"
 	"net"
@@ -15,6 +16,7 @@ func (srv *Srv) NewConn(c net.Conn) {
 	conn.Srv = srv
 	conn.Msize = srv.Msize
 	conn.Dotu = srv.Dotu
+	conn.Dotl = srv.Dotl
 	conn.Debuglevel = srv.Debuglevel
 	conn.conn = c
 	conn.fidpool = make(map[uint32]*SrvFid)
@@ -103,7 +105,7 @@ func (conn *Conn) recv() {
 
 				break
 			}
-			fc, err, fcsize := Unpack(buf, conn.Dotu)
+			fc, err, fcsize := Unpack(buf, conn.Dotu || conn.Dotl)
 			if err != nil {
 				log.Printf("invalid packet : %v %v\n", err, buf)
 				conn.conn.Close()
@@ -169,6 +171,10 @@ func (conn *Conn) recv() {
 }
 
 func (conn *Conn) send() {
+	// the responses are buffered and written together when there are no more
+	// pending responses, to save system calls when many requests are processed
+	// concurrently
+	w := bufio.NewWriterSize(conn.conn, int(conn.Msize))
 	for {
 		select {
 		case <-conn.done:
@@ -191,16 +197,16 @@ func (conn *Conn) send() {
 				}
 			}
 
-			for buf := req.Rc.Pkt; len(buf) > 0; {
-				n, err := conn.conn.Write(buf)
-				if err != nil {
-					/* just close the socket, will get signal on conn.done */
-					log.Println("error while writing")
-					conn.conn.Close()
-					break
-				}
+			_, err := w.Write(req.Rc.Pkt)
+			if err == nil && len(conn.reqout) == 0 {
+				err = w.Flush()
+			}
 
-				buf = buf[n:]
+			if err != nil {
+				/* just close the socket, will get signal on conn.done */
+				log.Println("error while writing")
+				conn.conn.Close()
+				w.Reset(conn.conn)
 			}
 
 			select {
@@ -20,9 +20,12 @@ func (srv *Srv) version(req *SrvReq) {
 	}
 
 	conn.Dotu = tc.Version == "9P2000.u" && srv.Dotu
+	conn.Dotl = tc.Version == "9P2000.L" && srv.Dotl
 	ver := "9P2000"
 	if conn.Dotu {
 		ver = "9P2000.u"
+	} else if conn.Dotl {
+		ver = "9P2000.L"
 	}
 
 	/* make sure that the responses of all current requests will be ignored */
@@ -58,7 +61,7 @@ func (srv *Srv) auth(req *SrvReq) {
 	}
 
 	var user User = nil
-	if tc.Unamenum != NOUID || conn.Dotu {
+	if tc.Unamenum != NOUID || conn.Dotu || conn.Dotl {
 		user = srv.Upool.Uid2User(int(tc.Unamenum))
 	} else if tc.Uname != "" {
 		user = srv.Upool.Uname2User(tc.Uname)
@@ -115,7 +118,7 @@ func (srv *Srv) attach(req *SrvReq) {
 	}
 
 	var user User = nil
-	if tc.Unamenum != NOUID || conn.Dotu {
+	if tc.Unamenum != NOUID || conn.Dotu || conn.Dotl {
 		user = srv.Upool.Uid2User(int(tc.Unamenum))
 	} else if tc.Uname != "" {
 		user = srv.Upool.Uname2User(tc.Uname)
@@ -0,0 +1,213 @@
+// Copyright 2009 The Go9p Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package go9p
+
+type fidLock int
+
+const (
+	fidUnlocked fidLock = iota
+	fidShared
+	fidExclusive
+)
+
+var Enotsup error = &Error{"operation not supported", EOPNOTSUPP}
+
+// Requests on different fids are processed concurrently. The requests on
+// the same fid that only use it, such as reads and writes of an opened file,
+// are processed concurrently too, while the requests changing its state,
+// such as opening it or reading a directory, are serialized with them.
+func (req *SrvReq) sharesFid() bool {
+	tc := req.Tc
+	switch tc.Type {
+	case Tread:
+		return req.Fid.Type&QTDIR == 0
+
+	case Twalk:
+		return tc.Fid != tc.Newfid
+
+	case Twrite, Tstat, Tgetattr, Tsetattr, Tstatfs, Treadlink, Tfsync, Tlock, Tgetlock,
+		Txattrwalk, Tmkdir, Tsymlink, Tmknod, Tlink, Tunlinkat, Trenameat:
+		return true
+	}
+
+	return false
+}
+
+// lockFid locks the fid of the request until it is responded
+func (req *SrvReq) lockFid() {
+	if req.sharesFid() {
+		req.Fid.oplock.RLock()
+		req.fidlock = fidShared
+	} else {
+		req.Fid.oplock.Lock()
+		req.fidlock = fidExclusive
+	}
+}
+
+func (req *SrvReq) unlockFid() {
+	switch req.fidlock {
+	case fidShared:
+		req.Fid.oplock.RUnlock()
+	case fidExclusive:
+		req.Fid.oplock.Unlock()
+	}
+
+	req.fidlock = fidUnlocked
+}
+
+// processl processes the 9P2000.L requests
+func (srv *Srv) processl(req *SrvReq) {
+	tc := req.Tc
+	ops := (srv.ops).(LSrvReqOps)
+	switch tc.Type {
+	default:
+		req.RespondError(&Error{"unknown message type", EINVAL})
+
+	case Tlopen:
+		srv.lopen(req)
+
+	case Tlcreate:
+		srv.lcreate(req)
+
+	case Treaddir:
+		srv.readdir(req)
+
+	case Tgetattr:
+		ops.Getattr(req)
+
+	case Tsetattr:
+		ops.Setattr(req)
+
+	case Tstatfs:
+		ops.Statfs(req)
+
+	case Treadlink:
+		ops.Readlink(req)
+
+	case Tfsync:
+		ops.Fsync(req)
+
+	case Tlock:
+		ops.Lock(req)
+
+	case Tgetlock:
+		ops.Getlock(req)
+
+	case Tmkdir, Tsymlink, Tmknod, Tunlinkat, Trenameat, Tlink, Trename:
+		srv.dirop(req)
+
+	case Txattrwalk, Txattrcreate:
+		req.RespondError(Enotsup)
+	}
+}
+
+func (srv *Srv) lopen(req *SrvReq) {
+	fid := req.Fid
+	tc := req.Tc
+	if fid.opened {
+		req.RespondError(Eopen)
+		return
+	}
+
+	/* the access mode of the open flags has the same values as the 9P2000 modes */
+	fid.Omode = uint8(tc.Flags & 3)
+	if (fid.Type&QTDIR) != 0 && fid.Omode != OREAD {
+		req.RespondError(Eperm)
+		return
+	}
+
+	(srv.ops).(LSrvReqOps).Lopen(req)
+}
+
+func (srv *Srv) lopenPost(req *SrvReq) {
+	if req.Fid != nil {
+		req.Fid.opened = req.Rc != nil && req.Rc.Type == Rlopen
+	}
+}
+
+func (srv *Srv) lcreate(req *SrvReq) {
+	fid := req.Fid
+	tc := req.Tc
+	if fid.opened {
+		req.RespondError(Eopen)
+		return
+	}
+
+	if (fid.Type & QTDIR) == 0 {
+		req.RespondError(Enotdir)
+		return
+	}
+
+	fid.Omode = uint8(tc.Flags & 3)
+	(srv.ops).(LSrvReqOps).Lcreate(req)
+}
+
+func (srv *Srv) lcreatePost(req *SrvReq) {
+	if req.Rc != nil && req.Rc.Type == Rlcreate && req.Fid != nil {
+		req.Fid.Type = req.Rc.Qid.Type
+		req.Fid.opened = true
+	}
+}
+
+func (srv *Srv) readdir(req *SrvReq) {
+	fid := req.Fid
+	if (fid.Type & QTDIR) == 0 {
+		req.RespondError(Enotdir)
+		return
+	}
+
+	if !fid.opened {
+		req.RespondError(Ebaduse)
+		return
+	}
+
+	if req.Tc.Count+IOHDRSZ > req.Conn.Msize {
+		req.RespondError(Etoolarge)
+		return
+	}
+
+	(srv.ops).(LSrvReqOps).Readdir(req)
+}
+
+// dirop processes the requests changing the entries of a directory
+func (srv *Srv) dirop(req *SrvReq) {
+	tc := req.Tc
+	ops := (srv.ops).(LSrvReqOps)
+	if tc.Type == Trename || tc.Type == Trenameat || tc.Type == Tlink {
+		req.Dfid = req.Conn.FidGet(tc.Dfid)
+		if req.Dfid == nil {
+			req.RespondError(Eunknownfid)
+			return
+		}
+
+		if (req.Dfid.Type & QTDIR) == 0 {
+			req.RespondError(Enotdir)
+			return
+		}
+	}
+
+	/* the fid of Trename and Tlink is the file, and dfid the directory */
+	if tc.Type != Trename && tc.Type != Tlink && (req.Fid.Type&QTDIR) == 0 {
+		req.RespondError(Enotdir)
+		return
+	}
+
+	switch tc.Type {
+	case Tmkdir:
+		ops.Mkdir(req)
+	case Tsymlink:
+		ops.Symlink(req)
+	case Tmknod:
+		ops.Mknod(req)
+	case Tunlinkat:
+		ops.Unlinkat(req)
+	case Trenameat:
+		ops.Renameat(req)
+	case Tlink:
+		ops.Link(req)
+	case Trename:
+		ops.Rename(req)
+	}
+}
@@ -23,6 +23,17 @@ type SrvReqOps interface {
 
 // Respond to the request with Rerror message
 func (req *SrvReq) RespondError(err interface{}) {
+	if req.Conn.Dotl {
+		errornum := uint32(EIO)
+		if e, ok := err.(*Error); ok && e.Errornum != 0 {
+			errornum = e.Errornum
+		}
+
+		PackRlerror(req.Rc, errornum)
+		req.Respond()
+		return
+	}
+
 	switch e := err.(type) {
 	case *Error:
 		PackRerror(req.Rc, e.Error(), uint32(e.Errornum), req.Conn.Dotu)
@@ -164,3 +175,91 @@ func (req *SrvReq) RespondRwstat() {
 		req.Respond()
 	}
 }
+
+// 9P2000.L request operations. This interface should be implemented by the
+// file servers supporting 9P2000.L, in addition to SrvReqOps. The reads and
+// writes of the files opened by Lopen and Lcreate are done by the Read and
+// Write operations.
+type LSrvReqOps interface {
+	Lopen(*SrvReq)
+	Lcreate(*SrvReq)
+	Getattr(*SrvReq)
+	Setattr(*SrvReq)
+	Readdir(*SrvReq)
+	Statfs(*SrvReq)
+	Readlink(*SrvReq)
+	Fsync(*SrvReq)
+	Lock(*SrvReq)
+	Getlock(*SrvReq)
+	Mkdir(*SrvReq)
+	Symlink(*SrvReq)
+	Mknod(*SrvReq)
+	Unlinkat(*SrvReq)
+	Renameat(*SrvReq)
+	Rename(*SrvReq)
+	Link(*SrvReq)
+}
+
+func (req *SrvReq) respondPacked(err error) {
+	if err != nil {
+		req.RespondError(err)
+	} else {
+		req.Respond()
+	}
+}
+
+// Respond to the request with Rlopen message
+func (req *SrvReq) RespondRlopen(qid *Qid, iounit uint32) {
+	req.respondPacked(PackRlopen(req.Rc, qid, iounit))
+}
+
+// Respond to the request with Rlcreate message
+func (req *SrvReq) RespondRlcreate(qid *Qid, iounit uint32) {
+	req.respondPacked(PackRlcreate(req.Rc, qid, iounit))
+}
+
+// Respond to the request with Rgetattr message
+func (req *SrvReq) RespondRgetattr(attr *Lattr) {
+	req.respondPacked(PackRgetattr(req.Rc, attr))
+}
+
+// Respond to the request with Rstatfs message
+func (req *SrvReq) RespondRstatfs(st *Statfs) {
+	req.respondPacked(PackRstatfs(req.Rc, st))
+}
+
+// Respond to the request with Rreadlink message
+func (req *SrvReq) RespondRreadlink(target string) {
+	req.respondPacked(PackRreadlink(req.Rc, target))
+}
+
+// Respond to the request with Rlock message
+func (req *SrvReq) RespondRlock(status uint8) {
+	req.respondPacked(PackRlock(req.Rc, status))
+}
+
+// Respond to the request with Rgetlock message
+func (req *SrvReq) RespondRgetlock(lock *Lock) {
+	req.respondPacked(PackRgetlock(req.Rc, lock))
+}
+
+// Respond to the request with Rmkdir message
+func (req *SrvReq) RespondRmkdir(qid *Qid) {
+	req.respondPacked(PackRmkdir(req.Rc, qid))
+}
+
+// Respond to the request with Rsymlink message
+func (req *SrvReq) RespondRsymlink(qid *Qid) {
+	req.respondPacked(PackRsymlink(req.Rc, qid))
+}
+
+// Respond to the request with Rmknod message
+func (req *SrvReq) RespondRmknod(qid *Qid) {
+	req.respondPacked(PackRmknod(req.Rc, qid))
+}
+
+// Respond to the request with the empty response of its T-message (Rsetattr,
+// Rfsync, Runlinkat, Rrenameat, Rrename or Rlink)
+func (req *SrvReq) RespondRempty() {
+	req.respondPacked(PackRempty(req.Rc, req.Tc.Type+1))
+}
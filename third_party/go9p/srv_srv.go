@@ -119,6 +119,7 @@ type Srv struct {
 	Id         string // Used for debugging and stats
 	Msize      uint32 // Maximum size of the 9P2000 messages supported by the server
 	Dotu       bool   // If true, the server supports the 9P2000.u extension
+	Dotl       bool   // If true, the server supports the 9P2000.L extension, ops must implement LSrvReqOps
 	Debuglevel int    // debug level
 	Upool      Users  // Interface for finding users and groups known to the file server
 	Maxpend    int    // Maximum pending outgoing requests
@@ -134,6 +135,7 @@ type Conn struct {
 	Srv        *Srv
 	Msize      uint32 // maximum size of 9P2000 messages for the connection
 	Dotu       bool   // if true, both the client and the server speak 9P2000.u
+	Dotl       bool   // if true, both the client and the server speak 9P2000.L
 	Id         string // used for debugging and stats
 	Debuglevel int
 
@@ -162,6 +164,7 @@ type Conn struct {
 // when a SrvFid is destroyed.
 type SrvFid struct {
 	sync.Mutex
+	oplock    sync.RWMutex // serializes the requests changing the state of the fid
 	fid       uint32
 	refcount  int
 	opened    bool        // True if the SrvFid is opened
@@ -186,9 +189,11 @@ type SrvReq struct {
 	Fid    *SrvFid // The SrvFid value for all messages that contain fid[4]
 	Afid   *SrvFid // The SrvFid value for the messages that contain afid[4] (Tauth and Tattach)
 	Newfid *SrvFid // The SrvFid value for the messages that contain newfid[4] (Twalk)
+	Dfid   *SrvFid // The SrvFid value for the messages that contain dfid[4] (Trename, Trenameat, Tlink)
 	Conn   *Conn   // Connection that the request belongs to
 
 	status     reqStatus
+	fidlock    fidLock
 	flushreq   *SrvReq
 	prev, next *SrvReq
 }
@@ -217,6 +222,14 @@ func (srv *Srv) Start(ops interface{}) bool {
 		srv.Log = NewLogger(1024)
 	}
 
+	if _, ok := (ops).(LSrvReqOps); !ok {
+		srv.Dotl = false
+	}
+
+	if srv.Maxpend == 0 {
+		srv.Maxpend = 64
+	}
+
 	if sop, ok := (interface{}(srv)).(StatsOps); ok {
 		sop.statsRegister()
 	}
@@ -238,6 +251,7 @@ func (req *SrvReq) process() {
 
 	if flushed {
 		req.Respond()
+		return
 	}
 
 	if rop, ok := (req.Conn.Srv.ops).(SrvReqProcessOps); ok {
@@ -272,6 +286,18 @@ func (req *SrvReq) Process() {
 			req.RespondError(Eunknownfid)
 			return
 		}
+
+		req.lockFid()
+	}
+
+	if IsDotl(tc.Type) {
+		if !conn.Dotl {
+			req.RespondError(&Error{"unknown message type", EINVAL})
+			return
+		}
+
+		srv.processl(req)
+		return
 	}
 
 	switch req.Tc.Type {
@@ -354,13 +380,25 @@ func (req *SrvReq) PostProcess() {
 
 	case Tremove:
 		srv.removePost(req)
+
+	case Tlopen:
+		srv.lopenPost(req)
+
+	case Tlcreate:
+		srv.lcreatePost(req)
 	}
 
+	req.unlockFid()
 	if req.Fid != nil {
 		req.Fid.DecRef()
 		req.Fid = nil
 	}
 
+	if req.Dfid != nil {
+		req.Dfid.DecRef()
+		req.Dfid = nil
+	}
+
 	if req.Afid != nil {
 		req.Afid.DecRef()
 		req.Afid = nil
@@ -5,6 +5,7 @@
 package go9p
 
 import (
+	"errors"
 	"io"
 	"log"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"path"
 	"sort"
 	"strconv"
+	"sync"
 	"syscall"
 )
 
@@ -22,36 +24,65 @@ type ufsFid struct {
 	direntends []int
 	dirents    []byte
 	diroffset  uint64
-	st         os.FileInfo
+	ldirents   []Dirent // entries of the directory read by Treaddir
 }
 
 type Ufs struct {
 	Srv
 	Root string
+
+	// Owner is reported as the owner of all the files by 9P2000.L when set,
+	// so that the files of the host belong to a user of the client. The
+	// changes of owner are ignored then.
+	Owner *FileOwner
+
+	once  sync.Once
+	locks *lockTable
 }
 
+// FileOwner is the numeric owner of a file
+type FileOwner struct {
+	Uid uint32
+	Gid uint32
+}
+
+// toError converts an error of the host to an Error, with the Linux
+// error number of the client
 func toError(err error) *Error {
 	var ecode uint32
 
 	ename := err.Error()
-	if e, ok := err.(syscall.Errno); ok {
-		ecode = uint32(e)
-	} else {
+	var errno syscall.Errno
+	switch {
+	case errors.As(err, &errno):
+		ecode = linuxErrno(errno)
+	case os.IsNotExist(err):
+		ecode = ENOENT
+	case os.IsExist(err):
+		ecode = EEXIST
+	case os.IsPermission(err):
+		ecode = EACCES
+	default:
 		ecode = EIO
 	}
 
 	return &Error{ename, ecode}
 }
 
-func (fid *ufsFid) stat() *Error {
-	var err error
+func (ufs *Ufs) init() {
+	ufs.once.Do(func() {
+		ufs.locks = newLockTable()
+	})
+}
 
-	fid.st, err = os.Lstat(fid.path)
+// lstat returns the attributes of a file
+func (ufs *Ufs) lstat(path string) (os.FileInfo, *Error) {
+	st, err := os.Lstat(path)
 	if err != nil {
-		return toError(err)
+		return nil, toError(err)
 	}
 
-	return nil
+	return st, nil
 }
 
 func omode2uflags(mode uint8) int {
@@ -142,10 +173,13 @@ func (*Ufs) ConnOpened(conn *Conn) {
 	}
 }
 
-func (*Ufs) ConnClosed(conn *Conn) {
+func (ufs *Ufs) ConnClosed(conn *Conn) {
 	if conn.Srv.Debuglevel > 0 {
 		log.Println("disconnected")
 	}
+
+	ufs.init()
+	ufs.locks.releaseConn(conn)
 }
 
 func (*Ufs) FidDestroy(sfid *SrvFid) {
@@ -175,23 +209,23 @@ func (ufs *Ufs) Attach(req *SrvReq) {
 	fid.path = path.Join(ufs.Root, tc.Aname)
 
 	req.Fid.Aux = fid
-	err := fid.stat()
+	st, err := ufs.lstat(fid.path)
 	if err != nil {
 		req.RespondError(err)
 		return
 	}
 
-	qid := dir2Qid(fid.st)
+	qid := dir2Qid(st)
 	req.RespondRattach(qid)
 }
 
 func (*Ufs) Flush(req *SrvReq) {}
 
-func (*Ufs) Walk(req *SrvReq) {
+func (ufs *Ufs) Walk(req *SrvReq) {
 	fid := req.Fid.Aux.(*ufsFid)
 	tc := req.Tc
 
-	err := fid.stat()
+	_, err := ufs.lstat(fid.path)
 	if err != nil {
 		req.RespondError(err)
 		return
@@ -207,10 +241,14 @@ func (*Ufs) Walk(req *SrvReq) {
 	i := 0
 	for ; i < len(tc.Wname); i++ {
 		p := path + "/" + tc.Wname[i]
-		st, err := os.Lstat(p)
+		st, err := ufs.lstat(p)
 		if err != nil {
 			if i == 0 {
-				req.RespondError(Enoent)
+				if req.Conn.Dotl {
+					req.RespondError(err)
+				} else {
+					req.RespondError(Enoent)
+				}
 				return
 			}
 
@@ -225,10 +263,10 @@ func (*Ufs) Walk(req *SrvReq) {
 	req.RespondRwalk(wqids[0:i])
 }
 
-func (*Ufs) Open(req *SrvReq) {
+func (ufs *Ufs) Open(req *SrvReq) {
 	fid := req.Fid.Aux.(*ufsFid)
 	tc := req.Tc
-	err := fid.stat()
+	st, err := ufs.lstat(fid.path)
 	if err != nil {
 		req.RespondError(err)
 		return
@@ -241,13 +279,13 @@ func (*Ufs) Open(req *SrvReq) {
 		return
 	}
 
-	req.RespondRopen(dir2Qid(fid.st), 0)
+	req.RespondRopen(dir2Qid(st), 0)
 }
 
-func (*Ufs) Create(req *SrvReq) {
+func (ufs *Ufs) Create(req *SrvReq) {
 	fid := req.Fid.Aux.(*ufsFid)
 	tc := req.Tc
-	err := fid.stat()
+	_, err := ufs.lstat(fid.path)
 	if err != nil {
 		req.RespondError(err)
 		return
@@ -307,29 +345,24 @@ func (*Ufs) Create(req *SrvReq) {
 
 	fid.path = path
 	fid.file = file
-	err = fid.stat()
+	st, err := ufs.lstat(fid.path)
 	if err != nil {
 		req.RespondError(err)
 		return
 	}
 
-	req.RespondRcreate(dir2Qid(fid.st), 0)
+	req.RespondRcreate(dir2Qid(st), 0)
 }
 
-func (*Ufs) Read(req *SrvReq) {
+func (ufs *Ufs) Read(req *SrvReq) {
 	fid := req.Fid.Aux.(*ufsFid)
 	tc := req.Tc
 	rc := req.Rc
-	err := fid.stat()
-	if err != nil {
-		req.RespondError(err)
-		return
-	}
 
 	InitRread(rc, tc.Count)
 	var count int
 	var e error
-	if fid.st.IsDir() {
+	if req.Fid.Type&QTDIR != 0 {
 		if tc.Offset == 0 {
 			var e error
 			// If we got here, it was open. Can't really seek
@@ -401,14 +434,9 @@ func (*Ufs) Read(req *SrvReq) {
 	req.Respond()
 }
 
-func (*Ufs) Write(req *SrvReq) {
+func (ufs *Ufs) Write(req *SrvReq) {
 	fid := req.Fid.Aux.(*ufsFid)
 	tc := req.Tc
-	err := fid.stat()
-	if err != nil {
-		req.RespondError(err)
-		return
-	}
 
 	n, e := fid.file.WriteAt(tc.Data, int64(tc.Offset))
 	if e != nil {
@@ -421,9 +449,9 @@ func (*Ufs) Write(req *SrvReq) {
 
 func (*Ufs) Clunk(req *SrvReq) { req.RespondRclunk() }
 
-func (*Ufs) Remove(req *SrvReq) {
+func (ufs *Ufs) Remove(req *SrvReq) {
 	fid := req.Fid.Aux.(*ufsFid)
-	err := fid.stat()
+	_, err := ufs.lstat(fid.path)
 	if err != nil {
 		req.RespondError(err)
 		return
@@ -438,15 +466,15 @@ func (*Ufs) Remove(req *SrvReq) {
 	req.RespondRremove()
 }
 
-func (*Ufs) Stat(req *SrvReq) {
+func (ufs *Ufs) Stat(req *SrvReq) {
 	fid := req.Fid.Aux.(*ufsFid)
-	err := fid.stat()
+	fst, err := ufs.lstat(fid.path)
 	if err != nil {
 		req.RespondError(err)
 		return
 	}
 
-	st, derr := dir2Dir(fid.path, fid.st, req.Conn.Dotu, req.Conn.Srv.Upool)
+	st, derr := dir2Dir(fid.path, fst, req.Conn.Dotu, req.Conn.Srv.Upool)
 	if st == nil {
 		req.RespondError(derr)
 		return
@@ -11,12 +11,24 @@ import (
 	"k8s.io/minikube/third_party/go9p"
 )
 
+// Options configures the server
+type Options struct {
+	// Owner is reported as the owner of all the files to the 9P2000.L clients, when set
+	Owner *go9p.FileOwner
+}
+
 func StartServer(addrVal string, debugVal int, rootVal string) {
+	StartServerWithOptions(addrVal, debugVal, rootVal, Options{})
+}
+
+func StartServerWithOptions(addrVal string, debugVal int, rootVal string, opts Options) {
 	ufs := new(go9p.Ufs)
 	ufs.Dotu = true
+	ufs.Dotl = true
 	ufs.Id = "ufs"
 	ufs.Root = rootVal
 	ufs.Debuglevel = debugVal
+	ufs.Owner = opts.Owner
 	ufs.Start(ufs)
 
 	fmt.Print("ufs starting\n")
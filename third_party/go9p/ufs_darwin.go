@@ -123,7 +123,7 @@ func (dir *ufsDir) dotu(path string, d os.FileInfo, upool Users, sysMode *syscal
 
 func (u *Ufs) Wstat(req *SrvReq) {
 	fid := req.Fid.Aux.(*ufsFid)
-	err := fid.stat()
+	_, err := u.lstat(fid.path)
 	if err != nil {
 		req.RespondError(err)
 		return
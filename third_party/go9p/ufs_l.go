@@ -0,0 +1,554 @@
+// Copyright 2009 The go9p Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package go9p
+
+import (
+	"os"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// types of the directory entries, as in the d_type field of struct dirent
+const (
+	dtUnknown = 0
+	dtFifo    = 1
+	dtChr     = 2
+	dtDir     = 4
+	dtBlk     = 6
+	dtReg     = 8
+	dtLnk     = 10
+	dtSock    = 12
+)
+
+func lflags2uflags(flags uint32) int {
+	ret := omode2uflags(uint8(flags & 3))
+	if flags&LOTRUNC != 0 {
+		ret |= os.O_TRUNC
+	}
+
+	if flags&LOEXCL != 0 {
+		ret |= os.O_EXCL
+	}
+
+	// the client sends the offsets of the appends, and WriteAt
+	// fails on the files opened with O_APPEND
+	return ret
+}
+
+func unixMode2FileMode(mode uint32) os.FileMode {
+	ret := os.FileMode(mode & 0777)
+	if mode&syscall.S_ISUID != 0 {
+		ret |= os.ModeSetuid
+	}
+
+	if mode&syscall.S_ISGID != 0 {
+		ret |= os.ModeSetgid
+	}
+
+	if mode&syscall.S_ISVTX != 0 {
+		ret |= os.ModeSticky
+	}
+
+	return ret
+}
+
+func dirent2Type(d os.FileInfo) uint8 {
+	switch mode := d.Mode(); {
+	case mode.IsDir():
+		return dtDir
+	case mode.IsRegular():
+		return dtReg
+	case mode&os.ModeSymlink != 0:
+		return dtLnk
+	case mode&os.ModeNamedPipe != 0:
+		return dtFifo
+	case mode&os.ModeSocket != 0:
+		return dtSock
+	case mode&os.ModeCharDevice != 0:
+		return dtChr
+	case mode&os.ModeDevice != 0:
+		return dtBlk
+	}
+
+	return dtUnknown
+}
+
+// child returns the path of an entry of the directory of the fid, the name
+// can't leave the directory
+func (fid *ufsFid) child(name string) (string, *Error) {
+	if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+		return "", &Error{"invalid file name", EINVAL}
+	}
+
+	return fid.path + "/" + name, nil
+}
+
+// lattr returns the 9P2000.L attributes of a file
+func (ufs *Ufs) lattr(st os.FileInfo) *Lattr {
+	sys := st.Sys().(*syscall.Stat_t)
+	a := &Lattr{
+		Valid:   GetattrBasic,
+		Qid:     *dir2Qid(st),
+		Mode:    uint32(sys.Mode),
+		Uid:     sys.Uid,
+		Gid:     sys.Gid,
+		Nlink:   uint64(sys.Nlink),
+		Rdev:    uint64(sys.Rdev),
+		Size:    uint64(sys.Size),
+		Blksize: uint64(sys.Blksize),
+		Blocks:  uint64(sys.Blocks),
+	}
+	statTimes(a, sys)
+	if ufs.Owner != nil {
+		a.Uid = ufs.Owner.Uid
+		a.Gid = ufs.Owner.Gid
+	}
+
+	return a
+}
+
+// created returns the qid of a file created by a request
+func (ufs *Ufs) created(path string) (*Qid, *Error) {
+	st, err := ufs.lstat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return dir2Qid(st), nil
+}
+
+func (ufs *Ufs) Lopen(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	tc := req.Tc
+	st, err := ufs.lstat(fid.path)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	var e error
+	fid.file, e = os.OpenFile(fid.path, lflags2uflags(tc.Flags), 0)
+	if e != nil {
+		req.RespondError(toError(e))
+		return
+	}
+
+	req.RespondRlopen(dir2Qid(st), 0)
+}
+
+func (ufs *Ufs) Lcreate(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	tc := req.Tc
+	path, err := fid.child(tc.Name)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	file, e := os.OpenFile(path, lflags2uflags(tc.Flags)|os.O_CREATE, unixMode2FileMode(tc.Lmode))
+	if e != nil {
+		req.RespondError(toError(e))
+		return
+	}
+
+	qid, err := ufs.created(path)
+	if err != nil {
+		file.Close()
+		req.RespondError(err)
+		return
+	}
+
+	fid.path = path
+	fid.file = file
+	req.RespondRlcreate(qid, 0)
+}
+
+func (ufs *Ufs) Getattr(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	st, err := ufs.lstat(fid.path)
+	if err != nil && fid.file != nil {
+		// the file can be removed while it is opened
+		if fst, e := fid.file.Stat(); e == nil {
+			st, err = fst, nil
+		}
+	}
+
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	req.RespondRgetattr(ufs.lattr(st))
+}
+
+func (ufs *Ufs) Setattr(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	a := &req.Tc.SetAttr
+
+	if a.Valid&SetattrMode != 0 {
+		if e := os.Chmod(fid.path, unixMode2FileMode(a.Mode)); e != nil {
+			req.RespondError(toError(e))
+			return
+		}
+	}
+
+	if a.Valid&(SetattrUid|SetattrGid) != 0 && ufs.Owner == nil {
+		uid, gid := -1, -1
+		if a.Valid&SetattrUid != 0 {
+			uid = int(a.Uid)
+		}
+
+		if a.Valid&SetattrGid != 0 {
+			gid = int(a.Gid)
+		}
+
+		if e := os.Lchown(fid.path, uid, gid); e != nil {
+			req.RespondError(toError(e))
+			return
+		}
+	}
+
+	if a.Valid&SetattrSize != 0 {
+		var e error
+		if fid.file != nil && req.Fid.Omode&3 != OREAD {
+			e = fid.file.Truncate(int64(a.Size))
+		} else {
+			e = os.Truncate(fid.path, int64(a.Size))
+		}
+
+		if e != nil {
+			req.RespondError(toError(e))
+			return
+		}
+	}
+
+	if a.Valid&(SetattrAtime|SetattrMtime) != 0 {
+		st, e := os.Stat(fid.path)
+		if e != nil {
+			req.RespondError(toError(e))
+			return
+		}
+
+		now := time.Now()
+		at, mt := atime(st.Sys().(*syscall.Stat_t)), st.ModTime()
+		if a.Valid&SetattrAtime != 0 {
+			at = now
+			if a.Valid&SetattrAtimeSet != 0 {
+				at = time.Unix(int64(a.Atime.Sec), int64(a.Atime.Nsec))
+			}
+		}
+
+		if a.Valid&SetattrMtime != 0 {
+			mt = now
+			if a.Valid&SetattrMtimeSet != 0 {
+				mt = time.Unix(int64(a.Mtime.Sec), int64(a.Mtime.Nsec))
+			}
+		}
+
+		if e := os.Chtimes(fid.path, at, mt); e != nil {
+			req.RespondError(toError(e))
+			return
+		}
+	}
+
+	req.RespondRempty()
+}
+
+// readDirents returns the entries of a directory, with the offset of each
+// entry being the index of the next one.
+func (ufs *Ufs) readDirents(dir string) ([]Dirent, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries, err := f.ReadDir(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	dst, err := os.Lstat(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := dst
+	if path.Clean(dir) != path.Clean(ufs.Root) {
+		if pst, err := os.Lstat(path.Dir(dir)); err == nil {
+			parent = pst
+		}
+	}
+
+	dirents := []Dirent{
+		{Qid: *dir2Qid(dst), Type: dtDir, Name: "."},
+		{Qid: *dir2Qid(parent), Type: dtDir, Name: ".."},
+	}
+	for _, e := range entries {
+		st, err := e.Info()
+		if err != nil {
+			// removed since the directory was read
+			continue
+		}
+
+		dirents = append(dirents, Dirent{Qid: *dir2Qid(st), Type: dirent2Type(st), Name: e.Name()})
+	}
+
+	for i := range dirents {
+		dirents[i].Offset = uint64(i + 1)
+	}
+
+	return dirents, nil
+}
+
+func (ufs *Ufs) Readdir(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	tc := req.Tc
+	rc := req.Rc
+	ufs.init()
+	if tc.Offset == 0 || fid.ldirents == nil {
+		dirents, e := ufs.readDirents(fid.path)
+		if e != nil {
+			req.RespondError(toError(e))
+			return
+		}
+
+		fid.ldirents = dirents
+	}
+
+	InitRreaddir(rc, tc.Count)
+	buf := rc.Data[0:0:len(rc.Data)]
+	for i := tc.Offset; i < uint64(len(fid.ldirents)); i++ {
+		b := PackDirent(&fid.ldirents[i], buf)
+		if b == nil {
+			if len(buf) == 0 {
+				req.RespondError(&Error{"too small read size for dir entry", EINVAL})
+				return
+			}
+
+			break
+		}
+
+		buf = b
+	}
+
+	SetRreadCount(rc, uint32(len(buf)))
+	req.Respond()
+}
+
+func (ufs *Ufs) Statfs(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	st, e := statfs(fid.path)
+	if e != nil {
+		req.RespondError(toError(e))
+		return
+	}
+
+	req.RespondRstatfs(st)
+}
+
+func (ufs *Ufs) Readlink(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	target, e := os.Readlink(fid.path)
+	if e != nil {
+		req.RespondError(toError(e))
+		return
+	}
+
+	req.RespondRreadlink(target)
+}
+
+func (ufs *Ufs) Fsync(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	if fid.file != nil {
+		if e := fid.file.Sync(); e != nil {
+			req.RespondError(toError(e))
+			return
+		}
+	}
+
+	req.RespondRempty()
+}
+
+func (ufs *Ufs) Lock(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	l := &req.Tc.Lock
+	ufs.init()
+	status := ufs.locks.lock(fid.path, lockOwner{req.Conn, l.ClientId, l.ProcId}, l)
+	req.RespondRlock(status)
+}
+
+func (ufs *Ufs) Getlock(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	l := &req.Tc.Lock
+	ufs.init()
+	req.RespondRgetlock(ufs.locks.getlock(fid.path, lockOwner{req.Conn, l.ClientId, l.ProcId}, l))
+}
+
+func (ufs *Ufs) Mkdir(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	tc := req.Tc
+	path, err := fid.child(tc.Name)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	if e := os.Mkdir(path, unixMode2FileMode(tc.Lmode)); e != nil {
+		req.RespondError(toError(e))
+		return
+	}
+
+	qid, err := ufs.created(path)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	req.RespondRmkdir(qid)
+}
+
+func (ufs *Ufs) Symlink(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	tc := req.Tc
+	path, err := fid.child(tc.Name)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	if e := os.Symlink(tc.Target, path); e != nil {
+		req.RespondError(toError(e))
+		return
+	}
+
+	qid, err := ufs.created(path)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	req.RespondRsymlink(qid)
+}
+
+func (ufs *Ufs) Mknod(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	tc := req.Tc
+	path, err := fid.child(tc.Name)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	if e := mknod(path, tc.Lmode, tc.Major, tc.Minor); e != nil {
+		req.RespondError(toError(e))
+		return
+	}
+
+	qid, err := ufs.created(path)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	req.RespondRmknod(qid)
+}
+
+func (ufs *Ufs) Unlinkat(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	tc := req.Tc
+	path, err := fid.child(tc.Name)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	var e error
+	if tc.Flags&AtRemovedir != 0 {
+		e = syscall.Rmdir(path)
+	} else {
+		e = syscall.Unlink(path)
+	}
+
+	if e != nil {
+		req.RespondError(toError(e))
+		return
+	}
+
+	req.RespondRempty()
+}
+
+func (ufs *Ufs) Renameat(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	dfid := req.Dfid.Aux.(*ufsFid)
+	tc := req.Tc
+	oldpath, err := fid.child(tc.Name)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	newpath, err := dfid.child(tc.Newname)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	if e := ufs.rename(oldpath, newpath); e != nil {
+		req.RespondError(e)
+		return
+	}
+
+	req.RespondRempty()
+}
+
+func (ufs *Ufs) Rename(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	dfid := req.Dfid.Aux.(*ufsFid)
+	newpath, err := dfid.child(req.Tc.Name)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	if e := ufs.rename(fid.path, newpath); e != nil {
+		req.RespondError(e)
+		return
+	}
+
+	fid.path = newpath
+	req.RespondRempty()
+}
+
+func (ufs *Ufs) rename(oldpath, newpath string) *Error {
+	e := os.Rename(oldpath, newpath)
+	if e != nil {
+		return toError(e)
+	}
+
+	return nil
+}
+
+func (ufs *Ufs) Link(req *SrvReq) {
+	fid := req.Fid.Aux.(*ufsFid)
+	dfid := req.Dfid.Aux.(*ufsFid)
+	newpath, err := dfid.child(req.Tc.Name)
+	if err != nil {
+		req.RespondError(err)
+		return
+	}
+
+	e := os.Link(fid.path, newpath)
+	if e != nil {
+		req.RespondError(toError(e))
+		return
+	}
+
+	req.RespondRempty()
+}
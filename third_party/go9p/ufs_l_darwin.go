@@ -0,0 +1,70 @@
+// Copyright 2009 The go9p Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package go9p
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// errors of the host whose number is not the one of Linux, the numbers
+// below 35 are the same
+var linuxErrnos = map[syscall.Errno]uint32{
+	syscall.EAGAIN:       EAGAIN,
+	syscall.EDEADLK:      EDEADLK,
+	syscall.ENAMETOOLONG: ENAMETOOLONG,
+	syscall.ENOLCK:       ENOLCK,
+	syscall.ENOSYS:       ENOSYS,
+	syscall.ENOTEMPTY:    ENOTEMPTY,
+	syscall.ELOOP:        ELOOP,
+	syscall.ENOATTR:      ENODATA,
+	syscall.EOPNOTSUPP:   EOPNOTSUPP,
+	syscall.ENOTSUP:      EOPNOTSUPP,
+}
+
+// linuxErrno returns the number of the error for the Linux clients
+func linuxErrno(errno syscall.Errno) uint32 {
+	if errno < 35 {
+		return uint32(errno)
+	}
+
+	if e, ok := linuxErrnos[errno]; ok {
+		return e
+	}
+
+	return EIO
+}
+
+func statTimes(a *Lattr, st *syscall.Stat_t) {
+	a.Atime = Ltime{uint64(st.Atimespec.Sec), uint64(st.Atimespec.Nsec)}
+	a.Mtime = Ltime{uint64(st.Mtimespec.Sec), uint64(st.Mtimespec.Nsec)}
+	a.Ctime = Ltime{uint64(st.Ctimespec.Sec), uint64(st.Ctimespec.Nsec)}
+	a.Btime = Ltime{uint64(st.Birthtimespec.Sec), uint64(st.Birthtimespec.Nsec)}
+	a.Valid |= GetattrBtime
+}
+
+func statfs(path string) (*Statfs, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return nil, err
+	}
+
+	return &Statfs{
+		Type:    st.Type,
+		Bsize:   uint32(st.Bsize),
+		Blocks:  st.Blocks,
+		Bfree:   st.Bfree,
+		Bavail:  uint64(st.Bavail),
+		Files:   st.Files,
+		Ffree:   uint64(st.Ffree),
+		Fsid:    uint64(uint32(st.Fsid.Val[0])) | uint64(uint32(st.Fsid.Val[1]))<<32,
+		Namelen: 255,
+	}, nil
+}
+
+func mknod(path string, mode, major, minor uint32) error {
+	return unix.Mknod(path, mode, int(unix.Mkdev(major, minor)))
+}
@@ -0,0 +1,43 @@
+// Copyright 2009 The go9p Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package go9p
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// the errors of the host are the errors of the client
+func linuxErrno(errno syscall.Errno) uint32 { return uint32(errno) }
+
+func statTimes(a *Lattr, st *syscall.Stat_t) {
+	a.Atime = Ltime{uint64(st.Atim.Sec), uint64(st.Atim.Nsec)}
+	a.Mtime = Ltime{uint64(st.Mtim.Sec), uint64(st.Mtim.Nsec)}
+	a.Ctime = Ltime{uint64(st.Ctim.Sec), uint64(st.Ctim.Nsec)}
+}
+
+func statfs(path string) (*Statfs, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return nil, err
+	}
+
+	return &Statfs{
+		Type:    uint32(st.Type),
+		Bsize:   uint32(st.Bsize),
+		Blocks:  st.Blocks,
+		Bfree:   st.Bfree,
+		Bavail:  st.Bavail,
+		Files:   st.Files,
+		Ffree:   st.Ffree,
+		Fsid:    uint64(uint32(st.Fsid.X__val[0])) | uint64(uint32(st.Fsid.X__val[1]))<<32,
+		Namelen: uint32(st.Namelen),
+	}, nil
+}
+
+func mknod(path string, mode, major, minor uint32) error {
+	return unix.Mknod(path, mode, int(unix.Mkdev(major, minor)))
+}
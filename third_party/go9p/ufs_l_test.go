@@ -0,0 +1,542 @@
+// Copyright 2009 The go9p Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package go9p
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+)
+
+// testClient is a minimal 9P2000.L client, talking to a server over a socketpair,
+// which sends the requests of concurrent callers without waiting for the responses
+type testClient struct {
+	sync.Mutex
+	conn    net.Conn
+	msize   uint32
+	tags    map[uint16]chan *Fcall
+	nexttag uint16
+	nextfid uint32
+}
+
+// socketpair returns the two ends of a unix socket pair
+func socketpair(t testing.TB) (net.Conn, net.Conn) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		t.Fatalf("socketpair: %v", err)
+	}
+
+	conns := []net.Conn{}
+	for i, fd := range fds {
+		f := os.NewFile(uintptr(fd), fmt.Sprintf("socketpair-%d", i))
+		c, err := net.FileConn(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("FileConn: %v", err)
+		}
+		conns = append(conns, c)
+	}
+
+	return conns[0], conns[1]
+}
+
+// newTestClient serves root over a socketpair, and returns a client attached to it
+func newTestClient(t testing.TB, ufs *Ufs) (*testClient, uint32) {
+	if ufs.Root == "" {
+		ufs.Root = t.TempDir()
+	}
+	ufs.Dotl = true
+	ufs.Id = "ufs"
+	if !ufs.Start(ufs) {
+		t.Fatalf("unable to start the server")
+	}
+
+	cconn, sconn := socketpair(t)
+	ufs.NewConn(sconn)
+	c := &testClient{conn: cconn, msize: MSIZE, tags: make(map[uint16]chan *Fcall), nextfid: 1}
+	t.Cleanup(func() { cconn.Close() })
+	go c.recv()
+
+	rc, err := c.rpc(func(fc *Fcall) error { return PackTversion(fc, MSIZE, "9P2000.L") })
+	if err != nil {
+		t.Fatalf("version: %v", err)
+	}
+	if rc.Version != "9P2000.L" {
+		t.Fatalf("server negotiated %q, want 9P2000.L", rc.Version)
+	}
+	c.msize = rc.Msize
+
+	root := c.fid()
+	_, err = c.rpc(func(fc *Fcall) error {
+		return PackTattach(fc, root, NOFID, "", "", uint32(os.Getuid()), true)
+	})
+	if err != nil {
+		t.Fatalf("attach: %v", err)
+	}
+
+	return c, root
+}
+
+func (c *testClient) fid() uint32 { return atomic.AddUint32(&c.nextfid, 1) }
+
+func (c *testClient) recv() {
+	for {
+		hdr := make([]byte, 4)
+		if _, err := io.ReadFull(c.conn, hdr); err != nil {
+			return
+		}
+
+		size, _ := gint32(hdr)
+		buf := make([]byte, size)
+		copy(buf, hdr)
+		if _, err := io.ReadFull(c.conn, buf[4:]); err != nil {
+			return
+		}
+
+		fc, err, _ := Unpack(buf, true)
+		if err != nil {
+			panic(fmt.Sprintf("invalid response %v: %v", buf, err))
+		}
+
+		c.Lock()
+		ch := c.tags[fc.Tag]
+		delete(c.tags, fc.Tag)
+		c.Unlock()
+		ch <- fc
+	}
+}
+
+// rpc sends the request created by pack, and returns its response
+func (c *testClient) rpc(pack func(*Fcall) error) (*Fcall, error) {
+	tc := NewFcall(c.msize)
+	if err := pack(tc); err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *Fcall, 1)
+	c.Lock()
+	tag := NOTAG
+	if tc.Type != Tversion {
+		tag = c.nexttag
+		c.nexttag = (c.nexttag + 1) % NOTAG
+	}
+	c.tags[tag] = ch
+	SetTag(tc, tag)
+	_, err := c.conn.Write(tc.Pkt)
+	c.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	rc := <-ch
+	switch {
+	case rc.Type == Rlerror:
+		return nil, syscall.Errno(rc.Errornum)
+	case rc.Type == Rerror:
+		return nil, &Error{rc.Error, rc.Errornum}
+	case rc.Type != tc.Type+1:
+		return nil, fmt.Errorf("unexpected response %d to %d", rc.Type, tc.Type)
+	}
+
+	return rc, nil
+}
+
+func (c *testClient) walk(t testing.TB, fid uint32, names ...string) uint32 {
+	t.Helper()
+	newfid := c.fid()
+	rc, err := c.rpc(func(fc *Fcall) error { return PackTwalk(fc, fid, newfid, names) })
+	if err != nil {
+		t.Fatalf("walk %v: %v", names, err)
+	}
+	if len(rc.Wqid) != len(names) {
+		t.Fatalf("walk %v: walked %d names", names, len(rc.Wqid))
+	}
+
+	return newfid
+}
+
+func (c *testClient) open(t testing.TB, fid uint32, flags uint32) {
+	t.Helper()
+	if _, err := c.rpc(func(fc *Fcall) error { return PackTlopen(fc, fid, flags) }); err != nil {
+		t.Fatalf("lopen: %v", err)
+	}
+}
+
+func (c *testClient) read(t testing.TB, fid uint32, offset uint64, count uint32) []byte {
+	t.Helper()
+	rc, err := c.rpc(func(fc *Fcall) error { return PackTread(fc, fid, offset, count) })
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	return rc.Data
+}
+
+func (c *testClient) write(t testing.TB, fid uint32, offset uint64, data []byte) {
+	t.Helper()
+	_, err := c.rpc(func(fc *Fcall) error { return PackTwrite(fc, fid, offset, uint32(len(data)), data) })
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func (c *testClient) getattr(t testing.TB, fid uint32) *Lattr {
+	t.Helper()
+	rc, err := c.rpc(func(fc *Fcall) error { return PackTgetattr(fc, fid, GetattrAll) })
+	if err != nil {
+		t.Fatalf("getattr: %v", err)
+	}
+
+	return &rc.Attr
+}
+
+func (c *testClient) readdir(t testing.TB, fid uint32) []string {
+	t.Helper()
+	names := []string{}
+	offset := uint64(0)
+	for {
+		rc, err := c.rpc(func(fc *Fcall) error { return PackTreaddir(fc, fid, offset, 512) })
+		if err != nil {
+			t.Fatalf("readdir: %v", err)
+		}
+		dirents, err := UnpackDirents(rc.Data)
+		if err != nil {
+			t.Fatalf("readdir: %v", err)
+		}
+		if len(dirents) == 0 {
+			return names
+		}
+		for _, d := range dirents {
+			names = append(names, d.Name)
+		}
+		offset = dirents[len(dirents)-1].Offset
+	}
+}
+
+func (c *testClient) clunk(t testing.TB, fid uint32) {
+	t.Helper()
+	if _, err := c.rpc(func(fc *Fcall) error { return PackTclunk(fc, fid) }); err != nil {
+		t.Fatalf("clunk: %v", err)
+	}
+}
+
+func TestUfsDotlFiles(t *testing.T) {
+	ufs := &Ufs{Owner: &FileOwner{Uid: 1000, Gid: 1001}}
+	c, root := newTestClient(t, ufs)
+
+	// mkdir and lcreate
+	if _, err := c.rpc(func(fc *Fcall) error { return PackTmkdir(fc, root, "dir", 0o755, 0) }); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	dir := c.walk(t, root, "dir")
+	file := c.walk(t, dir)
+	if _, err := c.rpc(func(fc *Fcall) error { return PackTlcreate(fc, file, "file", 2, 0o640, 0) }); err != nil {
+		t.Fatalf("lcreate: %v", err)
+	}
+	c.write(t, file, 0, []byte("hello world"))
+
+	a := c.getattr(t, file)
+	if a.Size != 11 || a.Mode != syscall.S_IFREG|0o640 || a.Uid != 1000 || a.Gid != 1001 {
+		t.Errorf("unexpected attributes: size %d, mode %o, owner %d:%d", a.Size, a.Mode, a.Uid, a.Gid)
+	}
+
+	// truncate
+	if _, err := c.rpc(func(fc *Fcall) error { return PackTsetattr(fc, file, &SetAttr{Valid: SetattrSize, Size: 5}) }); err != nil {
+		t.Fatalf("setattr: %v", err)
+	}
+	if got := c.getattr(t, file).Size; got != 5 {
+		t.Errorf("size after truncate = %d, want 5", got)
+	}
+	c.clunk(t, file)
+
+	// symlink and readlink
+	if _, err := c.rpc(func(fc *Fcall) error { return PackTsymlink(fc, dir, "link", "file", 0) }); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	link := c.walk(t, dir, "link")
+	rc, err := c.rpc(func(fc *Fcall) error { return PackTfid(fc, Treadlink, link) })
+	if err != nil {
+		t.Fatalf("readlink: %v", err)
+	}
+	if rc.Target != "file" {
+		t.Errorf("readlink = %q, want file", rc.Target)
+	}
+	c.clunk(t, link)
+
+	// readdir
+	c.open(t, dir, 0)
+	names := c.readdir(t, dir)
+	sort.Strings(names)
+	if fmt.Sprint(names) != "[. .. file link]" {
+		t.Errorf("readdir = %v", names)
+	}
+
+	// renameat and unlinkat
+	if _, err := c.rpc(func(fc *Fcall) error { return PackTrenameat(fc, dir, "file", root, "moved") }); err != nil {
+		t.Fatalf("renameat: %v", err)
+	}
+	if b, err := os.ReadFile(filepath.Join(ufs.Root, "moved")); err != nil || string(b) != "hello" {
+		t.Errorf("renamed file = %q, %v", b, err)
+	}
+	if _, err := c.rpc(func(fc *Fcall) error { return PackTunlinkat(fc, root, "dir", AtRemovedir) }); err != syscall.ENOTEMPTY {
+		t.Errorf("unlinkat of a non empty directory = %v, want ENOTEMPTY", err)
+	}
+	if _, err := c.rpc(func(fc *Fcall) error { return PackTunlinkat(fc, dir, "link", 0) }); err != nil {
+		t.Errorf("unlinkat: %v", err)
+	}
+
+	// names can't leave the directory
+	if _, err := c.rpc(func(fc *Fcall) error { return PackTmkdir(fc, dir, "../escape", 0o755, 0) }); err != syscall.EINVAL {
+		t.Errorf("mkdir ../escape = %v, want EINVAL", err)
+	}
+
+	// errors are the ones of Linux
+	if _, err := c.rpc(func(fc *Fcall) error { return PackTwalk(fc, root, c.fid(), []string{"missing"}) }); err != syscall.ENOENT {
+		t.Errorf("walk to a missing file = %v, want ENOENT", err)
+	}
+}
+
+func TestUfsDotlLock(t *testing.T) {
+	c, root := newTestClient(t, &Ufs{})
+	file := c.walk(t, root)
+	if _, err := c.rpc(func(fc *Fcall) error { return PackTlcreate(fc, file, "file", 2, 0o644, 0) }); err != nil {
+		t.Fatalf("lcreate: %v", err)
+	}
+
+	lock := func(typ uint8, proc uint32, start, length uint64) uint8 {
+		rc, err := c.rpc(func(fc *Fcall) error {
+			return PackTlock(fc, Tlock, file, &Lock{Type: typ, Start: start, Length: length, ProcId: proc, ClientId: "node"})
+		})
+		if err != nil {
+			t.Fatalf("lock: %v", err)
+		}
+		return rc.Status
+	}
+	getlock := func(typ uint8, proc uint32) *Lock {
+		rc, err := c.rpc(func(fc *Fcall) error {
+			return PackTlock(fc, Tgetlock, file, &Lock{Type: typ, ProcId: proc, ClientId: "node"})
+		})
+		if err != nil {
+			t.Fatalf("getlock: %v", err)
+		}
+		return &rc.Lock
+	}
+
+	if st := lock(LockTypeWrlck, 1, 0, 10); st != LockSuccess {
+		t.Fatalf("write lock = %d, want success", st)
+	}
+	if st := lock(LockTypeRdlck, 2, 5, 10); st != LockBlocked {
+		t.Errorf("conflicting read lock = %d, want blocked", st)
+	}
+	if st := lock(LockTypeRdlck, 2, 10, 10); st != LockSuccess {
+		t.Errorf("read lock after the write lock = %d, want success", st)
+	}
+	if l := getlock(LockTypeWrlck, 3); l.Type != LockTypeWrlck || l.ProcId != 1 || l.Length != 10 {
+		t.Errorf("getlock = %+v, want the write lock of process 1", l)
+	}
+
+	// unlocking a part of the range keeps the rest
+	if st := lock(LockTypeUnlck, 1, 0, 5); st != LockSuccess {
+		t.Fatalf("unlock = %d, want success", st)
+	}
+	if st := lock(LockTypeWrlck, 2, 0, 5); st != LockSuccess {
+		t.Errorf("write lock of the unlocked range = %d, want success", st)
+	}
+	if st := lock(LockTypeWrlck, 3, 5, 1); st != LockBlocked {
+		t.Errorf("write lock of the locked range = %d, want blocked", st)
+	}
+	if l := getlock(LockTypeRdlck, 1); l.Type != LockTypeWrlck || l.ProcId != 2 {
+		t.Errorf("getlock = %+v, want the write lock of process 2", l)
+	}
+}
+
+func TestUfsConcurrentReads(t *testing.T) {
+	ufs := &Ufs{}
+	ufs.Root = t.TempDir()
+	data := make([]byte, 1<<20)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	if err := os.WriteFile(filepath.Join(ufs.Root, "data"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, root := newTestClient(t, ufs)
+	file := c.walk(t, root, "data")
+	c.open(t, file, 0)
+
+	// the reads of a fid are processed concurrently
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for off := w * 4096; off < len(data); off += 8 * 4096 {
+				if got := c.read(t, file, uint64(off), 4096); !bytes.Equal(got, data[off:off+4096]) {
+					t.Errorf("unexpected data at %d", off)
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// the writes through another fid are seen
+	writer := c.walk(t, root, "data")
+	c.open(t, writer, 2)
+	c.write(t, writer, 0, []byte("changed"))
+	if got := c.read(t, file, 0, 7); string(got) != "changed" {
+		t.Errorf("read after write = %q, want changed", got)
+	}
+	if got := c.read(t, file, uint64(len(data)-10), 4096); !bytes.Equal(got, data[len(data)-10:]) {
+		t.Errorf("unexpected data at the end of the file: %v", got)
+	}
+}
+
+func TestUfsLegacyVersion(t *testing.T) {
+	ufs := &Ufs{}
+	ufs.Root = t.TempDir()
+	ufs.Id = "ufs"
+	ufs.Start(ufs)
+	cconn, sconn := socketpair(t)
+	defer cconn.Close()
+	ufs.NewConn(sconn)
+	c := &testClient{conn: cconn, msize: MSIZE, tags: make(map[uint16]chan *Fcall)}
+	go c.recv()
+
+	rc, err := c.rpc(func(fc *Fcall) error { return PackTversion(fc, MSIZE, "9P2000.L") })
+	if err != nil {
+		t.Fatalf("version: %v", err)
+	}
+	if rc.Version != "9P2000" {
+		t.Errorf("server without 9P2000.L negotiated %q, want 9P2000", rc.Version)
+	}
+}
+
+// The benchmarks measure the server over a socketpair, as seen by a client:
+//
+//	go test ./third_party/go9p -run xxx -bench . -benchmem
+func benchmarkRead(b *testing.B, ufs *Ufs, size int, count uint32) {
+	ufs.Root = b.TempDir()
+	if err := os.WriteFile(filepath.Join(ufs.Root, "data"), make([]byte, size), 0o644); err != nil {
+		b.Fatal(err)
+	}
+	c, root := newTestClient(b, ufs)
+	file := c.walk(b, root, "data")
+	c.open(b, file, 0)
+
+	b.SetBytes(int64(count))
+	b.ResetTimer()
+	off := 0
+	for i := 0; i < b.N; i++ {
+		c.read(b, file, uint64(off), count)
+		off = (off + int(count)) % size
+	}
+}
+
+func BenchmarkUfsRead(b *testing.B) {
+	for _, count := range []uint32{4096, 128 * 1024} {
+		b.Run(fmt.Sprintf("count=%d", count), func(b *testing.B) {
+			benchmarkRead(b, &Ufs{}, 16<<20, count)
+		})
+	}
+}
+
+func BenchmarkUfsParallelRead(b *testing.B) {
+	ufs := &Ufs{}
+	ufs.Root = b.TempDir()
+	if err := os.WriteFile(filepath.Join(ufs.Root, "data"), make([]byte, 16<<20), 0o644); err != nil {
+		b.Fatal(err)
+	}
+	c, root := newTestClient(b, ufs)
+
+	b.SetBytes(64 * 1024)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		file := c.walk(b, root, "data")
+		c.open(b, file, 0)
+		off := 0
+		for pb.Next() {
+			c.read(b, file, uint64(off), 64*1024)
+			off = (off + 64*1024) % (16 << 20)
+		}
+	})
+}
+
+// benchmarkTree creates a tree of directories like the ones of node_modules
+func benchmarkTree(b *testing.B, root string) []string {
+	paths := []string{}
+	for i := 0; i < 50; i++ {
+		dir := filepath.Join(root, fmt.Sprintf("pkg%d", i), "lib")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			b.Fatal(err)
+		}
+		for j := 0; j < 20; j++ {
+			name := fmt.Sprintf("file%d.js", j)
+			if err := os.WriteFile(filepath.Join(dir, name), []byte("module.exports = {}"), 0o644); err != nil {
+				b.Fatal(err)
+			}
+			paths = append(paths, filepath.Join(fmt.Sprintf("pkg%d", i), "lib", name))
+		}
+	}
+
+	return paths
+}
+
+// BenchmarkUfsLookup walks to the files of a tree and gets their attributes,
+// as the build tools do
+func BenchmarkUfsLookup(b *testing.B) {
+	ufs := &Ufs{}
+	ufs.Root = b.TempDir()
+	paths := benchmarkTree(b, ufs.Root)
+	c, root := newTestClient(b, ufs)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fid := c.walk(b, root, strings.Split(paths[i%len(paths)], "/")...)
+		c.getattr(b, fid)
+		c.clunk(b, fid)
+	}
+}
+
+func BenchmarkUfsReaddir(b *testing.B) {
+	ufs := &Ufs{}
+	ufs.Root = b.TempDir()
+	for i := 0; i < 1000; i++ {
+		if err := os.WriteFile(filepath.Join(ufs.Root, fmt.Sprintf("file%d", i)), nil, 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	c, root := newTestClient(b, ufs)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dir := c.walk(b, root)
+		c.open(b, dir, 0)
+		offset := uint64(0)
+		for {
+			rc, err := c.rpc(func(fc *Fcall) error { return PackTreaddir(fc, dir, offset, 64*1024) })
+			if err != nil {
+				b.Fatalf("readdir: %v", err)
+			}
+			dirents, _ := UnpackDirents(rc.Data)
+			if len(dirents) == 0 {
+				break
+			}
+			offset = dirents[len(dirents)-1].Offset
+		}
+		c.clunk(b, dir)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2009 The go9p Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package go9p
+
+import (
+	"math"
+	"sync"
+)
+
+// lockOwner identifies the owner of a POSIX lock: a process of a client
+type lockOwner struct {
+	conn     *Conn
+	clientId string
+	procId   uint32
+}
+
+// lockRange is a lock of the bytes from start to end (excluded) of a file
+type lockRange struct {
+	owner lockOwner
+	typ   uint8
+	start uint64
+	end   uint64
+}
+
+func (l *lockRange) overlaps(o *lockRange) bool {
+	return l.start < o.end && o.start < l.end
+}
+
+// lockTable keeps the POSIX locks taken with Tlock. The locks are held by
+// the server, the clients see the locks of each other but not the ones
+// of the processes of the host.
+type lockTable struct {
+	sync.Mutex
+	files map[string][]lockRange
+}
+
+func newLockTable() *lockTable {
+	return &lockTable{files: make(map[string][]lockRange)}
+}
+
+func newLockRange(owner lockOwner, l *Lock) lockRange {
+	end := uint64(math.MaxUint64)
+	if l.Length != 0 && l.Start+l.Length > l.Start {
+		end = l.Start + l.Length
+	}
+
+	return lockRange{owner: owner, typ: l.Type, start: l.Start, end: end}
+}
+
+// conflict returns a lock of another owner preventing to take the lock, or nil
+func (t *lockTable) conflict(path string, l *lockRange) *lockRange {
+	for i := range t.files[path] {
+		o := &t.files[path][i]
+		if o.owner != l.owner && o.overlaps(l) && (o.typ == LockTypeWrlck || l.typ == LockTypeWrlck) {
+			return o
+		}
+	}
+
+	return nil
+}
+
+// lock takes or releases a lock, replacing the locks of the owner in its range,
+// and returns the status of Rlock. Taking a lock never blocks, the clients try
+// again when it is not available.
+func (t *lockTable) lock(path string, owner lockOwner, l *Lock) uint8 {
+	r := newLockRange(owner, l)
+	t.Lock()
+	defer t.Unlock()
+	if r.typ != LockTypeUnlck && t.conflict(path, &r) != nil {
+		return LockBlocked
+	}
+
+	locks := []lockRange{}
+	for _, o := range t.files[path] {
+		if o.owner != owner || !o.overlaps(&r) {
+			locks = append(locks, o)
+			continue
+		}
+
+		if o.start < r.start {
+			left := o
+			left.end = r.start
+			locks = append(locks, left)
+		}
+
+		if o.end > r.end {
+			right := o
+			right.start = r.end
+			locks = append(locks, right)
+		}
+	}
+
+	if r.typ != LockTypeUnlck {
+		locks = append(locks, r)
+	}
+
+	if len(locks) == 0 {
+		delete(t.files, path)
+	} else {
+		t.files[path] = locks
+	}
+
+	return LockSuccess
+}
+
+// getlock returns the lock preventing to take the lock, or a lock of type
+// LockTypeUnlck if it can be taken
+func (t *lockTable) getlock(path string, owner lockOwner, l *Lock) *Lock {
+	r := newLockRange(owner, l)
+	t.Lock()
+	defer t.Unlock()
+	o := t.conflict(path, &r)
+	if o == nil {
+		return &Lock{Type: LockTypeUnlck, Start: l.Start, Length: l.Length, ProcId: l.ProcId, ClientId: l.ClientId}
+	}
+
+	length := uint64(0)
+	if o.end != math.MaxUint64 {
+		length = o.end - o.start
+	}
+
+	return &Lock{Type: o.typ, Start: o.start, Length: length, ProcId: o.owner.procId, ClientId: o.owner.clientId}
+}
+
+// releaseConn releases the locks taken through a connection
+func (t *lockTable) releaseConn(conn *Conn) {
+	t.Lock()
+	defer t.Unlock()
+	for path, locks := range t.files {
+		kept := []lockRange{}
+		for _, l := range locks {
+			if l.owner.conn != conn {
+				kept = append(kept, l)
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(t.files, path)
+		} else {
+			t.files[path] = kept
+		}
+	}
+}
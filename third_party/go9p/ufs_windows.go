@@ -5,7 +5,9 @@
 package go9p
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path"
 	"strings"
@@ -135,7 +137,7 @@ func (dir *ufsDir) dotu(path string, d os.FileInfo, upool Users, sysMode *syscal
 
 func (u *Ufs) Wstat(req *SrvReq) {
 	fid := req.Fid.Aux.(*ufsFid)
-	err := fid.stat()
+	_, err := u.lstat(fid.path)
 	if err != nil {
 		req.RespondError(err)
 		return
@@ -247,3 +249,17 @@ func (u *Ufs) Wstat(req *SrvReq) {
 
 	req.RespondRwstat()
 }
+
+// linuxErrno returns the number of the error for the Linux clients
+func linuxErrno(errno syscall.Errno) uint32 {
+	switch {
+	case errors.Is(errno, fs.ErrNotExist):
+		return ENOENT
+	case errors.Is(errno, fs.ErrExist):
+		return EEXIST
+	case errors.Is(errno, fs.ErrPermission):
+		return EACCES
+	}
+
+	return EIO
+}
@@ -38,6 +38,14 @@ func Unpack(buf []byte, dotu bool) (fc *Fcall, err error, fcsz int) {
 	p = p[0 : fc.Size-7]
 	fc.Pkt = buf[0:fc.Size]
 	fcsz = int(fc.Size)
+	if IsDotl(fc.Type) {
+		if _, err = unpackl(fc, p); err != nil {
+			return nil, err, 0
+		}
+
+		return
+	}
+
 	if fc.Type < Tversion || fc.Type >= Tlast {
 		return nil, &Error{"invalid id", EINVAL}, 0
 	}